@@ -9,6 +9,8 @@ import (
 	"github.com/kelseyhightower/envconfig"
 	"github.com/kernkw/hhapp/internal/config"
 	"github.com/kernkw/hhapp/internal/data"
+	"github.com/kernkw/hhapp/internal/geo"
+	"github.com/kernkw/hhapp/internal/mail"
 	"github.com/kernkw/hhapp/internal/route"
 )
 
@@ -30,7 +32,22 @@ func main() {
 		panic(err)
 	}
 
-	router := route.NewRouter(db)
+	mailer, err := mail.NewMailer(cfg)
+	if err != nil {
+		panic(err)
+	}
+
+	geocoder, err := geo.NewGeocoder(cfg)
+	if err != nil {
+		panic(err)
+	}
+
+	zones, err := geo.NewTimeZones(cfg)
+	if err != nil {
+		panic(err)
+	}
+
+	router := route.NewRouter(db, mailer, geocoder, zones, cfg)
 	// bind := fmt.Sprintf("%s:%d", cfg.Addr, cfg.Port)
 	bind := fmt.Sprintf("%s:%d", "localhost", cfg.Port)
 	log.Printf("serving http on %s", bind)
@@ -9,6 +9,12 @@ CREATE TABLE `user` (
   `updated_at` datetime DEFAULT NULL,
   `created_at` datetime DEFAULT NULL,
   `email` varchar(256) DEFAULT NULL,
+  `email_verified_at` datetime DEFAULT NULL,
+  `roles` set('admin','venue_owner','patron') NOT NULL DEFAULT 'patron',
+  `totp_secret` varchar(64) DEFAULT NULL,
+  `totp_enabled_at` datetime DEFAULT NULL,
+  `totp_last_step` bigint DEFAULT NULL,
+  `token_generation` int(11) NOT NULL DEFAULT 0,
   PRIMARY KEY (`id`),
   UNIQUE KEY `username_unique` (`username`),
   KEY `user_username_index` (`username`),
@@ -25,12 +31,20 @@ CREATE TABLE `venue` (
   `state` varchar(100) COLLATE utf8_unicode_ci DEFAULT NULL,
   `zip` varchar(30) COLLATE utf8_unicode_ci DEFAULT NULL,
   `country` varchar(5) CHARACTER SET utf8 DEFAULT NULL,
+  `latitude` decimal(9,6) DEFAULT NULL,
+  `longitude` decimal(9,6) DEFAULT NULL,
+  `time_zone` varchar(64) DEFAULT NULL,
   `image` text COLLATE utf8_unicode_ci DEFAULT NULL,
+  `owner_id` int(11) DEFAULT NULL,
   `updated_at` datetime DEFAULT NULL,
   `created_at` datetime DEFAULT NULL,
   PRIMARY KEY (`id`),
   UNIQUE KEY `name_unique` (`name`),
-  KEY `venue_name_index` (`name`)
+  KEY `venue_name_index` (`name`),
+  KEY `venue_location_index` (`latitude`, `longitude`),
+  FOREIGN KEY (owner_id)
+        REFERENCES user(id)
+        ON DELETE SET NULL
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8 COLLATE=utf8_unicode_ci;
 
 CREATE TABLE `venue_list` (
@@ -59,22 +73,28 @@ CREATE TABLE `venue_lists` (
 CREATE TABLE `user_favorites` (
   `id` int(11) NOT NULL AUTO_INCREMENT,
   `venue_id` int(11) NOT NULL,
-  `user_id` varchar(100) NOT NULL,
+  `user_id` int(11) NOT NULL,
   `updated_at` datetime DEFAULT NULL,
   `created_at` datetime DEFAULT NULL,
   PRIMARY KEY (`id`),
   FOREIGN KEY (venue_id)
         REFERENCES venue(id)
         ON DELETE CASCADE,
+  FOREIGN KEY (user_id)
+        REFERENCES user(id)
+        ON DELETE CASCADE,
   UNIQUE INDEX `user_favorite_unique` (`venue_id`, `user_id`)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8 COLLATE=utf8_unicode_ci;
 
 CREATE TABLE `menu` (
   `id` int(11) NOT NULL AUTO_INCREMENT,
   `venue_id` int(11) NOT NULL,
+  `name` varchar(100) COLLATE utf8_unicode_ci NOT NULL DEFAULT 'Happy Hour',
+  `type` enum('happy_hour','late_night','brunch','other') COLLATE utf8_unicode_ci NOT NULL DEFAULT 'happy_hour',
   `updated_at` datetime DEFAULT NULL,
   `created_at` datetime DEFAULT NULL,
   PRIMARY KEY (`id`),
+  UNIQUE KEY `menu_venue_name_unique` (`venue_id`, `name`),
   FOREIGN KEY (venue_id)
         REFERENCES venue(id)
         ON DELETE CASCADE
@@ -84,11 +104,15 @@ CREATE TABLE `menu_item` (
   `id` int(11) NOT NULL AUTO_INCREMENT,
   `menu_id` int(11) NOT NULL,
   `category` enum('drink','food','all') COLLATE utf8_unicode_ci DEFAULT NULL,
-  `price` double DEFAULT NULL,
+  `price` bigint NOT NULL DEFAULT '0',
+  `regular_price` bigint DEFAULT NULL,
+  `currency` char(3) COLLATE utf8_unicode_ci NOT NULL DEFAULT 'USD',
   `description` text COLLATE utf8_unicode_ci,
+  `position` int(11) NOT NULL DEFAULT '0',
   `updated_at` datetime DEFAULT NULL,
   `created_at` datetime DEFAULT NULL,
   PRIMARY KEY (`id`),
+  KEY `menu_item_menu_position` (`menu_id`, `position`),
   FOREIGN KEY (menu_id)
         REFERENCES menu(id)
         ON DELETE CASCADE
@@ -107,6 +131,7 @@ CREATE TABLE `notification` (
         REFERENCES venue_list(id),
   FOREIGN KEY (favorites_id)
         REFERENCES user_favorites(id)
+        ON DELETE CASCADE
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8 COLLATE=utf8_unicode_ci;
 
 CREATE TABLE `user_notifications` (
@@ -119,7 +144,8 @@ CREATE TABLE `user_notifications` (
   PRIMARY KEY (`id`),
   FOREIGN KEY (user_id)
         REFERENCES user(id)
-        ON DELETE CASCADE
+        ON DELETE CASCADE,
+  UNIQUE INDEX `user_notification_unique` (`user_id`, `notification_id`)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8 COLLATE=utf8_unicode_ci;
 
 CREATE TABLE `menu_datetime` (
@@ -131,13 +157,130 @@ CREATE TABLE `menu_datetime` (
   `thu` tinyint(1) DEFAULT '0',
   `fri` tinyint(1) DEFAULT '0',
   `sat` tinyint(1) DEFAULT '0',
-  `sunday` tinyint(1) DEFAULT '0',
-  `start_at` timestamp DEFAULT 0,
-  `end_at` timestamp DEFAULT 0,
+  `sun` tinyint(1) DEFAULT '0',
+  `start_time` time NOT NULL DEFAULT '00:00:00',
+  `end_time` time NOT NULL DEFAULT '00:00:00',
+  `overnight` tinyint(1) NOT NULL DEFAULT '0',
+  `updated_at` datetime DEFAULT NULL,
+  `created_at` datetime DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  FOREIGN KEY (menu_id)
+        REFERENCES menu(id)
+        ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8 COLLATE=utf8_unicode_ci;
+
+CREATE TABLE `menu_exception` (
+  `id` int(11) NOT NULL AUTO_INCREMENT,
+  `menu_id` int(11) NOT NULL,
+  `kind` enum('special','blackout') COLLATE utf8_unicode_ci NOT NULL,
+  `start_date` date NOT NULL,
+  `end_date` date NOT NULL,
+  `start_time` time NOT NULL DEFAULT '00:00:00',
+  `end_time` time NOT NULL DEFAULT '00:00:00',
+  `overnight` tinyint(1) NOT NULL DEFAULT '0',
+  `note` varchar(255) COLLATE utf8_unicode_ci NOT NULL DEFAULT '',
   `updated_at` datetime DEFAULT NULL,
   `created_at` datetime DEFAULT NULL,
   PRIMARY KEY (`id`),
+  KEY `menu_exception_menu_dates` (`menu_id`, `start_date`),
   FOREIGN KEY (menu_id)
         REFERENCES menu(id)
         ON DELETE CASCADE
-) ENGINE=InnoDB DEFAULT CHARSET=utf8 COLLATE=utf8_unicode_ci;
\ No newline at end of file
+) ENGINE=InnoDB DEFAULT CHARSET=utf8 COLLATE=utf8_unicode_ci;
+
+CREATE TABLE `menu_change` (
+  `id` int(11) NOT NULL AUTO_INCREMENT,
+  `venue_id` int(11) NOT NULL,
+  `menu_id` int(11) NOT NULL,
+  `entity` enum('menu','menu_item','schedule','exception') COLLATE utf8_unicode_ci NOT NULL,
+  `entity_id` int(11) NOT NULL,
+  `action` enum('create','update','delete') COLLATE utf8_unicode_ci NOT NULL,
+  `user_id` int(11) DEFAULT NULL,
+  `old_value` text COLLATE utf8_unicode_ci,
+  `new_value` text COLLATE utf8_unicode_ci,
+  `created_at` datetime NOT NULL,
+  PRIMARY KEY (`id`),
+  KEY `menu_change_venue_created` (`venue_id`, `created_at`),
+  FOREIGN KEY (user_id)
+        REFERENCES user(id)
+        ON DELETE SET NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8 COLLATE=utf8_unicode_ci;
+
+CREATE TABLE `refresh_token` (
+  `id` int(11) NOT NULL AUTO_INCREMENT,
+  `user_id` int(11) NOT NULL,
+  `token_hash` char(64) NOT NULL,
+  `expires_at` datetime NOT NULL,
+  `revoked_at` datetime DEFAULT NULL,
+  `updated_at` datetime DEFAULT NULL,
+  `created_at` datetime DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `token_hash_unique` (`token_hash`),
+  KEY `refresh_token_user_id_index` (`user_id`),
+  FOREIGN KEY (user_id)
+        REFERENCES user(id)
+        ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8 COLLATE=utf8_unicode_ci;
+
+CREATE TABLE `password_reset` (
+  `id` int(11) NOT NULL AUTO_INCREMENT,
+  `user_id` int(11) NOT NULL,
+  `token_hash` char(64) NOT NULL,
+  `expires_at` datetime NOT NULL,
+  `used_at` datetime DEFAULT NULL,
+  `updated_at` datetime DEFAULT NULL,
+  `created_at` datetime DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `token_hash_unique` (`token_hash`),
+  FOREIGN KEY (user_id)
+        REFERENCES user(id)
+        ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8 COLLATE=utf8_unicode_ci;
+
+CREATE TABLE `api_key` (
+  `id` int(11) NOT NULL AUTO_INCREMENT,
+  `user_id` int(11) NOT NULL,
+  `name` varchar(100) COLLATE utf8_unicode_ci NOT NULL,
+  `prefix` varchar(16) NOT NULL,
+  `key_hash` char(64) NOT NULL,
+  `scope` enum('read','write') NOT NULL DEFAULT 'read',
+  `created_by` int(11) NOT NULL,
+  `last_used_at` datetime DEFAULT NULL,
+  `revoked_at` datetime DEFAULT NULL,
+  `updated_at` datetime DEFAULT NULL,
+  `created_at` datetime DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `key_hash_unique` (`key_hash`),
+  FOREIGN KEY (user_id)
+        REFERENCES user(id)
+        ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8 COLLATE=utf8_unicode_ci;
+
+CREATE TABLE `recovery_code` (
+  `id` int(11) NOT NULL AUTO_INCREMENT,
+  `user_id` int(11) NOT NULL,
+  `code_hash` char(64) NOT NULL,
+  `used_at` datetime DEFAULT NULL,
+  `updated_at` datetime DEFAULT NULL,
+  `created_at` datetime DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE INDEX `recovery_code_unique` (`user_id`, `code_hash`),
+  FOREIGN KEY (user_id)
+        REFERENCES user(id)
+        ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8 COLLATE=utf8_unicode_ci;
+
+CREATE TABLE `user_identity` (
+  `id` int(11) NOT NULL AUTO_INCREMENT,
+  `user_id` int(11) NOT NULL,
+  `provider` varchar(64) NOT NULL,
+  `subject` varchar(255) NOT NULL,
+  `email` varchar(256) DEFAULT NULL,
+  `updated_at` datetime DEFAULT NULL,
+  `created_at` datetime DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `provider_subject_unique` (`provider`, `subject`),
+  FOREIGN KEY (user_id)
+        REFERENCES user(id)
+        ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8 COLLATE=utf8_unicode_ci;
@@ -0,0 +1,22 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/kernkw/hhapp/internal/schema"
+)
+
+type contextKey int
+
+const userKey contextKey = 0
+
+// NewContext returns a copy of ctx carrying the authenticated user.
+func NewContext(ctx context.Context, user schema.User) context.Context {
+	return context.WithValue(ctx, userKey, user)
+}
+
+// FromContext returns the authenticated user stored in ctx, if any.
+func FromContext(ctx context.Context) (schema.User, bool) {
+	user, ok := ctx.Value(userKey).(schema.User)
+	return user, ok
+}
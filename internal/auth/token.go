@@ -0,0 +1,170 @@
+// Package auth issues and verifies the signed bearer tokens handed out by
+// /authenticate.
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/kernkw/hhapp/internal/schema"
+)
+
+var ErrInvalidToken = errors.New("invalid token")
+var ErrExpiredToken = errors.New("token has expired")
+var ErrRevokedToken = errors.New("token has been revoked")
+
+// header is the fixed JWT header for HS256 signed tokens.
+var header = base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+
+// Token purposes. Access tokens have no purpose so that a token minted for
+// anything else is never accepted as a bearer token.
+const (
+	PurposeAccess      = ""
+	PurposeVerifyEmail = "verify_email"
+	// PurposeTwoFactor tokens prove the password step of a two-step login
+	// and are exchanged for a session once the second factor checks out.
+	PurposeTwoFactor = "two_factor"
+	// PurposeOIDCState tokens ride along with the state of an OIDC login,
+	// carrying the user to link to when the flow was started signed in.
+	PurposeOIDCState = "oidc_state"
+)
+
+// Claims is the payload carried by a token. Generation is the user's token
+// generation when it was issued; access tokens are rejected once that has
+// moved on.
+type Claims struct {
+	UserID     int           `json:"sub"`
+	UserName   string        `json:"username"`
+	Email      string        `json:"email"`
+	Roles      []schema.Role `json:"roles,omitempty"`
+	Generation int           `json:"gen"`
+	Purpose    string        `json:"purpose,omitempty"`
+	IssuedAt   int64         `json:"iat"`
+	ExpiresAt  int64         `json:"exp"`
+}
+
+// User returns the user identified by the claims.
+func (c Claims) User() schema.User {
+	return schema.User{ID: c.UserID, UserName: c.UserName, Email: c.Email, Roles: c.Roles, TokenGeneration: c.Generation}
+}
+
+// Signer signs and verifies HS256 access tokens with a shared secret and
+// mints the opaque refresh tokens that accompany them.
+type Signer struct {
+	secret     []byte
+	ttl        time.Duration
+	refreshTTL time.Duration
+	now        func() time.Time
+}
+
+func NewSigner(secret string, ttl, refreshTTL time.Duration) *Signer {
+	return &Signer{secret: []byte(secret), ttl: ttl, refreshTTL: refreshTTL, now: time.Now}
+}
+
+// Sign issues an access token for user that expires after the signer's TTL.
+func (s *Signer) Sign(user schema.User) (string, time.Time, error) {
+	return s.SignPurpose(user, PurposeAccess, s.ttl)
+}
+
+// Verify checks the signature and expiry of an access token and returns its
+// claims.
+func (s *Signer) Verify(token string) (Claims, error) {
+	return s.VerifyPurpose(token, PurposeAccess)
+}
+
+// SignPurpose issues a token for user that is only valid for purpose and
+// expires after ttl.
+func (s *Signer) SignPurpose(user schema.User, purpose string, ttl time.Duration) (string, time.Time, error) {
+	now := s.now().UTC()
+	exp := now.Add(ttl)
+	c := Claims{
+		UserID:     user.ID,
+		UserName:   user.UserName,
+		Email:      user.Email,
+		Roles:      user.Roles,
+		Generation: user.TokenGeneration,
+		Purpose:    purpose,
+		IssuedAt:   now.Unix(),
+		ExpiresAt:  exp.Unix(),
+	}
+	b, err := json.Marshal(c)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	unsigned := header + "." + base64.RawURLEncoding.EncodeToString(b)
+	return unsigned + "." + s.signature(unsigned), exp, nil
+}
+
+// VerifyPurpose checks the signature, expiry and purpose of token and
+// returns its claims.
+func (s *Signer) VerifyPurpose(token, purpose string) (Claims, error) {
+	var c Claims
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 || parts[0] != header {
+		return c, ErrInvalidToken
+	}
+	want := s.signature(parts[0] + "." + parts[1])
+	if !hmac.Equal([]byte(parts[2]), []byte(want)) {
+		return c, ErrInvalidToken
+	}
+	b, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return c, ErrInvalidToken
+	}
+	if err := json.Unmarshal(b, &c); err != nil {
+		return c, ErrInvalidToken
+	}
+	if c.Purpose != purpose {
+		return c, ErrInvalidToken
+	}
+	if s.now().Unix() >= c.ExpiresAt {
+		return c, ErrExpiredToken
+	}
+	return c, nil
+}
+
+func (s *Signer) signature(unsigned string) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(unsigned))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// NewRefreshToken generates a random refresh token for user. The returned
+// string is handed to the client; only the schema.RefreshToken, which holds
+// its hash, should be persisted.
+func (s *Signer) NewRefreshToken(user schema.User) (string, schema.RefreshToken, error) {
+	token, hash, err := NewOpaqueToken()
+	if err != nil {
+		return "", schema.RefreshToken{}, err
+	}
+	rt := schema.RefreshToken{
+		UserID:    user.ID,
+		TokenHash: hash,
+		ExpiresAt: s.now().UTC().Add(s.refreshTTL),
+	}
+	return token, rt, nil
+}
+
+// NewOpaqueToken returns a random URL-safe token and its HashToken.
+func NewOpaqueToken() (string, string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", err
+	}
+	token := base64.RawURLEncoding.EncodeToString(b)
+	return token, HashToken(token), nil
+}
+
+// HashToken returns the hex encoded SHA-256 of an opaque token, which is the
+// form tokens are stored and looked up in.
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
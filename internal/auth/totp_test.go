@@ -0,0 +1,44 @@
+package auth
+
+import (
+	"testing"
+	"time"
+)
+
+// rfcSecret is the SHA-1 key from RFC 6238 appendix B, base32 encoded.
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestTOTPCode(t *testing.T) {
+	tests := []struct {
+		unix int64
+		want string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+	}
+	for _, tt := range tests {
+		got, err := TOTPCode(rfcSecret, time.Unix(tt.unix, 0))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != tt.want {
+			t.Errorf("TOTPCode at %v: got %v want %v", tt.unix, got, tt.want)
+		}
+	}
+}
+
+func TestValidateTOTP_skew(t *testing.T) {
+	now := time.Unix(1111111109, 0)
+	code, err := TOTPCode(rfcSecret, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if step, ok := ValidateTOTP(rfcSecret, code, now.Add(totpPeriod*time.Second)); !ok || step != now.Unix()/totpPeriod {
+		t.Errorf("code from the previous period: got step %v %v want %v", step, ok, now.Unix()/totpPeriod)
+	}
+	if _, ok := ValidateTOTP(rfcSecret, code, now.Add(3*totpPeriod*time.Second)); ok {
+		t.Errorf("stale code was accepted")
+	}
+}
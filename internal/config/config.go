@@ -1,5 +1,7 @@
 package config
 
+import "time"
+
 // Config is the configuration struct
 type Config struct {
 	Addr string `envconfig:"ADDR" default:""`
@@ -10,4 +12,60 @@ type Config struct {
 	DBName     string `envconfig:"DB_NAME" default:"pps"`
 	DBUser     string `envconfig:"DB_USER" required:"true"`
 	DBPassword string `envconfig:"DB_PASSWORD" required:"true"`
+
+	TokenSecret     string        `envconfig:"TOKEN_SECRET" required:"true"`
+	TokenTTL        time.Duration `envconfig:"TOKEN_TTL" default:"15m"`
+	RefreshTokenTTL time.Duration `envconfig:"REFRESH_TOKEN_TTL" default:"720h"`
+
+	PublicURL            string        `envconfig:"PUBLIC_URL" default:"http://localhost:8080"`
+	PasswordResetTTL     time.Duration `envconfig:"PASSWORD_RESET_TTL" default:"1h"`
+	EmailVerificationTTL time.Duration `envconfig:"EMAIL_VERIFICATION_TTL" default:"72h"`
+	// PasswordResetURL is the page of the front end where a new password is
+	// chosen. Reset emails link to it with the token as its token parameter.
+	PasswordResetURL string `envconfig:"PASSWORD_RESET_URL" required:"true"`
+
+	PasswordForgotMaxAttempts      int           `envconfig:"PASSWORD_FORGOT_MAX_ATTEMPTS" default:"3"`
+	PasswordForgotMaxAttemptsPerIP int           `envconfig:"PASSWORD_FORGOT_MAX_ATTEMPTS_PER_IP" default:"20"`
+	PasswordForgotWindow           time.Duration `envconfig:"PASSWORD_FORGOT_WINDOW" default:"1h"`
+
+	LoginMaxAttempts      int           `envconfig:"LOGIN_MAX_ATTEMPTS" default:"5"`
+	LoginMaxAttemptsPerIP int           `envconfig:"LOGIN_MAX_ATTEMPTS_PER_IP" default:"20"`
+	LoginWindow           time.Duration `envconfig:"LOGIN_WINDOW" default:"15m"`
+	LoginLockout          time.Duration `envconfig:"LOGIN_LOCKOUT" default:"15m"`
+	LoginBaseDelay        time.Duration `envconfig:"LOGIN_BASE_DELAY" default:"1s"`
+	LoginMaxDelay         time.Duration `envconfig:"LOGIN_MAX_DELAY" default:"30s"`
+	TrustProxyHeaders     bool          `envconfig:"TRUST_PROXY_HEADERS" default:"false"`
+
+	TOTPIssuer string `envconfig:"TOTP_ISSUER" default:"hhapp"`
+
+	PasswordMinLength int `envconfig:"PASSWORD_MIN_LENGTH" default:"10"`
+	// PasswordRejectCommon turns away passwords in data/common_passwords.txt,
+	// which is compiled in with go generate.
+	PasswordRejectCommon bool `envconfig:"PASSWORD_REJECT_COMMON" default:"true"`
+	// BcryptCost applies to new hashes; existing hashes made with another
+	// cost are replaced at the user's next login.
+	BcryptCost int `envconfig:"BCRYPT_COST" default:"10"`
+
+	// OIDC login is only enabled when OIDCIssuer is set. OIDCRedirectURL
+	// defaults to PublicURL + "/oidc/callback".
+	OIDCProvider     string   `envconfig:"OIDC_PROVIDER" default:"oidc"`
+	OIDCIssuer       string   `envconfig:"OIDC_ISSUER" default:""`
+	OIDCClientID     string   `envconfig:"OIDC_CLIENT_ID" default:""`
+	OIDCClientSecret string   `envconfig:"OIDC_CLIENT_SECRET" default:""`
+	OIDCRedirectURL  string   `envconfig:"OIDC_REDIRECT_URL" default:""`
+	OIDCScopes       []string `envconfig:"OIDC_SCOPES" default:"openid,email,profile"`
+
+	// GazetteerPath is the offline geocoding table venues are located
+	// with. Leave empty to disable geocoding.
+	GazetteerPath string `envconfig:"GAZETTEER_PATH" default:"data/gazetteer.csv"`
+	// TimeZonesPath is the table venue time zones are defaulted from. Venues
+	// left without a zone are in VenueTimeZone.
+	TimeZonesPath string `envconfig:"TIME_ZONES_PATH" default:"data/time_zones.csv"`
+	VenueTimeZone string `envconfig:"VENUE_TIME_ZONE" default:"America/Denver"`
+
+	MailFrom      string `envconfig:"MAIL_FROM" default:"no-reply@hhapp.local"`
+	MailOutboxDir string `envconfig:"MAIL_OUTBOX_DIR" default:"/tmp/hhapp/outbox"`
+	SMTPAddr      string `envconfig:"SMTP_ADDR" default:""`
+	SMTPUser      string `envconfig:"SMTP_USER" default:""`
+	SMTPPassword  string `envconfig:"SMTP_PASSWORD" default:""`
 }
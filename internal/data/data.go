@@ -2,6 +2,7 @@ package data
 
 import (
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"strconv"
@@ -23,21 +24,75 @@ type Database interface {
 	CreateUserFavorite(userFav schema.UserFavorite) (int, error)
 	UserFavoritesList(u schema.UserFavorite) ([]schema.Venue, error)
 	UserFavoritesGet(u schema.UserFavorite) (schema.Venue, error)
-	UserFavoritesDelete(id int) error
+	UserFavoritesDelete(u schema.UserFavorite) error
 	GetUser(user schema.User) (schema.User, error)
+	GetUserByID(id int) (schema.User, error)
+	UpdateUser(user schema.User) error
+	DeleteUser(id int) error
+	CreateRefreshToken(rt schema.RefreshToken) (int, error)
+	RefreshTokenGet(hash string) (schema.RefreshToken, error)
+	RevokeRefreshToken(hash string) error
+	RevokeUserRefreshTokens(userID int) error
+	UserTokenGeneration(userID int) (int, error)
+	GetUserByEmail(email string) (schema.User, error)
+	UpdateUserPassword(userID int, password string) error
+	RehashUserPassword(userID int, oldHash, newHash string) error
+	CreatePasswordReset(pr schema.PasswordReset) (int, error)
+	GetPasswordReset(hash string) (schema.PasswordReset, error)
+	UsePasswordReset(hash string) (schema.PasswordReset, error)
+	VerifyUserEmail(userID int, email string) error
+	CreateUserNotification(un schema.UserNotifications) (int, error)
+	UpdateUserRoles(userID int, roles []schema.Role) error
+	CreateAPIKey(k schema.APIKey) (int, error)
+	APIKeysList() ([]schema.APIKey, error)
+	RevokeAPIKey(id int) error
+	UseAPIKey(hash string) (schema.APIKey, error)
+	TOTPSecretGet(userID int) (string, error)
+	SetTOTPSecret(userID int, secret string) error
+	EnableTOTP(userID int, recoveryHashes []string) error
+	DisableTOTP(userID int) error
+	UseRecoveryCode(userID int, hash string) error
+	UseTOTPStep(userID int, step int64) error
+	IdentityGet(provider, subject string) (schema.Identity, error)
+	CreateIdentity(identity schema.Identity) (int, error)
 	CreateVenue(venue schema.Venue) (int, error)
 	CreateVenueList(venueList schema.VenueList) (int, error)
 	VenueListAdd(vla schema.VenueListAdd) (int, error)
-	CreateMenu(menu schema.Menu) (int, error)
-	AddToMenu(menuItem schema.MenuItem) (int, error)
+	CreateMenu(menu schema.Menu, userID int) (int, error)
+	MenuGet(id int) (schema.Menu, error)
+	MenusByVenue(venueID int) ([]schema.Menu, error)
+	MenusByVenues(ids []int) ([]schema.Menu, error)
+	UpdateMenu(menu schema.Menu, userID int) error
+	DeleteMenu(id, userID int) error
+	AddToMenu(menuItem schema.MenuItem, userID int) (int, error)
+	MenuItemGet(id int) (schema.MenuItem, error)
+	UpdateMenuItem(menuItem schema.MenuItem, userID int) error
+	DeleteMenuItem(id, userID int) error
+	ReorderMenuItems(menuID int, ids []int, userID int) error
 	VenueListGet(vl schema.VenueList) (schema.VenueList, error)
 	VenuesByList(id int) ([]schema.Venue, error)
 	VenueGet(v schema.Venue) (schema.Venue, error)
+	UpdateVenue(venue schema.Venue) error
+	DeleteVenue(id, userID int) error
+	Venues(f schema.VenueFilter) ([]schema.Venue, error)
+	MenuItemsByMenus(ids []int) ([]schema.MenuItem, error)
+	MenuSchedules(f schema.VenueFilter) ([]schema.MenuSchedule, error)
+	MenuDateTimesGet(menuID int) ([]schema.MenuDateTime, error)
+	CreateMenuDateTime(md schema.MenuDateTime, userID int) (int, error)
+	UpdateMenuDateTime(md schema.MenuDateTime, userID int) error
+	DeleteMenuDateTime(menuID, id, userID int) error
+	MenuExceptionsGet(menuID int) ([]schema.MenuException, error)
+	CreateMenuException(e schema.MenuException, userID int) (int, error)
+	UpdateMenuException(e schema.MenuException, userID int) error
+	DeleteMenuException(menuID, id, userID int) error
 	MenuItemsGet(m schema.Menu) ([]schema.MenuItem, error)
+	MenuChanges(f schema.MenuChangeFilter) ([]schema.MenuChange, error)
 }
 
 func NewStore(cfg *config.Config) (*Store, error) {
-	dsn := "%s:%s@tcp(%s:%d)/%s?parseTime=true"
+	// clientFoundRows makes RowsAffected count matched rather than changed
+	// rows, so an update that writes identical values isn't ErrNotFound.
+	dsn := "%s:%s@tcp(%s:%d)/%s?parseTime=true&clientFoundRows=true"
 	conn := fmt.Sprintf(dsn, cfg.DBUser, cfg.DBPassword, cfg.DBHost, cfg.DBPort, cfg.DBName)
 	fmt.Println(conn)
 	db, err := sql.Open(mysql, conn)
@@ -62,12 +117,18 @@ func (s *Store) Close() {
 
 var ErrDuplicateEntry = errors.New("duplicate entry")
 var ErrNotFound = errors.New("no matching records found")
+var ErrInvalidOrder = errors.New("ids must list every item on the menu exactly once")
 
 func (s *Store) CreateUser(user schema.User) (int, error) {
 	var id int
 	err := s.transaction(s.db, func(tx *sql.Tx) (bool, error) {
-		q := `INSERT INTO user (username, password, email, created_at) VALUES (?, ?, ?, ?)`
-		res, err := tx.Exec(q, user.UserName, user.Password, user.Email, time.Now().UTC())
+		roles := schema.JoinRoles(user.Roles)
+		if roles == "" {
+			roles = string(schema.RolePatron)
+		}
+		now := time.Now().UTC()
+		q := `INSERT INTO user (username, password, email, roles, first_name, last_name, updated_at, created_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`
+		res, err := tx.Exec(q, user.UserName, user.Password, user.Email, roles, user.FirstName, user.LastName, now, now)
 		if err != nil && strings.Contains(err.Error(), "Duplicate entry") {
 			return true, ErrDuplicateEntry
 		}
@@ -96,7 +157,7 @@ func (s *Store) CreateUserFavorite(userFav schema.UserFavorite) (int, error) {
 func (s *Store) UserFavoritesList(u schema.UserFavorite) ([]schema.Venue, error) {
 	var venues []schema.Venue
 	err := s.transaction(s.db, func(tx *sql.Tx) (bool, error) {
-		query := `SELECT v.id, v.name, v.address, v.address2, v.city, v.state, v.zip, v.country, v.image from user_favorites as uf
+		query := `SELECT v.id, v.name, v.address, v.address2, v.city, v.state, v.zip, v.country, v.latitude, v.longitude, IFNULL(v.time_zone, ''), v.image from user_favorites as uf
 					JOIN venue as v on uf.venue_id = v.id
 					WHERE uf.user_id = ?`
 		rows, err := tx.Query(query, u.UserID)
@@ -105,10 +166,12 @@ func (s *Store) UserFavoritesList(u schema.UserFavorite) ([]schema.Venue, error)
 		}
 		for rows.Next() {
 			var venue schema.Venue
-			err := rows.Scan(&venue.ID, &venue.Name, &venue.Address, &venue.Address2, &venue.City, &venue.State, &venue.Zip, &venue.Country, &venue.Image)
+			var lat, lng sql.NullFloat64
+			err := rows.Scan(&venue.ID, &venue.Name, &venue.Address, &venue.Address2, &venue.City, &venue.State, &venue.Zip, &venue.Country, &lat, &lng, &venue.TimeZone, &venue.Image)
 			if err != nil {
 				return false, err
 			}
+			venue.Location = point(lat, lng)
 			venues = append(venues, venue)
 		}
 
@@ -121,26 +184,32 @@ func (s *Store) UserFavoritesList(u schema.UserFavorite) ([]schema.Venue, error)
 func (s *Store) UserFavoritesGet(u schema.UserFavorite) (schema.Venue, error) {
 	var venue schema.Venue
 	err := s.transaction(s.db, func(tx *sql.Tx) (bool, error) {
-		query := `SELECT uf.id, v.name, v.address, v.address2, v.city, v.state, v.zip, v.country, v.image from user_favorites as uf
+		query := `SELECT uf.id, v.name, v.address, v.address2, v.city, v.state, v.zip, v.country, v.latitude, v.longitude, IFNULL(v.time_zone, ''), v.image from user_favorites as uf
 					JOIN venue as v on uf.venue_id = v.id
 					WHERE uf.user_id = ? AND uf.venue_id = ?`
 		row := tx.QueryRow(query, u.UserID, u.VenueID)
-		err := row.Scan(&venue.ID, &venue.Name, &venue.Address, &venue.Address2, &venue.City, &venue.State, &venue.Zip, &venue.Country, &venue.Image)
+		var lat, lng sql.NullFloat64
+		err := row.Scan(&venue.ID, &venue.Name, &venue.Address, &venue.Address2, &venue.City, &venue.State, &venue.Zip, &venue.Country, &lat, &lng, &venue.TimeZone, &venue.Image)
 		if err != nil {
 			return false, err
 		}
+		venue.Location = point(lat, lng)
 		return false, err
 	})
 
 	return venue, err
 }
-func (s *Store) UserFavoritesDelete(id int) error {
+func (s *Store) UserFavoritesDelete(u schema.UserFavorite) error {
 	err := s.transaction(s.db, func(tx *sql.Tx) (bool, error) {
-		query := `DELETE FROM user_favorites WHERE id = ?`
-		_, err := tx.Exec(query, id)
+		query := `DELETE FROM user_favorites WHERE id = ? AND user_id = ?`
+		res, err := tx.Exec(query, u.ID, u.UserID)
 		if err != nil {
 			return false, err
 		}
+		n, err := res.RowsAffected()
+		if err == nil && n == 0 {
+			return true, ErrNotFound
+		}
 		return false, err
 	})
 
@@ -150,197 +219,382 @@ func (s *Store) UserFavoritesDelete(id int) error {
 func (s *Store) GetUser(user schema.User) (schema.User, error) {
 	u := schema.User{}
 	err := s.transaction(s.db, func(tx *sql.Tx) (bool, error) {
-		row := tx.QueryRow(`SELECT id, username, password, email, first_name, last_name FROM user WHERE username=?`, user.UserName)
-		row.Scan(&u.ID, &u.UserName, &u.Password, &u.Email, &u.FirstName, &u.LastName)
+		var roles string
+		row := tx.QueryRow(`SELECT id, username, password, email, totp_enabled_at IS NOT NULL, roles, IFNULL(first_name, ''), IFNULL(last_name, ''), token_generation FROM user WHERE username=?`, user.UserName)
+		row.Scan(&u.ID, &u.UserName, &u.Password, &u.Email, &u.TwoFactorEnabled, &roles, &u.FirstName, &u.LastName, &u.TokenGeneration)
+		u.Roles, _ = schema.ParseRoles(roles)
 		return false, nil
 	})
 	return u, err
 }
 
-func (s *Store) CreateVenue(venue schema.Venue) (int, error) {
-	var id int
+func (s *Store) GetUserByID(id int) (schema.User, error) {
+	u := schema.User{}
 	err := s.transaction(s.db, func(tx *sql.Tx) (bool, error) {
-		q := `INSERT INTO venue (name, address, address2, city, state, zip, country, image, created_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`
-		fmt.Println(fmt.Sprintf("%+v", venue))
-		res, err := tx.Exec(q, venue.Name, venue.Address, venue.Address2, venue.City, venue.State, venue.Zip, venue.Country, venue.Image, time.Now().UTC())
-		if err != nil && strings.Contains(err.Error(), "Duplicate entry") {
-			return true, ErrDuplicateEntry
+		var roles string
+		row := tx.QueryRow(`SELECT id, username, email, email_verified_at IS NOT NULL, totp_enabled_at IS NOT NULL, roles, IFNULL(first_name, ''), IFNULL(last_name, ''), token_generation FROM user WHERE id=?`, id)
+		err := row.Scan(&u.ID, &u.UserName, &u.Email, &u.EmailVerified, &u.TwoFactorEnabled, &roles, &u.FirstName, &u.LastName, &u.TokenGeneration)
+		if err == sql.ErrNoRows {
+			return true, ErrNotFound
 		}
-		resID, err := res.LastInsertId()
-		id = int(resID)
+		if err != nil {
+			return false, err
+		}
+		u.Roles, err = schema.ParseRoles(roles)
 		return false, err
 	})
-
-	return id, err
+	return u, err
 }
 
-func (s *Store) CreateVenueList(venueList schema.VenueList) (int, error) {
+func (s *Store) CreateRefreshToken(rt schema.RefreshToken) (int, error) {
 	var id int
 	err := s.transaction(s.db, func(tx *sql.Tx) (bool, error) {
-		q := `INSERT INTO venue_list (name, created_at) VALUES (?, ?)`
-		res, err := tx.Exec(q, venueList.Name, time.Now().UTC())
-		if err != nil && strings.Contains(err.Error(), "Duplicate entry") {
-			return true, ErrDuplicateEntry
+		q := `INSERT INTO refresh_token (user_id, token_hash, expires_at, created_at) VALUES (?, ?, ?, ?)`
+		res, err := tx.Exec(q, rt.UserID, rt.TokenHash, rt.ExpiresAt, time.Now().UTC())
+		if err != nil {
+			if strings.Contains(err.Error(), "Duplicate entry") {
+				return true, ErrDuplicateEntry
+			}
+			return true, err
 		}
 		resID, err := res.LastInsertId()
 		id = int(resID)
 		return false, err
 	})
-
 	return id, err
 }
 
-func (s *Store) VenueListAdd(vla schema.VenueListAdd) (int, error) {
-	var id int
-	venueList := schema.VenueList{ID: vla.VenueListID, Name: vla.VenueListName}
-	vl, err := s.VenueListGet(venueList)
-	if err != nil {
-		return 0, fmt.Errorf("venue list %s not found", vla.VenueListName)
-	}
-	venue := schema.Venue{ID: vla.VenueID, Name: vla.VenueName}
-	v, err := s.VenueGet(venue)
-	if err != nil {
-		return 0, fmt.Errorf("venue %s not found", vla.VenueName)
-	}
-	err = s.transaction(s.db, func(tx *sql.Tx) (bool, error) {
-		q := `INSERT INTO venue_lists (venue_id, venue_list_id, created_at) VALUES (?, ?, ?)`
-		res, err := tx.Exec(q, v.ID, vl.ID, time.Now().UTC())
-		if err != nil && strings.Contains(err.Error(), "Duplicate entry") {
-			return true, ErrDuplicateEntry
+func (s *Store) RefreshTokenGet(hash string) (schema.RefreshToken, error) {
+	var rt schema.RefreshToken
+	err := s.transaction(s.db, func(tx *sql.Tx) (bool, error) {
+		row := tx.QueryRow(`SELECT id, user_id, token_hash, expires_at, revoked_at FROM refresh_token WHERE token_hash = ?`, hash)
+		err := row.Scan(&rt.ID, &rt.UserID, &rt.TokenHash, &rt.ExpiresAt, &rt.RevokedAt)
+		if err == sql.ErrNoRows {
+			return true, ErrNotFound
 		}
-		resID, err := res.LastInsertId()
-		id = int(resID)
 		return false, err
 	})
+	return rt, err
+}
 
-	return id, err
+// RevokeRefreshToken revokes a single active refresh token. ErrNotFound is
+// returned if there is no such token or it was already revoked.
+func (s *Store) RevokeRefreshToken(hash string) error {
+	return s.transaction(s.db, func(tx *sql.Tx) (bool, error) {
+		now := time.Now().UTC()
+		q := `UPDATE refresh_token SET revoked_at = ?, updated_at = ? WHERE token_hash = ? AND revoked_at IS NULL`
+		res, err := tx.Exec(q, now, now, hash)
+		if err != nil {
+			return false, err
+		}
+		n, err := res.RowsAffected()
+		if err == nil && n == 0 {
+			return true, ErrNotFound
+		}
+		return false, err
+	})
 }
 
-func (s *Store) VenueListGet(vl schema.VenueList) (schema.VenueList, error) {
-	var query, svalue string
-	var venueList schema.VenueList
-	switch {
-	case vl.ID != 0:
-		query = `SELECT id, name FROM venue_list WHERE id = ?`
-		svalue = strconv.Itoa(vl.ID)
-	case vl.Name != "":
-		query = `SELECT id, name FROM venue_list WHERE name = ?`
-		svalue = vl.Name
-	default:
-		return venueList, errors.New("no venue list id or name provided")
-	}
+// RevokeUserRefreshTokens revokes every active refresh token held by a user
+// and bumps their token generation, ending all of their sessions including
+// access tokens that have yet to expire.
+func (s *Store) RevokeUserRefreshTokens(userID int) error {
+	return s.transaction(s.db, func(tx *sql.Tx) (bool, error) {
+		now := time.Now().UTC()
+		q := `UPDATE refresh_token SET revoked_at = ?, updated_at = ? WHERE user_id = ? AND revoked_at IS NULL`
+		if _, err := tx.Exec(q, now, now, userID); err != nil {
+			return false, err
+		}
+		_, err := tx.Exec(`UPDATE user SET token_generation = token_generation + 1 WHERE id = ?`, userID)
+		return false, err
+	})
+}
 
+// UserTokenGeneration returns the generation a user's access tokens must
+// carry to be accepted. It returns ErrNotFound once the user is deleted.
+func (s *Store) UserTokenGeneration(userID int) (int, error) {
+	var gen int
 	err := s.transaction(s.db, func(tx *sql.Tx) (bool, error) {
-		row := tx.QueryRow(query, svalue)
-		err := row.Scan(&venueList.ID, &venueList.Name)
+		err := tx.QueryRow(`SELECT token_generation FROM user WHERE id = ?`, userID).Scan(&gen)
 		if err == sql.ErrNoRows {
 			return true, ErrNotFound
 		}
 		return false, err
 	})
-
-	return venueList, err
+	return gen, err
 }
 
-func (s *Store) VenuesByList(id int) ([]schema.Venue, error) {
-	var venues []schema.Venue
+func (s *Store) GetUserByEmail(email string) (schema.User, error) {
+	u := schema.User{}
 	err := s.transaction(s.db, func(tx *sql.Tx) (bool, error) {
-		query := `SELECT v.id, v.name, v.address, v.address2, v.city, v.state, v.zip, v.country, v.image from venue_lists as vl
-					JOIN venue as v on vl.venue_id = v.id
-					WHERE vl.venue_list_id = ?`
-		rows, err := tx.Query(query, id)
+		var roles string
+		row := tx.QueryRow(`SELECT id, username, email, email_verified_at IS NOT NULL, totp_enabled_at IS NOT NULL, roles, IFNULL(first_name, ''), IFNULL(last_name, ''), token_generation FROM user WHERE email=? ORDER BY id LIMIT 1`, email)
+		err := row.Scan(&u.ID, &u.UserName, &u.Email, &u.EmailVerified, &u.TwoFactorEnabled, &roles, &u.FirstName, &u.LastName, &u.TokenGeneration)
+		if err == sql.ErrNoRows {
+			return true, ErrNotFound
+		}
 		if err != nil {
 			return false, err
 		}
-		for rows.Next() {
-			var venue schema.Venue
-			err := rows.Scan(&venue.ID, &venue.Name, &venue.Address, &venue.Address2, &venue.City, &venue.State, &venue.Zip, &venue.Country, &venue.Image)
-			if err != nil {
-				return false, err
-			}
-			venues = append(venues, venue)
+		u.Roles, err = schema.ParseRoles(roles)
+		return false, err
+	})
+	return u, err
+}
+
+// UpdateUser saves a user's profile. Changing the email address marks it
+// unverified again.
+func (s *Store) UpdateUser(user schema.User) error {
+	return s.transaction(s.db, func(tx *sql.Tx) (bool, error) {
+		// MySQL applies assignments left to right, so email_verified_at
+		// must be compared against the old email before it is replaced.
+		q := `UPDATE user SET email_verified_at = IF(email = ?, email_verified_at, NULL), email = ?,
+				first_name = ?, last_name = ?, updated_at = ? WHERE id = ?`
+		res, err := tx.Exec(q, user.Email, user.Email, user.FirstName, user.LastName, time.Now().UTC(), user.ID)
+		if err != nil {
+			return false, err
+		}
+		n, err := res.RowsAffected()
+		if err == nil && n == 0 {
+			return true, ErrNotFound
+		}
+		if err != nil {
+			return false, err
 		}
+		// Notifications go to the address the user subscribed with. Once
+		// they give it up the subscriptions are dropped; they may subscribe
+		// again when the new address is verified.
+		_, err = tx.Exec(`DELETE FROM user_notifications WHERE user_id = ? AND email <> ?`, user.ID, user.Email)
+		return false, err
+	})
+}
 
+// DeleteUser removes a user. Favorites, notification subscriptions and
+// tokens are removed by their foreign key cascades, and access tokens stop
+// working because UserTokenGeneration no longer finds the user.
+func (s *Store) DeleteUser(id int) error {
+	return s.transaction(s.db, func(tx *sql.Tx) (bool, error) {
+		res, err := tx.Exec(`DELETE FROM user WHERE id = ?`, id)
+		if err != nil {
+			return false, err
+		}
+		n, err := res.RowsAffected()
+		if err == nil && n == 0 {
+			return true, ErrNotFound
+		}
 		return false, err
 	})
+}
 
-	return venues, err
+// UpdateUserPassword stores an already hashed password for a user and
+// bumps their token generation so that access tokens issued under the old
+// password stop working.
+func (s *Store) UpdateUserPassword(userID int, password string) error {
+	return s.transaction(s.db, func(tx *sql.Tx) (bool, error) {
+		q := `UPDATE user SET password = ?, token_generation = token_generation + 1, updated_at = ? WHERE id = ?`
+		res, err := tx.Exec(q, password, time.Now().UTC(), userID)
+		if err != nil {
+			return false, err
+		}
+		n, err := res.RowsAffected()
+		if err == nil && n == 0 {
+			return true, ErrNotFound
+		}
+		return false, err
+	})
 }
 
-func (s *Store) VenueGet(v schema.Venue) (schema.Venue, error) {
-	var query, svalue string
-	var venue schema.Venue
-	switch {
-	case v.ID != 0:
-		query = `SELECT id, name, address, address2, city, state, zip, country, image FROM venue WHERE id = ?`
-		svalue = strconv.Itoa(v.ID)
-	case v.Name != "":
-		query = `SELECT id, name, address, address2, city, state, zip, country, image FROM venue WHERE name = ?`
-		svalue = v.Name
-	default:
-		return venue, errors.New("no venue id or name provided")
-	}
+// RehashUserPassword replaces a user's password hash with a new hash of the
+// same password. Unlike UpdateUserPassword it leaves their tokens alone, and
+// it does nothing if the password changed since oldHash was read.
+func (s *Store) RehashUserPassword(userID int, oldHash, newHash string) error {
+	return s.transaction(s.db, func(tx *sql.Tx) (bool, error) {
+		q := `UPDATE user SET password = ?, updated_at = ? WHERE id = ? AND password = ?`
+		_, err := tx.Exec(q, newHash, time.Now().UTC(), userID, oldHash)
+		return false, err
+	})
+}
 
+func (s *Store) CreatePasswordReset(pr schema.PasswordReset) (int, error) {
+	var id int
 	err := s.transaction(s.db, func(tx *sql.Tx) (bool, error) {
-		row := tx.QueryRow(query, svalue)
-		err := row.Scan(&venue.ID, &venue.Name, &venue.Address, &venue.Address2, &venue.City, &venue.State, &venue.Zip, &venue.Country, &venue.Image)
+		q := `INSERT INTO password_reset (user_id, token_hash, expires_at, created_at) VALUES (?, ?, ?, ?)`
+		res, err := tx.Exec(q, pr.UserID, pr.TokenHash, pr.ExpiresAt, time.Now().UTC())
+		if err != nil {
+			if strings.Contains(err.Error(), "Duplicate entry") {
+				return true, ErrDuplicateEntry
+			}
+			return true, err
+		}
+		resID, err := res.LastInsertId()
+		id = int(resID)
+		return false, err
+	})
+	return id, err
+}
+
+// GetPasswordReset returns an unused, unexpired reset token without using
+// it. ErrNotFound is returned for unknown, used or expired tokens.
+func (s *Store) GetPasswordReset(hash string) (schema.PasswordReset, error) {
+	var pr schema.PasswordReset
+	err := s.transaction(s.db, func(tx *sql.Tx) (bool, error) {
+		q := `SELECT id, user_id, token_hash, expires_at FROM password_reset
+				WHERE token_hash = ? AND used_at IS NULL AND expires_at > ?`
+		row := tx.QueryRow(q, hash, time.Now().UTC())
+		err := row.Scan(&pr.ID, &pr.UserID, &pr.TokenHash, &pr.ExpiresAt)
 		if err == sql.ErrNoRows {
 			return true, ErrNotFound
 		}
 		return false, err
 	})
+	return pr, err
+}
 
-	return venue, err
+// UsePasswordReset marks an unused, unexpired reset token as used and
+// returns it. ErrNotFound is returned for unknown, used or expired tokens.
+func (s *Store) UsePasswordReset(hash string) (schema.PasswordReset, error) {
+	var pr schema.PasswordReset
+	err := s.transaction(s.db, func(tx *sql.Tx) (bool, error) {
+		now := time.Now().UTC()
+		q := `SELECT id, user_id, token_hash, expires_at FROM password_reset
+				WHERE token_hash = ? AND used_at IS NULL AND expires_at > ? FOR UPDATE`
+		row := tx.QueryRow(q, hash, now)
+		err := row.Scan(&pr.ID, &pr.UserID, &pr.TokenHash, &pr.ExpiresAt)
+		if err == sql.ErrNoRows {
+			return true, ErrNotFound
+		}
+		if err != nil {
+			return false, err
+		}
+		_, err = tx.Exec(`UPDATE password_reset SET used_at = ?, updated_at = ? WHERE id = ?`, now, now, pr.ID)
+		pr.UsedAt = &now
+		return false, err
+	})
+	return pr, err
 }
 
-func (s *Store) MenuItemsGet(m schema.Menu) ([]schema.MenuItem, error) {
-	var menuItems []schema.MenuItem
+// UpdateUserRoles replaces a user's roles. Their token generation is bumped
+// because access tokens carry the roles they were issued with.
+func (s *Store) UpdateUserRoles(userID int, roles []schema.Role) error {
+	return s.transaction(s.db, func(tx *sql.Tx) (bool, error) {
+		q := `UPDATE user SET roles = ?, token_generation = token_generation + 1, updated_at = ? WHERE id = ?`
+		res, err := tx.Exec(q, schema.JoinRoles(roles), time.Now().UTC(), userID)
+		if err != nil {
+			return false, err
+		}
+		n, err := res.RowsAffected()
+		if err == nil && n == 0 {
+			return true, ErrNotFound
+		}
+		return false, err
+	})
+}
+
+func (s *Store) CreateAPIKey(k schema.APIKey) (int, error) {
+	var id int
 	err := s.transaction(s.db, func(tx *sql.Tx) (bool, error) {
-		query := `SELECT mi.id, m.id, mi.category, mi.price, mi.description
-					FROM menu as m
-					JOIN menu_item as mi on m.id = mi.menu_id
-					WHERE m.venue_id = ?`
-		rows, err := tx.Query(query, m.VenueID)
+		now := time.Now().UTC()
+		q := `INSERT INTO api_key (user_id, name, prefix, key_hash, scope, created_by, updated_at, created_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`
+		res, err := tx.Exec(q, k.UserID, k.Name, k.Prefix, k.KeyHash, string(k.Scope), k.CreatedBy, now, now)
+		if err != nil {
+			if strings.Contains(err.Error(), "Duplicate entry") {
+				return true, ErrDuplicateEntry
+			}
+			return true, err
+		}
+		resID, err := res.LastInsertId()
+		id = int(resID)
+		return false, err
+	})
+	return id, err
+}
+
+func (s *Store) APIKeysList() ([]schema.APIKey, error) {
+	var keys []schema.APIKey
+	err := s.transaction(s.db, func(tx *sql.Tx) (bool, error) {
+		query := `SELECT id, user_id, name, prefix, scope, created_by, last_used_at, revoked_at, created_at FROM api_key ORDER BY id`
+		rows, err := tx.Query(query)
 		if err != nil {
 			return false, err
 		}
+		defer rows.Close()
 		for rows.Next() {
-			var mi schema.MenuItem
-			err := rows.Scan(&mi.ID, &mi.MenuID, &mi.Category, &mi.Price, &mi.Description)
+			var k schema.APIKey
+			var scope string
+			err := rows.Scan(&k.ID, &k.UserID, &k.Name, &k.Prefix, &scope, &k.CreatedBy, &k.LastUsedAt, &k.RevokedAt, &k.CreatedAt)
 			if err != nil {
 				return false, err
 			}
-			menuItems = append(menuItems, mi)
+			k.Scope = schema.APIKeyScope(scope)
+			keys = append(keys, k)
 		}
+		return false, rows.Err()
+	})
+	return keys, err
+}
 
+func (s *Store) RevokeAPIKey(id int) error {
+	return s.transaction(s.db, func(tx *sql.Tx) (bool, error) {
+		now := time.Now().UTC()
+		q := `UPDATE api_key SET revoked_at = ?, updated_at = ? WHERE id = ? AND revoked_at IS NULL`
+		res, err := tx.Exec(q, now, now, id)
+		if err != nil {
+			return false, err
+		}
+		n, err := res.RowsAffected()
+		if err == nil && n == 0 {
+			return true, ErrNotFound
+		}
 		return false, err
 	})
-
-	return menuItems, err
 }
 
-func (s *Store) CreateMenu(menu schema.Menu) (int, error) {
-	var id int
+// UseAPIKey returns the unrevoked key with the given hash and records that
+// it was used. ErrNotFound is returned for unknown or revoked keys.
+func (s *Store) UseAPIKey(hash string) (schema.APIKey, error) {
+	var k schema.APIKey
 	err := s.transaction(s.db, func(tx *sql.Tx) (bool, error) {
-		q := `INSERT INTO menu (venue_id, created_at) VALUES (?, ?)`
-		res, err := tx.Exec(q, menu.VenueID, time.Now().UTC())
-		if err != nil && strings.Contains(err.Error(), "Duplicate entry") {
-			return true, ErrDuplicateEntry
+		var scope string
+		q := `SELECT id, user_id, name, prefix, scope, created_by, created_at FROM api_key WHERE key_hash = ? AND revoked_at IS NULL`
+		row := tx.QueryRow(q, hash)
+		err := row.Scan(&k.ID, &k.UserID, &k.Name, &k.Prefix, &scope, &k.CreatedBy, &k.CreatedAt)
+		if err == sql.ErrNoRows {
+			return true, ErrNotFound
 		}
-		resID, err := res.LastInsertId()
-		id = int(resID)
+		if err != nil {
+			return false, err
+		}
+		k.Scope = schema.APIKeyScope(scope)
+		now := time.Now().UTC()
+		k.LastUsedAt = &now
+		_, err = tx.Exec(`UPDATE api_key SET last_used_at = ? WHERE id = ?`, now, k.ID)
 		return false, err
 	})
+	return k, err
+}
 
-	return id, err
+// IdentityGet returns the link for an external provider account.
+func (s *Store) IdentityGet(provider, subject string) (schema.Identity, error) {
+	var i schema.Identity
+	err := s.transaction(s.db, func(tx *sql.Tx) (bool, error) {
+		q := `SELECT id, user_id, provider, subject, IFNULL(email, ''), created_at FROM user_identity WHERE provider = ? AND subject = ?`
+		row := tx.QueryRow(q, provider, subject)
+		err := row.Scan(&i.ID, &i.UserID, &i.Provider, &i.Subject, &i.Email, &i.CreatedAt)
+		if err == sql.ErrNoRows {
+			return true, ErrNotFound
+		}
+		return false, err
+	})
+	return i, err
 }
 
-func (s *Store) AddToMenu(menuItem schema.MenuItem) (int, error) {
+// CreateIdentity links an external provider account to a user.
+// ErrDuplicateEntry is returned if the account is already linked.
+func (s *Store) CreateIdentity(identity schema.Identity) (int, error) {
 	var id int
-	fmt.Printf("MenuItem: %+v", menuItem)
 	err := s.transaction(s.db, func(tx *sql.Tx) (bool, error) {
-		q := `INSERT INTO menu_item (menu_id, category, price, description, created_at) VALUES (?, ?, ?, ?, ?)`
-		res, err := tx.Exec(q, menuItem.MenuID, menuItem.Category, menuItem.Price, menuItem.Description, time.Now().UTC())
+		q := `INSERT INTO user_identity (user_id, provider, subject, email, created_at) VALUES (?, ?, ?, ?, ?)`
+		res, err := tx.Exec(q, identity.UserID, identity.Provider, identity.Subject, identity.Email, time.Now().UTC())
 		if err != nil {
 			if strings.Contains(err.Error(), "Duplicate entry") {
 				return true, ErrDuplicateEntry
@@ -351,10 +605,1270 @@ func (s *Store) AddToMenu(menuItem schema.MenuItem) (int, error) {
 		id = int(resID)
 		return false, err
 	})
-
 	return id, err
 }
 
+// TOTPSecretGet returns the user's TOTP secret, which is empty when they
+// have never enrolled.
+func (s *Store) TOTPSecretGet(userID int) (string, error) {
+	var secret string
+	err := s.transaction(s.db, func(tx *sql.Tx) (bool, error) {
+		row := tx.QueryRow(`SELECT IFNULL(totp_secret, '') FROM user WHERE id = ?`, userID)
+		err := row.Scan(&secret)
+		if err == sql.ErrNoRows {
+			return true, ErrNotFound
+		}
+		return false, err
+	})
+	return secret, err
+}
+
+// SetTOTPSecret stores a pending TOTP secret. Two-factor login is not
+// required until EnableTOTP is called.
+func (s *Store) SetTOTPSecret(userID int, secret string) error {
+	return s.transaction(s.db, func(tx *sql.Tx) (bool, error) {
+		q := `UPDATE user SET totp_secret = ?, totp_enabled_at = NULL, updated_at = ? WHERE id = ?`
+		res, err := tx.Exec(q, secret, time.Now().UTC(), userID)
+		if err != nil {
+			return false, err
+		}
+		n, err := res.RowsAffected()
+		if err == nil && n == 0 {
+			return true, ErrNotFound
+		}
+		return false, err
+	})
+}
+
+// EnableTOTP turns on two-factor login for a user and replaces their
+// recovery codes.
+func (s *Store) EnableTOTP(userID int, recoveryHashes []string) error {
+	return s.transaction(s.db, func(tx *sql.Tx) (bool, error) {
+		now := time.Now().UTC()
+		_, err := tx.Exec(`UPDATE user SET totp_enabled_at = ?, updated_at = ? WHERE id = ?`, now, now, userID)
+		if err != nil {
+			return false, err
+		}
+		_, err = tx.Exec(`DELETE FROM recovery_code WHERE user_id = ?`, userID)
+		if err != nil {
+			return false, err
+		}
+		for _, h := range recoveryHashes {
+			_, err = tx.Exec(`INSERT INTO recovery_code (user_id, code_hash, created_at) VALUES (?, ?, ?)`, userID, h, now)
+			if err != nil {
+				return false, err
+			}
+		}
+		return false, nil
+	})
+}
+
+func (s *Store) DisableTOTP(userID int) error {
+	return s.transaction(s.db, func(tx *sql.Tx) (bool, error) {
+		q := `UPDATE user SET totp_secret = NULL, totp_enabled_at = NULL, updated_at = ? WHERE id = ?`
+		_, err := tx.Exec(q, time.Now().UTC(), userID)
+		if err != nil {
+			return false, err
+		}
+		_, err = tx.Exec(`DELETE FROM recovery_code WHERE user_id = ?`, userID)
+		return false, err
+	})
+}
+
+// UseRecoveryCode marks one of a user's unused recovery codes as used.
+// ErrNotFound is returned if the code is unknown or already used.
+func (s *Store) UseRecoveryCode(userID int, hash string) error {
+	return s.transaction(s.db, func(tx *sql.Tx) (bool, error) {
+		now := time.Now().UTC()
+		q := `UPDATE recovery_code SET used_at = ?, updated_at = ? WHERE user_id = ? AND code_hash = ? AND used_at IS NULL`
+		res, err := tx.Exec(q, now, now, userID, hash)
+		if err != nil {
+			return false, err
+		}
+		n, err := res.RowsAffected()
+		if err == nil && n == 0 {
+			return true, ErrNotFound
+		}
+		return false, err
+	})
+}
+
+// UseTOTPStep records step as the last TOTP time step a user's code was
+// accepted for. ErrNotFound is returned if it is not after the last one, so
+// a code seen once can't be replayed.
+func (s *Store) UseTOTPStep(userID int, step int64) error {
+	return s.transaction(s.db, func(tx *sql.Tx) (bool, error) {
+		q := `UPDATE user SET totp_last_step = ? WHERE id = ? AND (totp_last_step IS NULL OR totp_last_step < ?)`
+		res, err := tx.Exec(q, step, userID, step)
+		if err != nil {
+			return false, err
+		}
+		n, err := res.RowsAffected()
+		if err == nil && n == 0 {
+			return true, ErrNotFound
+		}
+		return false, err
+	})
+}
+
+// VerifyUserEmail marks a user's email as verified, provided it is still the
+// address the verification was sent to.
+func (s *Store) VerifyUserEmail(userID int, email string) error {
+	return s.transaction(s.db, func(tx *sql.Tx) (bool, error) {
+		now := time.Now().UTC()
+		q := `UPDATE user SET email_verified_at = ?, updated_at = ? WHERE id = ? AND email = ?`
+		res, err := tx.Exec(q, now, now, userID, email)
+		if err != nil {
+			return false, err
+		}
+		n, err := res.RowsAffected()
+		if err == nil && n == 0 {
+			return true, ErrNotFound
+		}
+		return false, err
+	})
+}
+
+func (s *Store) CreateUserNotification(un schema.UserNotifications) (int, error) {
+	var id int
+	err := s.transaction(s.db, func(tx *sql.Tx) (bool, error) {
+		q := `INSERT INTO user_notifications (user_id, notification_id, email, created_at) VALUES (?, ?, ?, ?)`
+		res, err := tx.Exec(q, un.UserID, un.NotificationID, un.Email, time.Now().UTC())
+		if err != nil {
+			if strings.Contains(err.Error(), "Duplicate entry") {
+				return true, ErrDuplicateEntry
+			}
+			return true, err
+		}
+		resID, err := res.LastInsertId()
+		id = int(resID)
+		return false, err
+	})
+	return id, err
+}
+
+func (s *Store) CreateVenue(venue schema.Venue) (int, error) {
+	var id int
+	err := s.transaction(s.db, func(tx *sql.Tx) (bool, error) {
+		q := `INSERT INTO venue (name, address, address2, city, state, zip, country, latitude, longitude, time_zone, image, owner_id, updated_at, created_at)
+				VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+		fmt.Println(fmt.Sprintf("%+v", venue))
+		var owner interface{}
+		if venue.OwnerID != 0 {
+			owner = venue.OwnerID
+		}
+		lat, lng := coordinates(venue.Location)
+		now := time.Now().UTC()
+		res, err := tx.Exec(q, venue.Name, venue.Address, venue.Address2, venue.City, venue.State, venue.Zip, venue.Country, lat, lng, timeZone(venue.TimeZone), venue.Image, owner, now, now)
+		if err != nil && strings.Contains(err.Error(), "Duplicate entry") {
+			return true, ErrDuplicateEntry
+		}
+		resID, err := res.LastInsertId()
+		id = int(resID)
+		return false, err
+	})
+
+	return id, err
+}
+
+func (s *Store) CreateVenueList(venueList schema.VenueList) (int, error) {
+	var id int
+	err := s.transaction(s.db, func(tx *sql.Tx) (bool, error) {
+		q := `INSERT INTO venue_list (name, created_at) VALUES (?, ?)`
+		res, err := tx.Exec(q, venueList.Name, time.Now().UTC())
+		if err != nil && strings.Contains(err.Error(), "Duplicate entry") {
+			return true, ErrDuplicateEntry
+		}
+		resID, err := res.LastInsertId()
+		id = int(resID)
+		return false, err
+	})
+
+	return id, err
+}
+
+func (s *Store) VenueListAdd(vla schema.VenueListAdd) (int, error) {
+	var id int
+	venueList := schema.VenueList{ID: vla.VenueListID, Name: vla.VenueListName}
+	vl, err := s.VenueListGet(venueList)
+	if err != nil {
+		return 0, fmt.Errorf("venue list %s not found", vla.VenueListName)
+	}
+	venue := schema.Venue{ID: vla.VenueID, Name: vla.VenueName}
+	v, err := s.VenueGet(venue)
+	if err != nil {
+		return 0, fmt.Errorf("venue %s not found", vla.VenueName)
+	}
+	err = s.transaction(s.db, func(tx *sql.Tx) (bool, error) {
+		q := `INSERT INTO venue_lists (venue_id, venue_list_id, created_at) VALUES (?, ?, ?)`
+		res, err := tx.Exec(q, v.ID, vl.ID, time.Now().UTC())
+		if err != nil && strings.Contains(err.Error(), "Duplicate entry") {
+			return true, ErrDuplicateEntry
+		}
+		resID, err := res.LastInsertId()
+		id = int(resID)
+		return false, err
+	})
+
+	return id, err
+}
+
+func (s *Store) VenueListGet(vl schema.VenueList) (schema.VenueList, error) {
+	var query, svalue string
+	var venueList schema.VenueList
+	switch {
+	case vl.ID != 0:
+		query = `SELECT id, name FROM venue_list WHERE id = ?`
+		svalue = strconv.Itoa(vl.ID)
+	case vl.Name != "":
+		query = `SELECT id, name FROM venue_list WHERE name = ?`
+		svalue = vl.Name
+	default:
+		return venueList, errors.New("no venue list id or name provided")
+	}
+
+	err := s.transaction(s.db, func(tx *sql.Tx) (bool, error) {
+		row := tx.QueryRow(query, svalue)
+		err := row.Scan(&venueList.ID, &venueList.Name)
+		if err == sql.ErrNoRows {
+			return true, ErrNotFound
+		}
+		return false, err
+	})
+
+	return venueList, err
+}
+
+func (s *Store) VenuesByList(id int) ([]schema.Venue, error) {
+	var venues []schema.Venue
+	err := s.transaction(s.db, func(tx *sql.Tx) (bool, error) {
+		query := `SELECT v.id, v.name, v.address, v.address2, v.city, v.state, v.zip, v.country, v.latitude, v.longitude, IFNULL(v.time_zone, ''), v.image from venue_lists as vl
+					JOIN venue as v on vl.venue_id = v.id
+					WHERE vl.venue_list_id = ?`
+		rows, err := tx.Query(query, id)
+		if err != nil {
+			return false, err
+		}
+		for rows.Next() {
+			var venue schema.Venue
+			var lat, lng sql.NullFloat64
+			err := rows.Scan(&venue.ID, &venue.Name, &venue.Address, &venue.Address2, &venue.City, &venue.State, &venue.Zip, &venue.Country, &lat, &lng, &venue.TimeZone, &venue.Image)
+			if err != nil {
+				return false, err
+			}
+			venue.Location = point(lat, lng)
+			venues = append(venues, venue)
+		}
+
+		return false, err
+	})
+
+	return venues, err
+}
+
+func (s *Store) VenueGet(v schema.Venue) (schema.Venue, error) {
+	var query, svalue string
+	var venue schema.Venue
+	switch {
+	case v.ID != 0:
+		query = `SELECT id, name, address, address2, city, state, zip, country, latitude, longitude, IFNULL(time_zone, ''), image, IFNULL(owner_id, 0) FROM venue WHERE id = ?`
+		svalue = strconv.Itoa(v.ID)
+	case v.Name != "":
+		query = `SELECT id, name, address, address2, city, state, zip, country, latitude, longitude, IFNULL(time_zone, ''), image, IFNULL(owner_id, 0) FROM venue WHERE name = ?`
+		svalue = v.Name
+	default:
+		return venue, errors.New("no venue id or name provided")
+	}
+
+	err := s.transaction(s.db, func(tx *sql.Tx) (bool, error) {
+		row := tx.QueryRow(query, svalue)
+		var lat, lng sql.NullFloat64
+		err := row.Scan(&venue.ID, &venue.Name, &venue.Address, &venue.Address2, &venue.City, &venue.State, &venue.Zip, &venue.Country, &lat, &lng, &venue.TimeZone, &venue.Image, &venue.OwnerID)
+		if err == sql.ErrNoRows {
+			return true, ErrNotFound
+		}
+		venue.Location = point(lat, lng)
+		return false, err
+	})
+
+	return venue, err
+}
+
+// UpdateVenue writes every field of venue over the stored row.
+func (s *Store) UpdateVenue(venue schema.Venue) error {
+	return s.transaction(s.db, func(tx *sql.Tx) (bool, error) {
+		var owner interface{}
+		if venue.OwnerID != 0 {
+			owner = venue.OwnerID
+		}
+		lat, lng := coordinates(venue.Location)
+		q := `UPDATE venue SET name = ?, address = ?, address2 = ?, city = ?, state = ?, zip = ?, country = ?,
+				latitude = ?, longitude = ?, time_zone = ?, image = ?, owner_id = ?, updated_at = ? WHERE id = ?`
+		res, err := tx.Exec(q, venue.Name, venue.Address, venue.Address2, venue.City, venue.State, venue.Zip, venue.Country,
+			lat, lng, timeZone(venue.TimeZone), venue.Image, owner, time.Now().UTC(), venue.ID)
+		if err != nil {
+			if strings.Contains(err.Error(), "Duplicate entry") {
+				return true, ErrDuplicateEntry
+			}
+			return false, err
+		}
+		n, err := res.RowsAffected()
+		if err == nil && n == 0 {
+			return true, ErrNotFound
+		}
+		return false, err
+	})
+}
+
+// venueConditions returns the conditions, each to be preceded by AND, and
+// arguments that apply f to the venue aliased v. It returns false when f
+// matches no venue. Category is left to the caller.
+func venueConditions(f schema.VenueFilter) (string, []interface{}, bool) {
+	var query string
+	var args []interface{}
+	if f.IDs != nil {
+		if len(f.IDs) == 0 {
+			return "", nil, false
+		}
+		query += ` AND v.id IN (?` + strings.Repeat(", ?", len(f.IDs)-1) + `)`
+		for _, id := range f.IDs {
+			args = append(args, id)
+		}
+	}
+	if f.City != "" {
+		query += ` AND v.city = ?`
+		args = append(args, f.City)
+	}
+	if f.Min != nil && f.Max != nil {
+		query += ` AND v.latitude BETWEEN ? AND ? AND v.longitude BETWEEN ? AND ?`
+		args = append(args, f.Min.Lat, f.Max.Lat, f.Min.Lng, f.Max.Lng)
+	}
+	return query, args, true
+}
+
+// Venues returns the venues matching f.
+func (s *Store) Venues(f schema.VenueFilter) ([]schema.Venue, error) {
+	var venues []schema.Venue
+	conditions, args, ok := venueConditions(f)
+	if !ok {
+		return venues, nil
+	}
+	query := `SELECT v.id, v.name, v.address, v.address2, v.city, v.state, v.zip, v.country, v.latitude, v.longitude, IFNULL(v.time_zone, ''), v.image, IFNULL(v.owner_id, 0)
+				FROM venue as v
+				WHERE 1 = 1` + conditions
+	if f.Category != "" {
+		query += ` AND EXISTS (SELECT 1 FROM menu as cm JOIN menu_item as ci on ci.menu_id = cm.id
+					WHERE cm.venue_id = v.id AND ci.category IN (?, 'all'))`
+		args = append(args, f.Category)
+	}
+	query += ` ORDER BY v.id`
+
+	err := s.transaction(s.db, func(tx *sql.Tx) (bool, error) {
+		rows, err := tx.Query(query, args...)
+		if err != nil {
+			return false, err
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var venue schema.Venue
+			var lat, lng sql.NullFloat64
+			err := rows.Scan(&venue.ID, &venue.Name, &venue.Address, &venue.Address2, &venue.City, &venue.State, &venue.Zip, &venue.Country, &lat, &lng, &venue.TimeZone, &venue.Image, &venue.OwnerID)
+			if err != nil {
+				return false, err
+			}
+			venue.Location = point(lat, lng)
+			venues = append(venues, venue)
+		}
+		return false, rows.Err()
+	})
+
+	return venues, err
+}
+
+// coordinates returns the latitude and longitude column values for p, NULL
+// when the venue hasn't been geocoded.
+func coordinates(p *schema.Point) (interface{}, interface{}) {
+	if p == nil {
+		return nil, nil
+	}
+	return p.Lat, p.Lng
+}
+
+// timeZone stores a venue without a time zone as NULL.
+func timeZone(name string) interface{} {
+	if name == "" {
+		return nil
+	}
+	return name
+}
+
+// point is the inverse of coordinates.
+func point(lat, lng sql.NullFloat64) *schema.Point {
+	if !lat.Valid || !lng.Valid {
+		return nil
+	}
+	return &schema.Point{Lat: lat.Float64, Lng: lng.Float64}
+}
+
+// nullPrice stores an item without a regular price as NULL. Prices are
+// kept in minor units with the item's currency alongside.
+func nullPrice(p *schema.Money) interface{} {
+	if p == nil {
+		return nil
+	}
+	return p.Amount
+}
+
+// price is the inverse of nullPrice.
+func price(amount sql.NullInt64, currency string) *schema.Money {
+	if !amount.Valid {
+		return nil
+	}
+	return &schema.Money{Amount: amount.Int64, Currency: currency}
+}
+
+// DeleteVenue removes a venue. Its menus, list memberships and favorites
+// are removed by their foreign key cascades; the deletion of its menus is
+// recorded in their history first.
+func (s *Store) DeleteVenue(id, userID int) error {
+	return s.transaction(s.db, func(tx *sql.Tx) (bool, error) {
+		var venueID int
+		err := tx.QueryRow(`SELECT id FROM venue WHERE id = ? FOR UPDATE`, id).Scan(&venueID)
+		if err == sql.ErrNoRows {
+			return true, ErrNotFound
+		}
+		if err != nil {
+			return false, err
+		}
+		rows, err := tx.Query(`SELECT id FROM menu WHERE venue_id = ? ORDER BY id FOR UPDATE`, id)
+		if err != nil {
+			return false, err
+		}
+		var menuIDs []int
+		for rows.Next() {
+			var menuID int
+			if err := rows.Scan(&menuID); err != nil {
+				rows.Close()
+				return false, err
+			}
+			menuIDs = append(menuIDs, menuID)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return false, err
+		}
+		for _, menuID := range menuIDs {
+			menu, err := lockMenu(tx, menuID)
+			if err != nil {
+				return false, err
+			}
+			if err := recordMenuDelete(tx, userID, menu); err != nil {
+				return false, err
+			}
+		}
+
+		_, err = tx.Exec(`DELETE FROM venue WHERE id = ?`, id)
+		return false, err
+	})
+}
+
+func (s *Store) MenuItemsGet(m schema.Menu) ([]schema.MenuItem, error) {
+	var menuItems []schema.MenuItem
+	err := s.transaction(s.db, func(tx *sql.Tx) (bool, error) {
+		query := `SELECT mi.id, m.id, mi.category, mi.price, mi.regular_price, mi.currency, mi.description, mi.position
+					FROM menu as m
+					JOIN menu_item as mi on m.id = mi.menu_id
+					WHERE m.venue_id = ?
+					ORDER BY m.id, mi.position, mi.id`
+		rows, err := tx.Query(query, m.VenueID)
+		if err != nil {
+			return false, err
+		}
+		for rows.Next() {
+			var mi schema.MenuItem
+			var regular sql.NullInt64
+			err := rows.Scan(&mi.ID, &mi.MenuID, &mi.Category, &mi.Price.Amount, &regular, &mi.Price.Currency, &mi.Description, &mi.Position)
+			if err != nil {
+				return false, err
+			}
+			mi.RegularPrice = price(regular, mi.Price.Currency)
+			menuItems = append(menuItems, mi)
+		}
+
+		return false, err
+	})
+
+	return menuItems, err
+}
+
+// menuItems returns the menu items matching where, which may go on to
+// order or lock them.
+func menuItems(tx *sql.Tx, where string, args ...interface{}) ([]schema.MenuItem, error) {
+	var menuItems []schema.MenuItem
+	query := `SELECT id, menu_id, category, price, regular_price, currency, description, position
+				FROM menu_item WHERE ` + where
+	rows, err := tx.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var mi schema.MenuItem
+		var regular sql.NullInt64
+		err := rows.Scan(&mi.ID, &mi.MenuID, &mi.Category, &mi.Price.Amount, &regular, &mi.Price.Currency, &mi.Description, &mi.Position)
+		if err != nil {
+			return nil, err
+		}
+		mi.RegularPrice = price(regular, mi.Price.Currency)
+		menuItems = append(menuItems, mi)
+	}
+	return menuItems, rows.Err()
+}
+
+// MenuItemsByMenus returns the items on each of the given menus.
+func (s *Store) MenuItemsByMenus(ids []int) ([]schema.MenuItem, error) {
+	var items []schema.MenuItem
+	if len(ids) == 0 {
+		return items, nil
+	}
+	args := make([]interface{}, len(ids))
+	for i, id := range ids {
+		args[i] = id
+	}
+	err := s.transaction(s.db, func(tx *sql.Tx) (bool, error) {
+		var err error
+		items, err = menuItems(tx, `menu_id IN (?`+strings.Repeat(", ?", len(ids)-1)+`) ORDER BY menu_id, position, id`, args...)
+		return false, err
+	})
+
+	return items, err
+}
+
+// MenuSchedules returns every scheduled menu at the venues matching f: those
+// with a weekly window or a special, of f.MenuType when it is set. Each
+// comes with its exceptions.
+func (s *Store) MenuSchedules(f schema.VenueFilter) ([]schema.MenuSchedule, error) {
+	var schedules []schema.MenuSchedule
+	query := `SELECT v.id, v.name, v.address, v.address2, v.city, v.state, v.zip, v.country, v.latitude, v.longitude, IFNULL(v.time_zone, ''), v.image, IFNULL(v.owner_id, 0),
+				m.id, IFNULL(md.id, 0), IFNULL(md.mon, 0), IFNULL(md.tue, 0), IFNULL(md.wed, 0), IFNULL(md.thu, 0), IFNULL(md.fri, 0), IFNULL(md.sat, 0), IFNULL(md.sun, 0),
+				IFNULL(md.start_time, '00:00:00'), IFNULL(md.end_time, '00:00:00'), IFNULL(md.overnight, 0)
+				FROM venue as v
+				JOIN menu as m on m.venue_id = v.id
+				LEFT JOIN menu_datetime as md on md.menu_id = m.id
+				WHERE (md.id IS NOT NULL OR EXISTS (SELECT 1 FROM menu_exception as me WHERE me.menu_id = m.id AND me.kind = 'special'))`
+	conditions, args, ok := venueConditions(f)
+	if !ok {
+		return schedules, nil
+	}
+	query += conditions
+	if f.Category != "" {
+		query += ` AND EXISTS (SELECT 1 FROM menu_item as ci WHERE ci.menu_id = m.id AND ci.category IN (?, 'all'))`
+		args = append(args, f.Category)
+	}
+	if f.MenuType != "" {
+		query += ` AND m.type = ?`
+		args = append(args, string(f.MenuType))
+	}
+	query += ` ORDER BY v.id, m.id, md.id`
+
+	err := s.transaction(s.db, func(tx *sql.Tx) (bool, error) {
+		rows, err := tx.Query(query, args...)
+		if err != nil {
+			return false, err
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var venue schema.Venue
+			var lat, lng sql.NullFloat64
+			var md schema.MenuDateTime
+			var startAt, endAt string
+			err := rows.Scan(&venue.ID, &venue.Name, &venue.Address, &venue.Address2, &venue.City, &venue.State, &venue.Zip, &venue.Country, &lat, &lng, &venue.TimeZone, &venue.Image, &venue.OwnerID,
+				&md.MenuID, &md.ID, &md.Monday, &md.Tuesday, &md.Wednesday, &md.Thursday, &md.Friday, &md.Saturday, &md.Sunday, &startAt, &endAt, &md.Overnight)
+			if err != nil {
+				return false, err
+			}
+			venue.Location = point(lat, lng)
+			if md.StartAt, err = schema.ParseTimeOfDay(startAt); err != nil {
+				return false, err
+			}
+			if md.EndAt, err = schema.ParseTimeOfDay(endAt); err != nil {
+				return false, err
+			}
+			// Rows are ordered by menu, so a menu's times are adjacent.
+			if n := len(schedules); n == 0 || schedules[n-1].MenuID != md.MenuID {
+				schedules = append(schedules, schema.MenuSchedule{Venue: venue, MenuID: md.MenuID})
+			}
+			// A menu with only specials has a single row without a window.
+			if md.ID != 0 {
+				last := &schedules[len(schedules)-1]
+				last.Times = append(last.Times, md)
+			}
+		}
+		if err := rows.Err(); err != nil || len(schedules) == 0 {
+			return false, err
+		}
+
+		byMenu := make(map[int]int, len(schedules))
+		ids := make([]interface{}, len(schedules))
+		for i, sc := range schedules {
+			byMenu[sc.MenuID] = i
+			ids[i] = sc.MenuID
+		}
+		exceptions, err := menuExceptions(tx, `menu_id IN (?`+strings.Repeat(", ?", len(ids)-1)+`) ORDER BY menu_id, start_date, id`, ids...)
+		if err != nil {
+			return false, err
+		}
+		for _, e := range exceptions {
+			sc := &schedules[byMenu[e.MenuID]]
+			sc.Exceptions = append(sc.Exceptions, e)
+		}
+		return false, nil
+	})
+
+	return schedules, err
+}
+
+// menuDateTimes returns the schedule windows matching where, which may go on
+// to order or lock them.
+func menuDateTimes(tx *sql.Tx, where string, args ...interface{}) ([]schema.MenuDateTime, error) {
+	var times []schema.MenuDateTime
+	query := `SELECT id, menu_id, mon, tue, wed, thu, fri, sat, sun, start_time, end_time, overnight
+				FROM menu_datetime WHERE ` + where
+	rows, err := tx.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var md schema.MenuDateTime
+		var startAt, endAt string
+		err := rows.Scan(&md.ID, &md.MenuID, &md.Monday, &md.Tuesday, &md.Wednesday, &md.Thursday, &md.Friday, &md.Saturday, &md.Sunday, &startAt, &endAt, &md.Overnight)
+		if err != nil {
+			return nil, err
+		}
+		if md.StartAt, err = schema.ParseTimeOfDay(startAt); err != nil {
+			return nil, err
+		}
+		if md.EndAt, err = schema.ParseTimeOfDay(endAt); err != nil {
+			return nil, err
+		}
+		times = append(times, md)
+	}
+	return times, rows.Err()
+}
+
+// MenuDateTimesGet returns the windows of a menu's schedule.
+func (s *Store) MenuDateTimesGet(menuID int) ([]schema.MenuDateTime, error) {
+	var times []schema.MenuDateTime
+	err := s.transaction(s.db, func(tx *sql.Tx) (bool, error) {
+		var err error
+		times, err = menuDateTimes(tx, `menu_id = ? ORDER BY id`, menuID)
+		return false, err
+	})
+
+	return times, err
+}
+
+// CreateMenuDateTime adds a window to a menu's schedule. The menu is locked
+// while its windows are checked, so concurrent writes can't leave two
+// windows covering the same time; a window that would is refused with the
+// error of schema.ValidateSchedule.
+func (s *Store) CreateMenuDateTime(md schema.MenuDateTime, userID int) (int, error) {
+	var id int
+	err := s.transaction(s.db, func(tx *sql.Tx) (bool, error) {
+		if _, err := lockMenu(tx, md.MenuID); err == ErrNotFound {
+			return true, err
+		} else if err != nil {
+			return false, err
+		}
+		times, err := menuDateTimes(tx, `menu_id = ? FOR UPDATE`, md.MenuID)
+		if err != nil {
+			return false, err
+		}
+		if err := schema.ValidateSchedule(append(times, md)); err != nil {
+			return true, err
+		}
+		q := `INSERT INTO menu_datetime (menu_id, mon, tue, wed, thu, fri, sat, sun, start_time, end_time, overnight, updated_at, created_at)
+				VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+		now := time.Now().UTC()
+		res, err := tx.Exec(q, md.MenuID, md.Monday, md.Tuesday, md.Wednesday, md.Thursday, md.Friday, md.Saturday, md.Sunday,
+			md.StartAt.String(), md.EndAt.String(), md.Overnight, now, now)
+		if err != nil {
+			return false, err
+		}
+		resID, err := res.LastInsertId()
+		if err != nil {
+			return false, err
+		}
+		id, md.ID = int(resID), int(resID)
+		return false, recordChange(tx, userID, md.MenuID, schema.ChangeSchedule, md.ID, nil, md)
+	})
+
+	return id, err
+}
+
+// UpdateMenuDateTime writes every field of md over the window with the same
+// id and menu. Like CreateMenuDateTime it refuses a window that would
+// overlap another of the menu's.
+func (s *Store) UpdateMenuDateTime(md schema.MenuDateTime, userID int) error {
+	return s.transaction(s.db, func(tx *sql.Tx) (bool, error) {
+		if _, err := lockMenu(tx, md.MenuID); err == ErrNotFound {
+			return true, err
+		} else if err != nil {
+			return false, err
+		}
+		times, err := menuDateTimes(tx, `menu_id = ? FOR UPDATE`, md.MenuID)
+		if err != nil {
+			return false, err
+		}
+		var before *schema.MenuDateTime
+		for i := range times {
+			if times[i].ID == md.ID {
+				old := times[i]
+				before, times[i] = &old, md
+			}
+		}
+		if before == nil {
+			return true, ErrNotFound
+		}
+		if err := schema.ValidateSchedule(times); err != nil {
+			return true, err
+		}
+		q := `UPDATE menu_datetime SET mon = ?, tue = ?, wed = ?, thu = ?, fri = ?, sat = ?, sun = ?,
+				start_time = ?, end_time = ?, overnight = ?, updated_at = ? WHERE id = ? AND menu_id = ?`
+		_, err = tx.Exec(q, md.Monday, md.Tuesday, md.Wednesday, md.Thursday, md.Friday, md.Saturday, md.Sunday,
+			md.StartAt.String(), md.EndAt.String(), md.Overnight, time.Now().UTC(), md.ID, md.MenuID)
+		if err != nil {
+			return false, err
+		}
+		return false, recordChange(tx, userID, md.MenuID, schema.ChangeSchedule, md.ID, *before, md)
+	})
+}
+
+func (s *Store) DeleteMenuDateTime(menuID, id, userID int) error {
+	return s.transaction(s.db, func(tx *sql.Tx) (bool, error) {
+		before, err := menuDateTimes(tx, `id = ? AND menu_id = ? FOR UPDATE`, id, menuID)
+		if err != nil {
+			return false, err
+		}
+		if len(before) == 0 {
+			return true, ErrNotFound
+		}
+		if _, err := tx.Exec(`DELETE FROM menu_datetime WHERE id = ? AND menu_id = ?`, id, menuID); err != nil {
+			return false, err
+		}
+		return false, recordChange(tx, userID, menuID, schema.ChangeSchedule, id, before[0], nil)
+	})
+}
+
+// menuExceptions returns the exceptions matching where, which may go on to
+// order or lock them.
+func menuExceptions(tx *sql.Tx, where string, args ...interface{}) ([]schema.MenuException, error) {
+	var exceptions []schema.MenuException
+	query := `SELECT id, menu_id, kind, start_date, end_date, start_time, end_time, overnight, note
+				FROM menu_exception WHERE ` + where
+	rows, err := tx.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var e schema.MenuException
+		var kind, startAt, endAt string
+		var startDate, endDate time.Time
+		err := rows.Scan(&e.ID, &e.MenuID, &kind, &startDate, &endDate, &startAt, &endAt, &e.Overnight, &e.Note)
+		if err != nil {
+			return nil, err
+		}
+		e.Kind = schema.ExceptionKind(kind)
+		e.StartDate, e.EndDate = schema.DateOf(startDate), schema.DateOf(endDate)
+		if e.StartAt, err = schema.ParseTimeOfDay(startAt); err != nil {
+			return nil, err
+		}
+		if e.EndAt, err = schema.ParseTimeOfDay(endAt); err != nil {
+			return nil, err
+		}
+		exceptions = append(exceptions, e)
+	}
+	return exceptions, rows.Err()
+}
+
+// MenuExceptionsGet returns the dated exceptions to a menu's schedule.
+func (s *Store) MenuExceptionsGet(menuID int) ([]schema.MenuException, error) {
+	var exceptions []schema.MenuException
+	err := s.transaction(s.db, func(tx *sql.Tx) (bool, error) {
+		var err error
+		exceptions, err = menuExceptions(tx, `menu_id = ? ORDER BY start_date, id`, menuID)
+		return false, err
+	})
+
+	return exceptions, err
+}
+
+func (s *Store) CreateMenuException(e schema.MenuException, userID int) (int, error) {
+	var id int
+	err := s.transaction(s.db, func(tx *sql.Tx) (bool, error) {
+		q := `INSERT INTO menu_exception (menu_id, kind, start_date, end_date, start_time, end_time, overnight, note, updated_at, created_at)
+				VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+		now := time.Now().UTC()
+		res, err := tx.Exec(q, e.MenuID, string(e.Kind), e.StartDate.String(), e.EndDate.String(),
+			e.StartAt.String(), e.EndAt.String(), e.Overnight, e.Note, now, now)
+		if err != nil {
+			return false, err
+		}
+		resID, err := res.LastInsertId()
+		if err != nil {
+			return false, err
+		}
+		id, e.ID = int(resID), int(resID)
+		return false, recordChange(tx, userID, e.MenuID, schema.ChangeException, e.ID, nil, e)
+	})
+
+	return id, err
+}
+
+// UpdateMenuException writes every field of e over the exception with the
+// same id and menu.
+func (s *Store) UpdateMenuException(e schema.MenuException, userID int) error {
+	return s.transaction(s.db, func(tx *sql.Tx) (bool, error) {
+		before, err := menuExceptions(tx, `id = ? AND menu_id = ? FOR UPDATE`, e.ID, e.MenuID)
+		if err != nil {
+			return false, err
+		}
+		if len(before) == 0 {
+			return true, ErrNotFound
+		}
+		q := `UPDATE menu_exception SET kind = ?, start_date = ?, end_date = ?, start_time = ?, end_time = ?, overnight = ?,
+				note = ?, updated_at = ? WHERE id = ? AND menu_id = ?`
+		_, err = tx.Exec(q, string(e.Kind), e.StartDate.String(), e.EndDate.String(),
+			e.StartAt.String(), e.EndAt.String(), e.Overnight, e.Note, time.Now().UTC(), e.ID, e.MenuID)
+		if err != nil {
+			return false, err
+		}
+		return false, recordChange(tx, userID, e.MenuID, schema.ChangeException, e.ID, before[0], e)
+	})
+}
+
+func (s *Store) DeleteMenuException(menuID, id, userID int) error {
+	return s.transaction(s.db, func(tx *sql.Tx) (bool, error) {
+		before, err := menuExceptions(tx, `id = ? AND menu_id = ? FOR UPDATE`, id, menuID)
+		if err != nil {
+			return false, err
+		}
+		if len(before) == 0 {
+			return true, ErrNotFound
+		}
+		if _, err := tx.Exec(`DELETE FROM menu_exception WHERE id = ? AND menu_id = ?`, id, menuID); err != nil {
+			return false, err
+		}
+		return false, recordChange(tx, userID, menuID, schema.ChangeException, id, before[0], nil)
+	})
+}
+
+func (s *Store) MenuGet(id int) (schema.Menu, error) {
+	var menu schema.Menu
+	err := s.transaction(s.db, func(tx *sql.Tx) (bool, error) {
+		row := tx.QueryRow(`SELECT id, venue_id, name, type FROM menu WHERE id = ?`, id)
+		var menuType string
+		err := row.Scan(&menu.ID, &menu.VenueID, &menu.Name, &menuType)
+		if err == sql.ErrNoRows {
+			return true, ErrNotFound
+		}
+		menu.Type = schema.MenuType(menuType)
+		return false, err
+	})
+
+	return menu, err
+}
+
+func (s *Store) CreateMenu(menu schema.Menu, userID int) (int, error) {
+	var id int
+	err := s.transaction(s.db, func(tx *sql.Tx) (bool, error) {
+		q := `INSERT INTO menu (venue_id, name, type, updated_at, created_at) VALUES (?, ?, ?, ?, ?)`
+		now := time.Now().UTC()
+		res, err := tx.Exec(q, menu.VenueID, menu.Name, menu.Type, now, now)
+		if err != nil {
+			if strings.Contains(err.Error(), "Duplicate entry") {
+				return true, ErrDuplicateEntry
+			}
+			return false, err
+		}
+		resID, err := res.LastInsertId()
+		if err != nil {
+			return false, err
+		}
+		id = int(resID)
+		menu.ID = id
+		return false, recordChange(tx, userID, id, schema.ChangeMenu, id, nil, menu)
+	})
+
+	return id, err
+}
+
+// MenusByVenues returns the menus of each of the given venues.
+func (s *Store) MenusByVenues(ids []int) ([]schema.Menu, error) {
+	var menus []schema.Menu
+	if len(ids) == 0 {
+		return menus, nil
+	}
+	args := make([]interface{}, len(ids))
+	for i, id := range ids {
+		args[i] = id
+	}
+	err := s.transaction(s.db, func(tx *sql.Tx) (bool, error) {
+		query := `SELECT id, venue_id, name, type FROM menu
+					WHERE venue_id IN (?` + strings.Repeat(", ?", len(ids)-1) + `) ORDER BY venue_id, id`
+		rows, err := tx.Query(query, args...)
+		if err != nil {
+			return false, err
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var menu schema.Menu
+			var menuType string
+			if err := rows.Scan(&menu.ID, &menu.VenueID, &menu.Name, &menuType); err != nil {
+				return false, err
+			}
+			menu.Type = schema.MenuType(menuType)
+			menus = append(menus, menu)
+		}
+		return false, rows.Err()
+	})
+
+	return menus, err
+}
+
+// MenusByVenue returns a venue's menus.
+func (s *Store) MenusByVenue(venueID int) ([]schema.Menu, error) {
+	var menus []schema.Menu
+	err := s.transaction(s.db, func(tx *sql.Tx) (bool, error) {
+		rows, err := tx.Query(`SELECT id, venue_id, name, type FROM menu WHERE venue_id = ? ORDER BY id`, venueID)
+		if err != nil {
+			return false, err
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var menu schema.Menu
+			var menuType string
+			if err := rows.Scan(&menu.ID, &menu.VenueID, &menu.Name, &menuType); err != nil {
+				return false, err
+			}
+			menu.Type = schema.MenuType(menuType)
+			menus = append(menus, menu)
+		}
+		return false, rows.Err()
+	})
+
+	return menus, err
+}
+
+// lockMenu reads menu id for update.
+func lockMenu(tx *sql.Tx, id int) (schema.Menu, error) {
+	var menu schema.Menu
+	var menuType string
+	row := tx.QueryRow(`SELECT id, venue_id, name, type FROM menu WHERE id = ? FOR UPDATE`, id)
+	err := row.Scan(&menu.ID, &menu.VenueID, &menu.Name, &menuType)
+	if err == sql.ErrNoRows {
+		return menu, ErrNotFound
+	}
+	menu.Type = schema.MenuType(menuType)
+	return menu, err
+}
+
+// UpdateMenu renames or retypes a menu.
+func (s *Store) UpdateMenu(menu schema.Menu, userID int) error {
+	return s.transaction(s.db, func(tx *sql.Tx) (bool, error) {
+		before, err := lockMenu(tx, menu.ID)
+		if err == ErrNotFound {
+			return true, err
+		}
+		if err != nil {
+			return false, err
+		}
+		q := `UPDATE menu SET name = ?, type = ?, updated_at = ? WHERE id = ?`
+		if _, err := tx.Exec(q, menu.Name, menu.Type, time.Now().UTC(), menu.ID); err != nil {
+			if strings.Contains(err.Error(), "Duplicate entry") {
+				return true, ErrDuplicateEntry
+			}
+			return false, err
+		}
+		after := before
+		after.Name, after.Type = menu.Name, menu.Type
+		return false, recordChange(tx, userID, menu.ID, schema.ChangeMenu, menu.ID, before, after)
+	})
+}
+
+// DeleteMenu removes a menu. Its items and schedule go with it.
+func (s *Store) DeleteMenu(id, userID int) error {
+	return s.transaction(s.db, func(tx *sql.Tx) (bool, error) {
+		menu, err := lockMenu(tx, id)
+		if err == ErrNotFound {
+			return true, err
+		}
+		if err != nil {
+			return false, err
+		}
+		if err := recordMenuDelete(tx, userID, menu); err != nil {
+			return false, err
+		}
+
+		_, err = tx.Exec(`DELETE FROM menu WHERE id = ?`, id)
+		return false, err
+	})
+}
+
+// recordMenuDelete records the deletion of menu, locked by tx, in its
+// history. The menu's items, schedule and exceptions go with it, so their
+// deletion is recorded first, while the menu's venue can be found. The
+// menu's own deletion is recorded last so that it is the first to be
+// undone.
+func recordMenuDelete(tx *sql.Tx, userID int, menu schema.Menu) error {
+	items, err := menuItems(tx, `menu_id = ? FOR UPDATE`, menu.ID)
+	if err != nil {
+		return err
+	}
+	for _, item := range items {
+		if err := recordChange(tx, userID, menu.ID, schema.ChangeMenuItem, item.ID, item, nil); err != nil {
+			return err
+		}
+	}
+	times, err := menuDateTimes(tx, `menu_id = ? FOR UPDATE`, menu.ID)
+	if err != nil {
+		return err
+	}
+	for _, md := range times {
+		if err := recordChange(tx, userID, menu.ID, schema.ChangeSchedule, md.ID, md, nil); err != nil {
+			return err
+		}
+	}
+	exceptions, err := menuExceptions(tx, `menu_id = ? FOR UPDATE`, menu.ID)
+	if err != nil {
+		return err
+	}
+	for _, e := range exceptions {
+		if err := recordChange(tx, userID, menu.ID, schema.ChangeException, e.ID, e, nil); err != nil {
+			return err
+		}
+	}
+	return recordChange(tx, userID, menu.ID, schema.ChangeMenu, menu.ID, menu, nil)
+}
+
+func (s *Store) AddToMenu(menuItem schema.MenuItem, userID int) (int, error) {
+	var id int
+	fmt.Printf("MenuItem: %+v", menuItem)
+	err := s.transaction(s.db, func(tx *sql.Tx) (bool, error) {
+		// New items go to the end of the menu.
+		var position int
+		err := tx.QueryRow(`SELECT IFNULL(MAX(position) + 1, 0) FROM menu_item WHERE menu_id = ? FOR UPDATE`, menuItem.MenuID).Scan(&position)
+		if err != nil {
+			return false, err
+		}
+		q := `INSERT INTO menu_item (menu_id, category, price, regular_price, currency, description, position, created_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`
+		res, err := tx.Exec(q, menuItem.MenuID, menuItem.Category, menuItem.Price.Amount, nullPrice(menuItem.RegularPrice), menuItem.Price.Currency, menuItem.Description, position, time.Now().UTC())
+		if err != nil {
+			if strings.Contains(err.Error(), "Duplicate entry") {
+				return true, ErrDuplicateEntry
+			}
+			return true, err
+		}
+		resID, err := res.LastInsertId()
+		if err != nil {
+			return false, err
+		}
+		id, menuItem.ID, menuItem.Position = int(resID), int(resID), position
+		return false, recordChange(tx, userID, menuItem.MenuID, schema.ChangeMenuItem, menuItem.ID, nil, menuItem)
+	})
+
+	return id, err
+}
+
+// MenuItemGet returns the menu item with the given id.
+func (s *Store) MenuItemGet(id int) (schema.MenuItem, error) {
+	var mi schema.MenuItem
+	err := s.transaction(s.db, func(tx *sql.Tx) (bool, error) {
+		items, err := menuItems(tx, `id = ?`, id)
+		if err != nil {
+			return false, err
+		}
+		if len(items) == 0 {
+			return true, ErrNotFound
+		}
+		mi = items[0]
+		return false, nil
+	})
+
+	return mi, err
+}
+
+// UpdateMenuItem saves an item's category, prices and description. Its menu
+// and position are left as they are.
+func (s *Store) UpdateMenuItem(menuItem schema.MenuItem, userID int) error {
+	return s.transaction(s.db, func(tx *sql.Tx) (bool, error) {
+		before, err := menuItems(tx, `id = ? FOR UPDATE`, menuItem.ID)
+		if err != nil {
+			return false, err
+		}
+		if len(before) == 0 {
+			return true, ErrNotFound
+		}
+		q := `UPDATE menu_item SET category = ?, price = ?, regular_price = ?, currency = ?, description = ?, updated_at = ? WHERE id = ?`
+		_, err = tx.Exec(q, menuItem.Category, menuItem.Price.Amount, nullPrice(menuItem.RegularPrice), menuItem.Price.Currency, menuItem.Description, time.Now().UTC(), menuItem.ID)
+		if err != nil {
+			return false, err
+		}
+		menuItem.MenuID, menuItem.Position = before[0].MenuID, before[0].Position
+		return false, recordChange(tx, userID, menuItem.MenuID, schema.ChangeMenuItem, menuItem.ID, before[0], menuItem)
+	})
+}
+
+func (s *Store) DeleteMenuItem(id, userID int) error {
+	return s.transaction(s.db, func(tx *sql.Tx) (bool, error) {
+		before, err := menuItems(tx, `id = ? FOR UPDATE`, id)
+		if err != nil {
+			return false, err
+		}
+		if len(before) == 0 {
+			return true, ErrNotFound
+		}
+		if _, err := tx.Exec(`DELETE FROM menu_item WHERE id = ?`, id); err != nil {
+			return false, err
+		}
+		return false, recordChange(tx, userID, before[0].MenuID, schema.ChangeMenuItem, id, before[0], nil)
+	})
+}
+
+// ReorderMenuItems gives the items of a menu the positions of their ids in
+// ids, which must list every item on the menu exactly once; otherwise
+// ErrInvalidOrder is returned and nothing moves. Each item that moves is
+// recorded as an update.
+func (s *Store) ReorderMenuItems(menuID int, ids []int, userID int) error {
+	return s.transaction(s.db, func(tx *sql.Tx) (bool, error) {
+		items, err := menuItems(tx, `menu_id = ? FOR UPDATE`, menuID)
+		if err != nil {
+			return false, err
+		}
+		if len(ids) != len(items) {
+			return true, ErrInvalidOrder
+		}
+		byID := make(map[int]schema.MenuItem, len(items))
+		for _, item := range items {
+			byID[item.ID] = item
+		}
+		now := time.Now().UTC()
+		for i, id := range ids {
+			before, ok := byID[id]
+			if !ok {
+				return true, ErrInvalidOrder
+			}
+			// Forgetting each id as it is placed catches one listed twice.
+			delete(byID, id)
+			if before.Position == i {
+				continue
+			}
+			q := `UPDATE menu_item SET position = ?, updated_at = ? WHERE id = ? AND menu_id = ?`
+			if _, err := tx.Exec(q, i, now, id, menuID); err != nil {
+				return false, err
+			}
+			after := before
+			after.Position = i
+			if err := recordChange(tx, userID, menuID, schema.ChangeMenuItem, id, before, after); err != nil {
+				return false, err
+			}
+		}
+		return false, nil
+	})
+}
+
+// recordChange adds a change to a record on menuID to the history of the
+// menu's venue. before and after are the record's values, nil where it
+// didn't exist. A userID of 0 is stored as unknown.
+func recordChange(tx *sql.Tx, userID, menuID int, entity schema.ChangeEntity, entityID int, before, after interface{}) error {
+	action := schema.ChangeUpdate
+	switch {
+	case before == nil:
+		action = schema.ChangeCreate
+	case after == nil:
+		action = schema.ChangeDelete
+	}
+	var oldValue, newValue interface{}
+	if before != nil {
+		b, err := json.Marshal(before)
+		if err != nil {
+			return err
+		}
+		oldValue = string(b)
+	}
+	if after != nil {
+		b, err := json.Marshal(after)
+		if err != nil {
+			return err
+		}
+		newValue = string(b)
+	}
+	var user interface{}
+	if userID != 0 {
+		user = userID
+	}
+	q := `INSERT INTO menu_change (venue_id, menu_id, entity, entity_id, action, user_id, old_value, new_value, created_at)
+			SELECT venue_id, id, ?, ?, ?, ?, ?, ?, ? FROM menu WHERE id = ?`
+	_, err := tx.Exec(q, string(entity), entityID, string(action), user, oldValue, newValue, time.Now().UTC(), menuID)
+	return err
+}
+
+// MenuChanges returns the entries of a venue's menu history matching f,
+// newest first.
+func (s *Store) MenuChanges(f schema.MenuChangeFilter) ([]schema.MenuChange, error) {
+	var changes []schema.MenuChange
+	query := `SELECT id, venue_id, menu_id, entity, entity_id, action, IFNULL(user_id, 0), old_value, new_value, created_at
+				FROM menu_change WHERE venue_id = ?`
+	args := []interface{}{f.VenueID}
+	if f.MenuID != 0 {
+		query += ` AND menu_id = ?`
+		args = append(args, f.MenuID)
+	}
+	if !f.After.IsZero() {
+		query += ` AND created_at > ?`
+		args = append(args, f.After.UTC())
+	}
+	if !f.Until.IsZero() {
+		query += ` AND created_at <= ?`
+		args = append(args, f.Until.UTC())
+	}
+	query += ` ORDER BY id DESC`
+	if f.Limit > 0 {
+		query += ` LIMIT ?`
+		args = append(args, f.Limit)
+	}
+
+	err := s.transaction(s.db, func(tx *sql.Tx) (bool, error) {
+		rows, err := tx.Query(query, args...)
+		if err != nil {
+			return false, err
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var c schema.MenuChange
+			var entity, action string
+			var oldValue, newValue sql.NullString
+			err := rows.Scan(&c.ID, &c.VenueID, &c.MenuID, &entity, &c.EntityID, &action, &c.UserID, &oldValue, &newValue, &c.At)
+			if err != nil {
+				return false, err
+			}
+			c.Entity, c.Action = schema.ChangeEntity(entity), schema.ChangeAction(action)
+			if oldValue.Valid {
+				c.Before = json.RawMessage(oldValue.String)
+			}
+			if newValue.Valid {
+				c.After = json.RawMessage(newValue.String)
+			}
+			changes = append(changes, c)
+		}
+		return false, rows.Err()
+	})
+
+	return changes, err
+}
+
 var retryN int64 = 3
 
 func (s *Store) transaction(c *sql.DB, fn func(tx *sql.Tx) (bool, error)) error {
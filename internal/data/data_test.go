@@ -0,0 +1,261 @@
+package data
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/kernkw/hhapp/internal/schema"
+)
+
+// fakeDriver is a database/sql driver that records the statements run
+// against it and answers them from a fakeDB, so that what the Store does
+// inside a transaction can be checked without MySQL.
+type fakeDriver struct {
+	mu  sync.Mutex
+	dbs map[string]*fakeDB
+}
+
+var fakes = &fakeDriver{dbs: map[string]*fakeDB{}}
+
+func init() {
+	sql.Register("fake", fakes)
+}
+
+// fakeDB answers the statements of one test. rows gives the columns and
+// rows a query returns and affected the rows an exec changes; either may
+// be nil to return nothing.
+type fakeDB struct {
+	rows     func(q string, args []driver.Value) ([]string, [][]driver.Value)
+	affected func(q string, args []driver.Value) int64
+
+	mu  sync.Mutex
+	log []string
+}
+
+// newFakeStore returns a Store backed by db.
+func newFakeStore(t *testing.T, db *fakeDB) *Store {
+	fakes.mu.Lock()
+	name := fmt.Sprintf("%s-%d", t.Name(), len(fakes.dbs))
+	fakes.dbs[name] = db
+	fakes.mu.Unlock()
+	conn, err := sql.Open("fake", name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &Store{db: conn}
+}
+
+// statements returns the statements run, each with its arguments, and
+// BEGIN, COMMIT and ROLLBACK around transactions.
+func (db *fakeDB) statements() []string {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	return append([]string(nil), db.log...)
+}
+
+func (db *fakeDB) record(s string) {
+	db.mu.Lock()
+	db.log = append(db.log, s)
+	db.mu.Unlock()
+}
+
+func (d *fakeDriver) Open(name string) (driver.Conn, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	db, ok := d.dbs[name]
+	if !ok {
+		return nil, errors.New("fake: unknown database " + name)
+	}
+	return &fakeConn{db}, nil
+}
+
+type fakeConn struct{ db *fakeDB }
+
+func (c *fakeConn) Prepare(q string) (driver.Stmt, error) { return &fakeStmt{c.db, q}, nil }
+func (c *fakeConn) Close() error                          { return nil }
+func (c *fakeConn) Begin() (driver.Tx, error) {
+	c.db.record("BEGIN")
+	return c, nil
+}
+func (c *fakeConn) Commit() error {
+	c.db.record("COMMIT")
+	return nil
+}
+func (c *fakeConn) Rollback() error {
+	c.db.record("ROLLBACK")
+	return nil
+}
+
+type fakeStmt struct {
+	db *fakeDB
+	q  string
+}
+
+func (s *fakeStmt) Close() error  { return nil }
+func (s *fakeStmt) NumInput() int { return -1 }
+
+func (s *fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.db.record(statement(s.q, args))
+	var n int64 = 1
+	if s.db.affected != nil {
+		n = s.db.affected(s.q, args)
+	}
+	return fakeResult(n), nil
+}
+
+// fakeResult reports n rows affected and, for an insert, the id n.
+type fakeResult int64
+
+func (r fakeResult) LastInsertId() (int64, error) { return int64(r), nil }
+func (r fakeResult) RowsAffected() (int64, error) { return int64(r), nil }
+
+func (s *fakeStmt) Query(args []driver.Value) (driver.Rows, error) {
+	s.db.record(statement(s.q, args))
+	rows := &fakeRows{}
+	if s.db.rows != nil {
+		rows.columns, rows.values = s.db.rows(s.q, args)
+	}
+	return rows, nil
+}
+
+// statement joins q's words with single spaces and appends its arguments.
+func statement(q string, args []driver.Value) string {
+	s := strings.Join(strings.Fields(q), " ")
+	for _, arg := range args {
+		s += fmt.Sprintf(" [%v]", arg)
+	}
+	return s
+}
+
+type fakeRows struct {
+	columns []string
+	values  [][]driver.Value
+}
+
+func (r *fakeRows) Columns() []string { return r.columns }
+func (r *fakeRows) Close() error      { return nil }
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if len(r.values) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.values[0])
+	r.values = r.values[1:]
+	return nil
+}
+
+// committed reports whether the statements include one starting with prefix
+// between a BEGIN and the COMMIT that ends it.
+func committed(statements []string, prefix string) bool {
+	in, found := false, false
+	for _, s := range statements {
+		switch {
+		case s == "BEGIN":
+			in, found = true, false
+		case s == "COMMIT":
+			if found {
+				return true
+			}
+			in = false
+		case in && strings.HasPrefix(s, prefix):
+			found = true
+		}
+	}
+	return false
+}
+
+func TestUpdateUser_email(t *testing.T) {
+	db := &fakeDB{}
+	store := newFakeStore(t, db)
+	if err := store.UpdateUser(schema.User{ID: 42, Email: "new@domain.com"}); err != nil {
+		t.Fatal(err)
+	}
+	// Subscriptions made with the old address go in the same transaction
+	// as the address itself.
+	if !committed(db.statements(), "DELETE FROM user_notifications WHERE user_id = ? AND email <> ? [42] [new@domain.com]") {
+		t.Errorf("subscriptions to the old address were kept: %q", db.statements())
+	}
+}
+
+func TestRevokeUserRefreshTokens_generation(t *testing.T) {
+	db := &fakeDB{}
+	store := newFakeStore(t, db)
+	if err := store.RevokeUserRefreshTokens(42); err != nil {
+		t.Fatal(err)
+	}
+	// Outstanding access tokens are revoked along with the refresh tokens.
+	if !committed(db.statements(), "UPDATE user SET token_generation = token_generation + 1 WHERE id = ? [42]") {
+		t.Errorf("token generation was not bumped: %q", db.statements())
+	}
+}
+
+// scheduleDB holds menu 7 with a Mon-Fri 15:00-18:00 window.
+func scheduleDB() *fakeDB {
+	return &fakeDB{rows: func(q string, args []driver.Value) ([]string, [][]driver.Value) {
+		switch {
+		case strings.Contains(q, "FROM menu WHERE"):
+			return []string{"id", "venue_id", "name", "type"}, [][]driver.Value{{int64(7), int64(5), "Happy Hour", "happy_hour"}}
+		case strings.Contains(q, "FROM menu_datetime WHERE"):
+			return []string{"id", "menu_id", "mon", "tue", "wed", "thu", "fri", "sat", "sun", "start_time", "end_time", "overnight"},
+				[][]driver.Value{{int64(1), int64(7), true, true, true, true, true, false, false, "15:00:00", "18:00:00", false}}
+		}
+		return nil, nil
+	}}
+}
+
+func TestCreateMenuDateTime_overlap(t *testing.T) {
+	tests := []struct {
+		md   schema.MenuDateTime
+		want error
+	}{
+		{schema.MenuDateTime{MenuID: 7, Friday: true, StartAt: 17 * 60 * 60, EndAt: 19 * 60 * 60}, schema.ErrOverlappingWindows},
+		{schema.MenuDateTime{MenuID: 7, Friday: true, StartAt: 18 * 60 * 60, EndAt: 20 * 60 * 60}, nil},
+	}
+	for _, tt := range tests {
+		db := scheduleDB()
+		store := newFakeStore(t, db)
+		if _, err := store.CreateMenuDateTime(tt.md, 42); err != tt.want {
+			t.Errorf("%+v: got %v want %v", tt.md, err, tt.want)
+		}
+		statements := db.statements()
+		// The windows are checked with the menu locked, in the transaction
+		// that adds the new one.
+		if !strings.HasPrefix(statements[1], "SELECT id, venue_id, name, type FROM menu WHERE id = ? FOR UPDATE") {
+			t.Errorf("%+v: menu not locked first: %q", tt.md, statements)
+		}
+		if inserted := committed(statements, "INSERT INTO menu_datetime"); inserted != (tt.want == nil) {
+			t.Errorf("%+v: inserted %v: %q", tt.md, inserted, statements)
+		}
+	}
+}
+
+func TestUpdateMenuDateTime_overlap(t *testing.T) {
+	db := scheduleDB()
+	store := newFakeStore(t, db)
+	// The window may cover the time it covered itself.
+	md := schema.MenuDateTime{ID: 1, MenuID: 7, Monday: true, StartAt: 16 * 60 * 60, EndAt: 19 * 60 * 60}
+	if err := store.UpdateMenuDateTime(md, 42); err != nil {
+		t.Errorf("moved window: %v", err)
+	}
+	md.ID = 2
+	if err := store.UpdateMenuDateTime(md, 42); err != ErrNotFound {
+		t.Errorf("unknown window: got %v want %v", err, ErrNotFound)
+	}
+}
+
+func TestMenuSchedules_type(t *testing.T) {
+	db := &fakeDB{}
+	store := newFakeStore(t, db)
+	if _, err := store.MenuSchedules(schema.VenueFilter{City: "Denver", MenuType: schema.MenuHappyHour}); err != nil {
+		t.Fatal(err)
+	}
+	statements := db.statements()
+	if len(statements) < 2 || !strings.HasSuffix(statements[1], "AND m.type = ? ORDER BY v.id, m.id, md.id [Denver] [happy_hour]") {
+		t.Errorf("searched without the menu type: %q", statements)
+	}
+}
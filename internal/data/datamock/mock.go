@@ -9,22 +9,74 @@ import (
 // similarly-named callback fields. Calling a method for which no
 // corresponding callback has been set will result in a panic.
 type Mock struct {
-	CreateUser_          func(schema.User) (int, error)
-	CreateUserFavorite_  func(schema.UserFavorite) (int, error)
-	UserFavoritesList_   func(schema.UserFavorite) ([]schema.Venue, error)
-	UserFavoritesGet_    func(schema.UserFavorite) (schema.Venue, error)
-	UserFavoritesDelete_ func(int) error
-	GetUser_             func(schema.User) (schema.User, error)
-	CreateVenue_         func(schema.Venue) (int, error)
-	CreateVenueList_     func(schema.VenueList) (int, error)
-	VenueListAdd_        func(schema.VenueListAdd) (int, error)
-	CreateMenu_          func(schema.Menu) (int, error)
-	AddToMenu_           func(schema.MenuItem) (int, error)
-	VenueListGet_        func(schema.VenueList) (schema.VenueList, error)
-	VenueByList_         func(schema.VenueList) ([]schema.Venue, error)
-	VenuesByList_        func(int) ([]schema.Venue, error)
-	VenueGet_            func(schema.Venue) (schema.Venue, error)
-	MenuItemsGet_        func(schema.Menu) ([]schema.MenuItem, error)
+	CreateUser_              func(schema.User) (int, error)
+	CreateUserFavorite_      func(schema.UserFavorite) (int, error)
+	UserFavoritesList_       func(schema.UserFavorite) ([]schema.Venue, error)
+	UserFavoritesGet_        func(schema.UserFavorite) (schema.Venue, error)
+	UserFavoritesDelete_     func(schema.UserFavorite) error
+	GetUser_                 func(schema.User) (schema.User, error)
+	GetUserByID_             func(int) (schema.User, error)
+	UpdateUser_              func(schema.User) error
+	DeleteUser_              func(int) error
+	CreateRefreshToken_      func(schema.RefreshToken) (int, error)
+	RefreshTokenGet_         func(string) (schema.RefreshToken, error)
+	RevokeRefreshToken_      func(string) error
+	RevokeUserRefreshTokens_ func(int) error
+	UserTokenGeneration_     func(int) (int, error)
+	GetUserByEmail_          func(string) (schema.User, error)
+	UpdateUserPassword_      func(int, string) error
+	RehashUserPassword_      func(int, string, string) error
+	CreatePasswordReset_     func(schema.PasswordReset) (int, error)
+	GetPasswordReset_        func(string) (schema.PasswordReset, error)
+	UsePasswordReset_        func(string) (schema.PasswordReset, error)
+	VerifyUserEmail_         func(int, string) error
+	CreateUserNotification_  func(schema.UserNotifications) (int, error)
+	UpdateUserRoles_         func(int, []schema.Role) error
+	CreateAPIKey_            func(schema.APIKey) (int, error)
+	APIKeysList_             func() ([]schema.APIKey, error)
+	RevokeAPIKey_            func(int) error
+	UseAPIKey_               func(string) (schema.APIKey, error)
+	TOTPSecretGet_           func(int) (string, error)
+	SetTOTPSecret_           func(int, string) error
+	EnableTOTP_              func(int, []string) error
+	DisableTOTP_             func(int) error
+	UseRecoveryCode_         func(int, string) error
+	UseTOTPStep_             func(int, int64) error
+	IdentityGet_             func(string, string) (schema.Identity, error)
+	CreateIdentity_          func(schema.Identity) (int, error)
+	MenuGet_                 func(int) (schema.Menu, error)
+	CreateVenue_             func(schema.Venue) (int, error)
+	CreateVenueList_         func(schema.VenueList) (int, error)
+	VenueListAdd_            func(schema.VenueListAdd) (int, error)
+	CreateMenu_              func(schema.Menu, int) (int, error)
+	AddToMenu_               func(schema.MenuItem, int) (int, error)
+	MenuItemGet_             func(int) (schema.MenuItem, error)
+	UpdateMenuItem_          func(schema.MenuItem, int) error
+	DeleteMenuItem_          func(int, int) error
+	ReorderMenuItems_        func(int, []int, int) error
+	VenueListGet_            func(schema.VenueList) (schema.VenueList, error)
+	VenueByList_             func(schema.VenueList) ([]schema.Venue, error)
+	VenuesByList_            func(int) ([]schema.Venue, error)
+	VenueGet_                func(schema.Venue) (schema.Venue, error)
+	UpdateVenue_             func(schema.Venue) error
+	DeleteVenue_             func(int, int) error
+	Venues_                  func(schema.VenueFilter) ([]schema.Venue, error)
+	MenuItemsByMenus_        func([]int) ([]schema.MenuItem, error)
+	MenuSchedules_           func(schema.VenueFilter) ([]schema.MenuSchedule, error)
+	MenuDateTimesGet_        func(int) ([]schema.MenuDateTime, error)
+	CreateMenuDateTime_      func(schema.MenuDateTime, int) (int, error)
+	UpdateMenuDateTime_      func(schema.MenuDateTime, int) error
+	DeleteMenuDateTime_      func(int, int, int) error
+	MenuExceptionsGet_       func(int) ([]schema.MenuException, error)
+	CreateMenuException_     func(schema.MenuException, int) (int, error)
+	UpdateMenuException_     func(schema.MenuException, int) error
+	DeleteMenuException_     func(int, int, int) error
+	MenuChanges_             func(schema.MenuChangeFilter) ([]schema.MenuChange, error)
+	MenusByVenue_            func(int) ([]schema.Menu, error)
+	MenusByVenues_           func([]int) ([]schema.Menu, error)
+	UpdateMenu_              func(schema.Menu, int) error
+	DeleteMenu_              func(int, int) error
+	MenuItemsGet_            func(schema.Menu) ([]schema.MenuItem, error)
 }
 
 func (s *Mock) CreateUser(u schema.User) (int, error) { return s.CreateUser_(u) }
@@ -37,17 +89,145 @@ func (s *Mock) UserFavoritesList(u schema.UserFavorite) ([]schema.Venue, error)
 func (s *Mock) UserFavoritesGet(u schema.UserFavorite) (schema.Venue, error) {
 	return s.UserFavoritesGet_(u)
 }
-func (s *Mock) UserFavoritesDelete(id int) error                           { return s.UserFavoritesDelete_(id) }
-func (s *Mock) GetUser(u schema.User) (schema.User, error)                 { return s.GetUser_(u) }
-func (s *Mock) CreateVenue(v schema.Venue) (int, error)                    { return s.CreateVenue_(v) }
-func (s *Mock) CreateVenueList(vl schema.VenueList) (int, error)           { return s.CreateVenueList_(vl) }
-func (s *Mock) VenueListAdd(vla schema.VenueListAdd) (int, error)          { return s.VenueListAdd_(vla) }
-func (s *Mock) CreateMenu(menu schema.Menu) (int, error)                   { return s.CreateMenu_(menu) }
-func (s *Mock) AddToMenu(menuItem schema.MenuItem) (int, error)            { return s.AddToMenu_(menuItem) }
-func (s *Mock) VenueListGet(vl schema.VenueList) (schema.VenueList, error) { return s.VenueListGet_(vl) }
-func (s *Mock) VenuesByList(id int) ([]schema.Venue, error)                { return s.VenuesByList_(id) }
-func (s *Mock) VenueGet(v schema.Venue) (schema.Venue, error)              { return s.VenueGet_(v) }
-func (s *Mock) MenuItemsGet(m schema.Menu) ([]schema.MenuItem, error)      { return s.MenuItemsGet_(m) }
+func (s *Mock) UserFavoritesDelete(u schema.UserFavorite) error {
+	return s.UserFavoritesDelete_(u)
+}
+func (s *Mock) GetUser(u schema.User) (schema.User, error)        { return s.GetUser_(u) }
+func (s *Mock) CreateVenue(v schema.Venue) (int, error)           { return s.CreateVenue_(v) }
+func (s *Mock) CreateVenueList(vl schema.VenueList) (int, error)  { return s.CreateVenueList_(vl) }
+func (s *Mock) VenueListAdd(vla schema.VenueListAdd) (int, error) { return s.VenueListAdd_(vla) }
+func (s *Mock) CreateMenu(menu schema.Menu, userID int) (int, error) {
+	return s.CreateMenu_(menu, userID)
+}
+func (s *Mock) AddToMenu(menuItem schema.MenuItem, userID int) (int, error) {
+	return s.AddToMenu_(menuItem, userID)
+}
+func (s *Mock) VenueListGet(vl schema.VenueList) (schema.VenueList, error) {
+	return s.VenueListGet_(vl)
+}
+func (s *Mock) VenuesByList(id int) ([]schema.Venue, error)           { return s.VenuesByList_(id) }
+func (s *Mock) VenueGet(v schema.Venue) (schema.Venue, error)         { return s.VenueGet_(v) }
+func (s *Mock) MenuItemsGet(m schema.Menu) ([]schema.MenuItem, error) { return s.MenuItemsGet_(m) }
+func (s *Mock) GetUserByID(id int) (schema.User, error)               { return s.GetUserByID_(id) }
+func (s *Mock) CreateRefreshToken(rt schema.RefreshToken) (int, error) {
+	return s.CreateRefreshToken_(rt)
+}
+func (s *Mock) RefreshTokenGet(hash string) (schema.RefreshToken, error) {
+	return s.RefreshTokenGet_(hash)
+}
+func (s *Mock) RevokeRefreshToken(hash string) error { return s.RevokeRefreshToken_(hash) }
+func (s *Mock) RevokeUserRefreshTokens(userID int) error {
+	return s.RevokeUserRefreshTokens_(userID)
+}
+func (s *Mock) UserTokenGeneration(userID int) (int, error) {
+	return s.UserTokenGeneration_(userID)
+}
+func (s *Mock) GetUserByEmail(email string) (schema.User, error) {
+	return s.GetUserByEmail_(email)
+}
+func (s *Mock) UpdateUserPassword(userID int, password string) error {
+	return s.UpdateUserPassword_(userID, password)
+}
+func (s *Mock) RehashUserPassword(userID int, oldHash, newHash string) error {
+	return s.RehashUserPassword_(userID, oldHash, newHash)
+}
+func (s *Mock) CreatePasswordReset(pr schema.PasswordReset) (int, error) {
+	return s.CreatePasswordReset_(pr)
+}
+func (s *Mock) GetPasswordReset(hash string) (schema.PasswordReset, error) {
+	return s.GetPasswordReset_(hash)
+}
+func (s *Mock) UsePasswordReset(hash string) (schema.PasswordReset, error) {
+	return s.UsePasswordReset_(hash)
+}
+func (s *Mock) VerifyUserEmail(userID int, email string) error {
+	return s.VerifyUserEmail_(userID, email)
+}
+func (s *Mock) CreateUserNotification(un schema.UserNotifications) (int, error) {
+	return s.CreateUserNotification_(un)
+}
+func (s *Mock) UpdateUserRoles(userID int, roles []schema.Role) error {
+	return s.UpdateUserRoles_(userID, roles)
+}
+func (s *Mock) MenuGet(id int) (schema.Menu, error)          { return s.MenuGet_(id) }
+func (s *Mock) UpdateUser(user schema.User) error            { return s.UpdateUser_(user) }
+func (s *Mock) DeleteUser(id int) error                      { return s.DeleteUser_(id) }
+func (s *Mock) CreateAPIKey(k schema.APIKey) (int, error)    { return s.CreateAPIKey_(k) }
+func (s *Mock) APIKeysList() ([]schema.APIKey, error)        { return s.APIKeysList_() }
+func (s *Mock) RevokeAPIKey(id int) error                    { return s.RevokeAPIKey_(id) }
+func (s *Mock) UseAPIKey(hash string) (schema.APIKey, error) { return s.UseAPIKey_(hash) }
+func (s *Mock) TOTPSecretGet(userID int) (string, error)     { return s.TOTPSecretGet_(userID) }
+func (s *Mock) SetTOTPSecret(userID int, secret string) error {
+	return s.SetTOTPSecret_(userID, secret)
+}
+func (s *Mock) EnableTOTP(userID int, recoveryHashes []string) error {
+	return s.EnableTOTP_(userID, recoveryHashes)
+}
+func (s *Mock) DisableTOTP(userID int) error { return s.DisableTOTP_(userID) }
+func (s *Mock) UseRecoveryCode(userID int, hash string) error {
+	return s.UseRecoveryCode_(userID, hash)
+}
+func (s *Mock) UseTOTPStep(userID int, step int64) error {
+	return s.UseTOTPStep_(userID, step)
+}
+func (s *Mock) IdentityGet(provider, subject string) (schema.Identity, error) {
+	return s.IdentityGet_(provider, subject)
+}
+func (s *Mock) CreateIdentity(identity schema.Identity) (int, error) {
+	return s.CreateIdentity_(identity)
+}
+func (s *Mock) UpdateVenue(venue schema.Venue) error { return s.UpdateVenue_(venue) }
+func (s *Mock) DeleteVenue(id, userID int) error     { return s.DeleteVenue_(id, userID) }
+func (s *Mock) Venues(f schema.VenueFilter) ([]schema.Venue, error) {
+	return s.Venues_(f)
+}
+func (s *Mock) MenuItemsByMenus(ids []int) ([]schema.MenuItem, error) {
+	return s.MenuItemsByMenus_(ids)
+}
+func (s *Mock) MenuSchedules(f schema.VenueFilter) ([]schema.MenuSchedule, error) {
+	return s.MenuSchedules_(f)
+}
+func (s *Mock) MenuDateTimesGet(menuID int) ([]schema.MenuDateTime, error) {
+	return s.MenuDateTimesGet_(menuID)
+}
+func (s *Mock) CreateMenuDateTime(md schema.MenuDateTime, userID int) (int, error) {
+	return s.CreateMenuDateTime_(md, userID)
+}
+func (s *Mock) UpdateMenuDateTime(md schema.MenuDateTime, userID int) error {
+	return s.UpdateMenuDateTime_(md, userID)
+}
+func (s *Mock) DeleteMenuDateTime(menuID, id, userID int) error {
+	return s.DeleteMenuDateTime_(menuID, id, userID)
+}
+func (s *Mock) MenuExceptionsGet(menuID int) ([]schema.MenuException, error) {
+	return s.MenuExceptionsGet_(menuID)
+}
+func (s *Mock) CreateMenuException(e schema.MenuException, userID int) (int, error) {
+	return s.CreateMenuException_(e, userID)
+}
+func (s *Mock) UpdateMenuException(e schema.MenuException, userID int) error {
+	return s.UpdateMenuException_(e, userID)
+}
+func (s *Mock) DeleteMenuException(menuID, id, userID int) error {
+	return s.DeleteMenuException_(menuID, id, userID)
+}
+func (s *Mock) MenuChanges(f schema.MenuChangeFilter) ([]schema.MenuChange, error) {
+	return s.MenuChanges_(f)
+}
+func (s *Mock) MenusByVenue(venueID int) ([]schema.Menu, error) { return s.MenusByVenue_(venueID) }
+func (s *Mock) MenusByVenues(ids []int) ([]schema.Menu, error)  { return s.MenusByVenues_(ids) }
+func (s *Mock) UpdateMenu(menu schema.Menu, userID int) error {
+	return s.UpdateMenu_(menu, userID)
+}
+func (s *Mock) DeleteMenu(id, userID int) error             { return s.DeleteMenu_(id, userID) }
+func (s *Mock) MenuItemGet(id int) (schema.MenuItem, error) { return s.MenuItemGet_(id) }
+func (s *Mock) UpdateMenuItem(menuItem schema.MenuItem, userID int) error {
+	return s.UpdateMenuItem_(menuItem, userID)
+}
+func (s *Mock) DeleteMenuItem(id, userID int) error { return s.DeleteMenuItem_(id, userID) }
+func (s *Mock) ReorderMenuItems(menuID int, ids []int, userID int) error {
+	return s.ReorderMenuItems_(menuID, ids, userID)
+}
 
 // func (s *Mock) Close()                                     { return }
 
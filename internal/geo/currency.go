@@ -0,0 +1,29 @@
+package geo
+
+import "github.com/kernkw/hhapp/internal/schema"
+
+// countryCurrencies maps ISO 3166-1 alpha-2 codes to the ISO 4217 currency
+// venues there price in.
+var countryCurrencies = map[string]string{
+	"ae": "AED", "ar": "ARS", "at": "EUR", "au": "AUD", "be": "EUR",
+	"bh": "BHD", "br": "BRL", "ca": "CAD", "ch": "CHF", "cl": "CLP",
+	"cn": "CNY", "co": "COP", "cy": "EUR", "cz": "CZK", "de": "EUR",
+	"dk": "DKK", "ee": "EUR", "es": "EUR", "fi": "EUR", "fr": "EUR",
+	"gb": "GBP", "gr": "EUR", "hk": "HKD", "hr": "EUR", "hu": "HUF",
+	"id": "IDR", "ie": "EUR", "il": "ILS", "in": "INR", "is": "ISK",
+	"it": "EUR", "jp": "JPY", "kr": "KRW", "kw": "KWD", "lt": "EUR",
+	"lu": "EUR", "lv": "EUR", "mt": "EUR", "mx": "MXN", "my": "MYR",
+	"nl": "EUR", "no": "NOK", "nz": "NZD", "pe": "PEN", "ph": "PHP",
+	"pl": "PLN", "pt": "EUR", "se": "SEK", "sg": "SGD", "si": "EUR",
+	"sk": "EUR", "th": "THB", "tr": "TRY", "tw": "TWD", "us": "USD",
+	"vn": "VND", "za": "ZAR",
+}
+
+// Currency returns the currency of the country a venue is in, or
+// schema.DefaultCurrency when the country isn't known.
+func Currency(country string) string {
+	if c, ok := countryCurrencies[Country(country)]; ok {
+		return c
+	}
+	return schema.DefaultCurrency
+}
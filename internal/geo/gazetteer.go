@@ -0,0 +1,114 @@
+package geo
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/kernkw/hhapp/internal/schema"
+)
+
+// countryAliases maps the ways venues spell a country to the ISO 3166-1
+// alpha-2 code the gazetteer uses.
+var countryAliases = map[string]string{
+	"usa":            "us",
+	"united states":  "us",
+	"can":            "ca",
+	"canada":         "ca",
+	"mex":            "mx",
+	"mexico":         "mx",
+	"gbr":            "gb",
+	"uk":             "gb",
+	"united kingdom": "gb",
+}
+
+// Country normalises a country as entered on a venue to its lower-case
+// ISO 3166-1 alpha-2 code where it is known.
+func Country(c string) string {
+	c = strings.ToLower(strings.TrimSpace(c))
+	if a, ok := countryAliases[c]; ok {
+		return a
+	}
+	return c
+}
+
+// Gazetteer is an offline Geocoder backed by a table of postal codes and
+// places. Lookups try the postal code first and fall back to the city.
+type Gazetteer struct {
+	zips   map[string]schema.Point
+	cities map[string]schema.Point
+}
+
+// LoadGazetteer reads a gazetteer file. See ReadGazetteer for the format.
+func LoadGazetteer(path string) (*Gazetteer, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ReadGazetteer(f)
+}
+
+// ReadGazetteer reads CSV rows of country,state,city,zip,lat,lng. Lines
+// starting with # are comments. Rows with an empty zip only match by city.
+func ReadGazetteer(r io.Reader) (*Gazetteer, error) {
+	cr := csv.NewReader(r)
+	cr.Comment = '#'
+	cr.FieldsPerRecord = 6
+	g := &Gazetteer{
+		zips:   make(map[string]schema.Point),
+		cities: make(map[string]schema.Point),
+	}
+	for {
+		rec, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if rec[0] == "country" {
+			continue
+		}
+		lat, err := strconv.ParseFloat(strings.TrimSpace(rec[4]), 64)
+		if err != nil {
+			return nil, fmt.Errorf("gazetteer: bad latitude %q", rec[4])
+		}
+		lng, err := strconv.ParseFloat(strings.TrimSpace(rec[5]), 64)
+		if err != nil {
+			return nil, fmt.Errorf("gazetteer: bad longitude %q", rec[5])
+		}
+		p := schema.Point{Lat: lat, Lng: lng}
+		if err := p.Validate(); err != nil {
+			return nil, err
+		}
+		country := Country(rec[0])
+		if zip := strings.TrimSpace(rec[3]); zip != "" {
+			g.zips[key(country, zip)] = p
+			continue
+		}
+		g.cities[key(country, rec[1], rec[2])] = p
+	}
+	return g, nil
+}
+
+func (g *Gazetteer) Geocode(v schema.Venue) (schema.Point, error) {
+	country := Country(v.Country)
+	if p, ok := g.zips[key(country, v.Zip)]; ok && v.Zip != "" {
+		return p, nil
+	}
+	if p, ok := g.cities[key(country, v.State, v.City)]; ok {
+		return p, nil
+	}
+	return schema.Point{}, ErrNoMatch
+}
+
+func key(parts ...string) string {
+	for i, p := range parts {
+		parts[i] = strings.ToLower(strings.TrimSpace(p))
+	}
+	return strings.Join(parts, "|")
+}
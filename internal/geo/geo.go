@@ -0,0 +1,68 @@
+// Package geo locates venues and measures the distances between them.
+package geo
+
+import (
+	"errors"
+	"math"
+
+	"github.com/kernkw/hhapp/internal/config"
+	"github.com/kernkw/hhapp/internal/schema"
+)
+
+// ErrNoMatch is returned by a Geocoder that can't place an address.
+var ErrNoMatch = errors.New("address could not be geocoded")
+
+// earthRadiusKM is the mean radius of the earth.
+const earthRadiusKM = 6371.0088
+
+// Geocoder finds the coordinates of a venue's address.
+type Geocoder interface {
+	Geocode(v schema.Venue) (schema.Point, error)
+}
+
+// NewGeocoder returns a Gazetteer loaded from cfg.GazetteerPath, or a
+// Geocoder that never matches when no path is configured.
+func NewGeocoder(cfg *config.Config) (Geocoder, error) {
+	if cfg.GazetteerPath == "" {
+		return noGeocoder{}, nil
+	}
+	return LoadGazetteer(cfg.GazetteerPath)
+}
+
+type noGeocoder struct{}
+
+func (noGeocoder) Geocode(v schema.Venue) (schema.Point, error) {
+	return schema.Point{}, ErrNoMatch
+}
+
+// Distance returns the great-circle distance between a and b in kilometres.
+func Distance(a, b schema.Point) float64 {
+	lat1, lat2 := radians(a.Lat), radians(b.Lat)
+	dLat := lat2 - lat1
+	dLng := radians(b.Lng - a.Lng)
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return 2 * earthRadiusKM * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+// Bounds returns the south-west and north-east corners of a box that
+// contains every point within radiusKM of center. It is meant as a cheap
+// index-friendly prefilter ahead of Distance. Boxes are not split at the
+// antimeridian; longitudes are clamped instead.
+func Bounds(center schema.Point, radiusKM float64) (schema.Point, schema.Point) {
+	dLat := degrees(radiusKM / earthRadiusKM)
+	dLng := 180.0
+	if c := math.Cos(radians(center.Lat)); c > 1e-9 {
+		dLng = math.Min(180, dLat/c)
+	}
+	min := schema.Point{Lat: math.Max(-90, center.Lat-dLat), Lng: math.Max(-180, center.Lng-dLng)}
+	max := schema.Point{Lat: math.Min(90, center.Lat+dLat), Lng: math.Min(180, center.Lng+dLng)}
+	// Near the poles every longitude is in range.
+	if max.Lat == 90 || min.Lat == -90 {
+		min.Lng, max.Lng = -180, 180
+	}
+	return min, max
+}
+
+func radians(d float64) float64 { return d * math.Pi / 180 }
+func degrees(r float64) float64 { return r * 180 / math.Pi }
@@ -0,0 +1,188 @@
+package geo
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/kernkw/hhapp/internal/schema"
+)
+
+func TestDistance(t *testing.T) {
+	tests := []struct {
+		a, b schema.Point
+		want float64
+	}{
+		{schema.Point{Lat: 39.7392, Lng: -104.9903}, schema.Point{Lat: 39.7392, Lng: -104.9903}, 0},
+		// Denver to Boulder.
+		{schema.Point{Lat: 39.7392, Lng: -104.9903}, schema.Point{Lat: 40.0150, Lng: -105.2705}, 38.8},
+		// London to Paris.
+		{schema.Point{Lat: 51.5074, Lng: -0.1278}, schema.Point{Lat: 48.8566, Lng: 2.3522}, 343.6},
+		// Across the antimeridian.
+		{schema.Point{Lat: 0, Lng: 179.5}, schema.Point{Lat: 0, Lng: -179.5}, 111.2},
+	}
+	for _, tt := range tests {
+		if got := Distance(tt.a, tt.b); math.Abs(got-tt.want) > 0.1 {
+			t.Errorf("Distance(%v, %v): got %.2f want %.1f", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestBounds(t *testing.T) {
+	center := schema.Point{Lat: 39.7392, Lng: -104.9903}
+	min, max := Bounds(center, 10)
+	for _, p := range []schema.Point{
+		{Lat: min.Lat, Lng: center.Lng},
+		{Lat: max.Lat, Lng: center.Lng},
+		{Lat: center.Lat, Lng: min.Lng},
+		{Lat: center.Lat, Lng: max.Lng},
+	} {
+		if d := Distance(center, p); math.Abs(d-10) > 0.05 {
+			t.Errorf("edge %v is %.3fkm from the center, want 10", p, d)
+		}
+	}
+
+	min, max = Bounds(schema.Point{Lat: 89.99, Lng: 10}, 10)
+	if max.Lat != 90 || min.Lng != -180 || max.Lng != 180 {
+		t.Errorf("bounds near the pole: got %v %v, want every longitude", min, max)
+	}
+}
+
+const testGazetteer = `# test places
+country,state,city,zip,lat,lng
+us,co,denver,80202,39.7528,-104.9997
+us,co,denver,,39.7392,-104.9903
+gb,,london,,51.5074,-0.1278
+`
+
+func TestGazetteer_Geocode(t *testing.T) {
+	g, err := ReadGazetteer(strings.NewReader(testGazetteer))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		venue schema.Venue
+		want  schema.Point
+		err   error
+	}{
+		{schema.Venue{City: "Denver", State: "CO", Zip: "80202", Country: "USA"}, schema.Point{Lat: 39.7528, Lng: -104.9997}, nil},
+		{schema.Venue{City: "denver ", State: "co", Zip: "80299", Country: "United States"}, schema.Point{Lat: 39.7392, Lng: -104.9903}, nil},
+		{schema.Venue{City: "London", Country: "UK"}, schema.Point{Lat: 51.5074, Lng: -0.1278}, nil},
+		{schema.Venue{City: "Denver", State: "CO", Country: "CA"}, schema.Point{}, ErrNoMatch},
+		{schema.Venue{}, schema.Point{}, ErrNoMatch},
+	}
+	for _, tt := range tests {
+		got, err := g.Geocode(tt.venue)
+		if got != tt.want || err != tt.err {
+			t.Errorf("Geocode(%+v): got %v %v want %v %v", tt.venue, got, err, tt.want, tt.err)
+		}
+	}
+}
+
+func TestReadGazetteer_invalid(t *testing.T) {
+	for _, in := range []string{
+		"us,co,denver,80202,north,-104.9997\n",
+		"us,co,denver,80202,95,-104.9997\n",
+		"us,co,denver,80202\n",
+	} {
+		if _, err := ReadGazetteer(strings.NewReader(in)); err == nil {
+			t.Errorf("ReadGazetteer(%q): expected an error", in)
+		}
+	}
+}
+
+func TestLoadGazetteer_shipped(t *testing.T) {
+	g, err := LoadGazetteer("../../data/gazetteer.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := g.Geocode(schema.Venue{City: "Denver", State: "CO", Zip: "80202", Country: "USA"}); err != nil {
+		t.Errorf("shipped gazetteer can't place downtown Denver: %v", err)
+	}
+}
+
+const testTimeZones = `country,state,zone,lat,lng
+US,CO,America/Denver,39.7392,-104.9903
+US,TX,America/Chicago,29.7604,-95.3698
+US,TX,America/Denver,31.7619,-106.4850
+US,,America/New_York,40.7128,-74.0060
+US,,America/Los_Angeles,34.0522,-118.2437
+GB,,Europe/London,51.5074,-0.1278
+`
+
+func TestTimeZones_Zone(t *testing.T) {
+	tz, err := ReadTimeZones(strings.NewReader(testTimeZones))
+	if err != nil {
+		t.Skip(err) // no zoneinfo on this machine
+	}
+	tests := []struct {
+		venue schema.Venue
+		want  string
+	}{
+		{schema.Venue{State: "CO", Country: "USA"}, "America/Denver"},
+		{schema.Venue{State: "tx", Country: "US"}, "America/Chicago"},
+		{schema.Venue{State: "TX", Country: "US", Location: &schema.Point{Lat: 31.8, Lng: -106.4}}, "America/Denver"},
+		{schema.Venue{State: "OR", Country: "US"}, "America/New_York"},
+		{schema.Venue{State: "OR", Country: "US", Location: &schema.Point{Lat: 45.5, Lng: -122.7}}, "America/Los_Angeles"},
+		{schema.Venue{City: "London", Country: "UK"}, "Europe/London"},
+		{schema.Venue{Location: &schema.Point{Lat: 53.5, Lng: -2.2}}, "Europe/London"},
+		{schema.Venue{City: "Paris", Country: "FR"}, ""},
+	}
+	for _, tt := range tests {
+		if got := tz.Zone(tt.venue); got != tt.want {
+			t.Errorf("Zone(%+v): got %q want %q", tt.venue, got, tt.want)
+		}
+	}
+	if got := (*TimeZones)(nil).Zone(schema.Venue{State: "CO", Country: "US"}); got != "" {
+		t.Errorf("nil TimeZones returned %q", got)
+	}
+}
+
+func TestReadTimeZones_invalid(t *testing.T) {
+	for _, in := range []string{
+		"US,CO,America/Mountain,39.7392,-104.9903\n",
+		"US,CO,,39.7392,-104.9903\n",
+		"US,CO,America/Denver,north,-104.9903\n",
+	} {
+		if _, err := ReadTimeZones(strings.NewReader(in)); err == nil {
+			t.Errorf("ReadTimeZones(%q): expected an error", in)
+		}
+	}
+}
+
+func TestLoadTimeZones_shipped(t *testing.T) {
+	tz, err := LoadTimeZones("../../data/time_zones.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := tz.Zone(schema.Venue{City: "Denver", State: "CO", Country: "USA"}); got != "America/Denver" {
+		t.Errorf("shipped time zones put Denver in %q", got)
+	}
+}
+
+func TestCurrency(t *testing.T) {
+	tests := []struct {
+		country, want string
+	}{
+		{"USA", "USD"},
+		{"United Kingdom", "GBP"},
+		{" mx ", "MXN"},
+		{"DE", "EUR"},
+		{"JP", "JPY"},
+		{"", schema.DefaultCurrency},
+		{"Atlantis", schema.DefaultCurrency},
+	}
+	for _, tt := range tests {
+		if got := Currency(tt.country); got != tt.want {
+			t.Errorf("Currency(%q): got %q want %q", tt.country, got, tt.want)
+		}
+		if !schema.ValidCurrency(Currency(tt.country)) {
+			t.Errorf("Currency(%q) is not a currency Money can hold", tt.country)
+		}
+	}
+	for country, c := range countryCurrencies {
+		if !schema.ValidCurrency(c) {
+			t.Errorf("%s: %s is not a currency Money can hold", country, c)
+		}
+	}
+}
@@ -0,0 +1,165 @@
+package geo
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kernkw/hhapp/internal/config"
+	"github.com/kernkw/hhapp/internal/schema"
+)
+
+// TimeZones defaults a venue's IANA time zone from a table of reference
+// places. A venue takes the zone of its state, then of its country, then of
+// the nearest place in the table. Where the state or country spans several
+// zones the first row listed wins unless the venue has coordinates, in
+// which case the nearest of the state's or country's places does.
+type TimeZones struct {
+	states    map[string][]zoneRef
+	countries map[string][]zoneRef
+	all       []zoneRef
+}
+
+type zoneRef struct {
+	zone string
+	at   schema.Point
+}
+
+// NewTimeZones loads the table at cfg.TimeZonesPath. It returns nil, which
+// defaults no zones, when no path is configured.
+func NewTimeZones(cfg *config.Config) (*TimeZones, error) {
+	if cfg.TimeZonesPath == "" {
+		return nil, nil
+	}
+	return LoadTimeZones(cfg.TimeZonesPath)
+}
+
+// LoadTimeZones reads a time zone table. See ReadTimeZones for the format.
+func LoadTimeZones(path string) (*TimeZones, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ReadTimeZones(f)
+}
+
+// ReadTimeZones reads CSV rows of country,state,zone,lat,lng. Lines
+// starting with # are comments. Rows with an empty state stand for the
+// country as a whole.
+func ReadTimeZones(r io.Reader) (*TimeZones, error) {
+	cr := csv.NewReader(r)
+	cr.Comment = '#'
+	cr.FieldsPerRecord = 5
+	tz := &TimeZones{
+		states:    make(map[string][]zoneRef),
+		countries: make(map[string][]zoneRef),
+	}
+	countryWide := make(map[string]bool)
+	for {
+		rec, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if rec[0] == "country" {
+			continue
+		}
+		zone := strings.TrimSpace(rec[2])
+		if zone == "" {
+			return nil, fmt.Errorf("time zones: missing zone for %q", rec[0])
+		}
+		if _, err := LoadLocation(zone); err != nil {
+			return nil, fmt.Errorf("time zones: %v", err)
+		}
+		lat, err := strconv.ParseFloat(strings.TrimSpace(rec[3]), 64)
+		if err != nil {
+			return nil, fmt.Errorf("time zones: bad latitude %q", rec[3])
+		}
+		lng, err := strconv.ParseFloat(strings.TrimSpace(rec[4]), 64)
+		if err != nil {
+			return nil, fmt.Errorf("time zones: bad longitude %q", rec[4])
+		}
+		ref := zoneRef{zone, schema.Point{Lat: lat, Lng: lng}}
+		if err := ref.at.Validate(); err != nil {
+			return nil, err
+		}
+		country := Country(rec[0])
+		tz.all = append(tz.all, ref)
+		// A country's own rows replace the states that stood in for it.
+		if strings.TrimSpace(rec[1]) == "" {
+			if !countryWide[country] {
+				countryWide[country] = true
+				tz.countries[country] = nil
+			}
+			tz.countries[country] = append(tz.countries[country], ref)
+			continue
+		}
+		s := key(country, rec[1])
+		tz.states[s] = append(tz.states[s], ref)
+		if !countryWide[country] {
+			tz.countries[country] = append(tz.countries[country], ref)
+		}
+	}
+	return tz, nil
+}
+
+// Zone returns the time zone name for v, or "" when the table has nothing
+// that applies.
+func (tz *TimeZones) Zone(v schema.Venue) string {
+	if tz == nil {
+		return ""
+	}
+	country := Country(v.Country)
+	if refs := tz.states[key(country, v.State)]; len(refs) > 0 {
+		return nearestZone(refs, v.Location)
+	}
+	if refs := tz.countries[country]; len(refs) > 0 {
+		return nearestZone(refs, v.Location)
+	}
+	if v.Location != nil && len(tz.all) > 0 {
+		return nearestZone(tz.all, v.Location)
+	}
+	return ""
+}
+
+func nearestZone(refs []zoneRef, at *schema.Point) string {
+	best := refs[0]
+	if at == nil {
+		return best.zone
+	}
+	for _, ref := range refs[1:] {
+		if Distance(*at, ref.at) < Distance(*at, best.at) {
+			best = ref
+		}
+	}
+	return best.zone
+}
+
+var locations = struct {
+	sync.Mutex
+	m map[string]*time.Location
+}{m: make(map[string]*time.Location)}
+
+// LoadLocation is time.LoadLocation with the zones already read kept in
+// memory.
+func LoadLocation(name string) (*time.Location, error) {
+	locations.Lock()
+	defer locations.Unlock()
+	if loc, ok := locations.m[name]; ok {
+		return loc, nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, err
+	}
+	locations.m[name] = loc
+	return loc, nil
+}
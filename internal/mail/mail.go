@@ -0,0 +1,27 @@
+// Package mail delivers the transactional emails sent by the API.
+package mail
+
+import (
+	"github.com/kernkw/hhapp/internal/config"
+)
+
+// Message is a plain text email.
+type Message struct {
+	To      string `json:"to"`
+	Subject string `json:"subject"`
+	Body    string `json:"body"`
+}
+
+// Mailer sends messages.
+type Mailer interface {
+	Send(m Message) error
+}
+
+// NewMailer returns an SMTP mailer when an SMTP server is configured and
+// otherwise an Outbox that writes messages to cfg.MailOutboxDir.
+func NewMailer(cfg *config.Config) (Mailer, error) {
+	if cfg.SMTPAddr != "" {
+		return NewSMTPMailer(cfg.SMTPAddr, cfg.SMTPUser, cfg.SMTPPassword, cfg.MailFrom), nil
+	}
+	return NewOutbox(cfg.MailOutboxDir)
+}
@@ -0,0 +1,64 @@
+package mail
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Outbox is a Mailer that writes each message as a JSON file in a directory
+// instead of delivering it. It is meant for local development and tests.
+type Outbox struct {
+	mu  sync.Mutex
+	dir string
+	n   int
+}
+
+// NewOutbox returns an Outbox writing to dir, creating it if needed.
+func NewOutbox(dir string) (*Outbox, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	return &Outbox{dir: dir}, nil
+}
+
+func (o *Outbox) Send(m Message) error {
+	b, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.n++
+	name := fmt.Sprintf("%d-%06d.json", time.Now().UnixNano(), o.n)
+	return ioutil.WriteFile(filepath.Join(o.dir, name), b, 0644)
+}
+
+// Messages returns every message in the outbox, oldest first.
+func (o *Outbox) Messages() ([]Message, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	names, err := filepath.Glob(filepath.Join(o.dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(names)
+	var msgs []Message
+	for _, name := range names {
+		b, err := ioutil.ReadFile(name)
+		if err != nil {
+			return nil, err
+		}
+		var m Message
+		if err := json.Unmarshal(b, &m); err != nil {
+			return nil, err
+		}
+		msgs = append(msgs, m)
+	}
+	return msgs, nil
+}
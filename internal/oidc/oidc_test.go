@@ -0,0 +1,131 @@
+package oidc_test
+
+import (
+	"context"
+	"encoding/base64"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kernkw/hhapp/internal/config"
+	"github.com/kernkw/hhapp/internal/oidc"
+	"github.com/kernkw/hhapp/internal/oidc/oidctest"
+)
+
+func newProvider(t *testing.T) (*oidc.Provider, *oidctest.Issuer) {
+	iss, err := oidctest.NewIssuer("client", "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := oidc.NewProvider(&config.Config{
+		OIDCProvider:     "test",
+		OIDCIssuer:       iss.URL,
+		OIDCClientID:     "client",
+		OIDCClientSecret: "secret",
+		OIDCScopes:       []string{"openid", "email"},
+		PublicURL:        "http://hhapp.test",
+	})
+	return p, iss
+}
+
+func TestNewProvider_unconfigured(t *testing.T) {
+	if p := oidc.NewProvider(&config.Config{}); p != nil {
+		t.Errorf("expected no provider without an issuer")
+	}
+}
+
+func TestAuthCodeURL(t *testing.T) {
+	p, iss := newProvider(t)
+	defer iss.Close()
+
+	raw, err := p.AuthCodeURL(context.Background(), "state", "nonce")
+	if err != nil {
+		t.Fatal(err)
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	q := u.Query()
+	if !strings.HasPrefix(raw, iss.URL+"/authorize?") ||
+		q.Get("client_id") != "client" ||
+		q.Get("redirect_uri") != "http://hhapp.test/oidc/callback" ||
+		q.Get("scope") != "openid email" ||
+		q.Get("state") != "state" || q.Get("nonce") != "nonce" {
+		t.Errorf("unexpected authorization url %v", raw)
+	}
+}
+
+func TestExchangeAndVerify(t *testing.T) {
+	p, iss := newProvider(t)
+	defer iss.Close()
+
+	claims := iss.Claims("user-1", "nonce")
+	claims["email"] = "test@domain.com"
+	claims["email_verified"] = true
+	iss.AddCode("code", claims)
+
+	ctx := context.Background()
+	raw, err := p.Exchange(ctx, "code")
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := p.Verify(ctx, raw, "nonce")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Subject != "user-1" || c.Email != "test@domain.com" || !c.EmailVerified {
+		t.Errorf("unexpected claims %+v", c)
+	}
+	if _, err := p.Exchange(ctx, "code"); err == nil {
+		t.Errorf("authorization code was accepted twice")
+	}
+}
+
+func TestVerify_rejects(t *testing.T) {
+	p, iss := newProvider(t)
+	defer iss.Close()
+
+	tests := []struct {
+		name  string
+		claim string
+		value interface{}
+		nonce string
+	}{
+		{"wrong audience", "aud", "someone-else", "nonce"},
+		{"audience list", "aud", []string{"someone-else"}, "nonce"},
+		{"wrong issuer", "iss", "https://evil.example", "nonce"},
+		{"expired", "exp", time.Now().Add(-time.Hour).Unix(), "nonce"},
+		{"future", "iat", time.Now().Add(time.Hour).Unix(), "nonce"},
+		{"nonce", "nonce", "nonce", "other"},
+	}
+	for _, tt := range tests {
+		claims := iss.Claims("user-1", "nonce")
+		claims[tt.claim] = tt.value
+		raw, err := iss.Sign(claims)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := p.Verify(context.Background(), raw, tt.nonce); err == nil {
+			t.Errorf("%v: token was accepted", tt.name)
+		}
+	}
+}
+
+func TestVerify_unsigned(t *testing.T) {
+	p, iss := newProvider(t)
+	defer iss.Close()
+
+	raw, err := iss.Sign(iss.Claims("user-1", "nonce"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	parts := strings.Split(raw, ".")
+	none := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none","kid":"test-key"}`))
+	for _, forged := range []string{none + "." + parts[1] + ".", parts[0] + "." + parts[1] + ".AAAA"} {
+		if _, err := p.Verify(context.Background(), forged, "nonce"); err != oidc.ErrInvalidIDToken {
+			t.Errorf("got %v want %v", err, oidc.ErrInvalidIDToken)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package route
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/kernkw/hhapp/internal/auth"
+	"github.com/kernkw/hhapp/internal/data"
+	"github.com/kernkw/hhapp/internal/schema"
+)
+
+// apiKeyPrefix marks strings issued as API keys so they are recognisable
+// in config files and secret scanners.
+const apiKeyPrefix = "hh_"
+
+/*
+Test with this curl command:
+curl -H "Content-Type: application/json" -H "Authorization: Bearer $TOKEN" -d '{"user_id": 1, "name": "partner", "scope": "read"}' http://localhost:8080/api_keys
+*/
+func APIKeyCreate(db data.Database) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		admin, ok := auth.FromContext(r.Context())
+		if !ok {
+			writeError(w, http.StatusUnauthorized, nil)
+			return
+		}
+		var req struct {
+			UserID int    `json:"user_id"`
+			Name   string `json:"name"`
+			Scope  string `json:"scope"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, http.StatusUnprocessableEntity, err)
+			return
+		}
+		defer r.Body.Close()
+		if req.Name == "" {
+			writeError(w, http.StatusUnprocessableEntity, schema.RequiredFieldError("name"))
+			return
+		}
+		if req.Scope == "" {
+			req.Scope = string(schema.ScopeRead)
+		}
+		scope, err := schema.ParseAPIKeyScope(req.Scope)
+		if err != nil {
+			writeError(w, http.StatusUnprocessableEntity, err)
+			return
+		}
+		if _, err := db.GetUserByID(req.UserID); err != nil {
+			writeError(w, http.StatusUnprocessableEntity, err)
+			return
+		}
+
+		token, _, err := auth.NewOpaqueToken()
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		key := apiKeyPrefix + token
+		k := schema.APIKey{
+			UserID:    req.UserID,
+			Name:      req.Name,
+			Prefix:    key[:len(apiKeyPrefix)+8],
+			KeyHash:   auth.HashToken(key),
+			Scope:     scope,
+			CreatedBy: admin.ID,
+			CreatedAt: time.Now().UTC(),
+		}
+		id, err := db.CreateAPIKey(k)
+		if err != nil {
+			writeError(w, http.StatusConflict, err)
+			return
+		}
+		k.ID = id
+
+		// The key itself is only ever shown in this response.
+		type envelope struct {
+			Status string        `json:"status"`
+			Key    string        `json:"key"`
+			Data   schema.APIKey `json:"data"`
+		}
+		writeJSON(w, http.StatusCreated, envelope{http.StatusText(http.StatusCreated), key, k})
+	})
+}
+
+/*
+Test with this curl command:
+curl -H "Authorization: Bearer $TOKEN" http://localhost:8080/api_keys
+*/
+func APIKeysList(db data.Database) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keys, err := db.APIKeysList()
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		type envelope struct {
+			Data []schema.APIKey `json:"data"`
+		}
+		writeJSON(w, http.StatusOK, envelope{keys})
+	})
+}
+
+/*
+Test with this curl command:
+curl -X DELETE -H "Authorization: Bearer $TOKEN" http://localhost:8080/api_keys/1
+*/
+func APIKeyRevoke(db data.Database) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(mux.Vars(r)["id"])
+		if err != nil {
+			writeError(w, http.StatusUnprocessableEntity, err)
+			return
+		}
+		err = db.RevokeAPIKey(id)
+		if err == data.ErrNotFound {
+			writeError(w, http.StatusNotFound, err)
+			return
+		}
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		type envelope struct {
+			Status string `json:"status"`
+		}
+		writeJSON(w, http.StatusOK, envelope{http.StatusText(http.StatusOK)})
+	})
+}
@@ -0,0 +1,120 @@
+package route
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kernkw/hhapp/internal/auth"
+	"github.com/kernkw/hhapp/internal/data"
+	"github.com/kernkw/hhapp/internal/data/datamock"
+	"github.com/kernkw/hhapp/internal/schema"
+)
+
+func TestAPIKeyCreate(t *testing.T) {
+	var stored schema.APIKey
+	mockStore := &datamock.Mock{
+		GetUserByID_: func(id int) (schema.User, error) {
+			return schema.User{ID: id}, nil
+		},
+		CreateAPIKey_: func(k schema.APIKey) (int, error) {
+			stored = k
+			return 3, nil
+		},
+	}
+
+	req, err := http.NewRequest("POST", "/api_keys", bytes.NewReader([]byte(`{"user_id":7,"name":"partner","scope":"write"}`)))
+	checkError(err, t)
+	req = withUser(req, schema.User{ID: 1, Roles: []schema.Role{schema.RoleAdmin}})
+	rr := httptest.NewRecorder()
+
+	http.HandlerFunc(APIKeyCreate(mockStore)).
+		ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusCreated {
+		t.Fatalf("handler returned wrong status code: got %v want %v",
+			status, http.StatusCreated)
+	}
+	var resp struct {
+		Key string `json:"key"`
+	}
+	checkError(json.Unmarshal(rr.Body.Bytes(), &resp), t)
+	if !strings.HasPrefix(resp.Key, stored.Prefix) || stored.KeyHash != auth.HashToken(resp.Key) {
+		t.Errorf("stored key does not match issued key: %+v", stored)
+	}
+	if stored.UserID != 7 || stored.Scope != schema.ScopeWrite || stored.CreatedBy != 1 {
+		t.Errorf("unexpected key stored: %+v", stored)
+	}
+	if strings.Contains(rr.Body.String(), stored.KeyHash) {
+		t.Errorf("response leaks the key hash")
+	}
+}
+
+func TestAPIKeyCreate_bad_scope(t *testing.T) {
+	req, err := http.NewRequest("POST", "/api_keys", bytes.NewReader([]byte(`{"user_id":7,"name":"partner","scope":"admin"}`)))
+	checkError(err, t)
+	req = withUser(req, schema.User{ID: 1, Roles: []schema.Role{schema.RoleAdmin}})
+	rr := httptest.NewRecorder()
+
+	http.HandlerFunc(APIKeyCreate(&datamock.Mock{})).
+		ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusUnprocessableEntity {
+		t.Errorf("handler returned wrong status code: got %v want %v",
+			status, http.StatusUnprocessableEntity)
+	}
+}
+
+func TestAuthenticate_api_key(t *testing.T) {
+	keys := map[string]schema.APIKey{
+		auth.HashToken("hh_read"):  {ID: 1, UserID: 7, Scope: schema.ScopeRead},
+		auth.HashToken("hh_write"): {ID: 2, UserID: 7, Scope: schema.ScopeWrite},
+	}
+	mockStore := &datamock.Mock{
+		UseAPIKey_: func(hash string) (schema.APIKey, error) {
+			k, ok := keys[hash]
+			if !ok {
+				return schema.APIKey{}, data.ErrNotFound
+			}
+			return k, nil
+		},
+		GetUserByID_: func(id int) (schema.User, error) {
+			return schema.User{ID: id, Roles: []schema.Role{schema.RolePatron}}, nil
+		},
+	}
+	var got schema.User
+	inner := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got, _ = auth.FromContext(r.Context())
+	})
+	handler := Authenticate(inner, auth.NewSigner("secret", time.Minute, time.Hour), mockStore)
+
+	tests := []struct {
+		method string
+		key    string
+		want   int
+	}{
+		{"GET", "hh_read", http.StatusOK},
+		{"POST", "hh_read", http.StatusForbidden},
+		{"POST", "hh_write", http.StatusOK},
+		{"GET", "hh_revoked", http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		got = schema.User{}
+		req, err := http.NewRequest(tt.method, "/user_favorites", nil)
+		checkError(err, t)
+		req.Header.Set("X-API-Key", tt.key)
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+
+		if rr.Code != tt.want {
+			t.Errorf("%s with %s: got status %v want %v", tt.method, tt.key, rr.Code, tt.want)
+		}
+		if tt.want == http.StatusOK && got.ID != 7 {
+			t.Errorf("%s with %s: key user not stored in context: %+v", tt.method, tt.key, got)
+		}
+	}
+}
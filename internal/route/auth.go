@@ -0,0 +1,183 @@
+package route
+
+import (
+	"errors"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kernkw/hhapp/internal/auth"
+	"github.com/kernkw/hhapp/internal/data"
+	"github.com/kernkw/hhapp/internal/schema"
+	"github.com/kernkw/hhapp/internal/throttle"
+)
+
+var ErrForbidden = errors.New("you do not have permission to do that")
+
+var ErrReadOnlyKey = errors.New("api key is read-only")
+
+// apiKeyHeader carries partner API keys as an alternative to a bearer token.
+const apiKeyHeader = "X-API-Key"
+
+// Authenticate rejects requests that carry neither a valid bearer token nor
+// a valid API key, and makes the caller's user available to inner through
+// auth.FromContext. Read-only API keys may only make GET and HEAD requests.
+func Authenticate(inner http.Handler, tokens *auth.Signer, db data.Database) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var user schema.User
+		if token := bearerToken(r); token != "" {
+			claims, err := tokens.Verify(token)
+			if err != nil {
+				writeError(w, http.StatusUnauthorized, err)
+				return
+			}
+			// Tokens outlive a logout, password change or deletion, so
+			// each one is checked against the user's current generation.
+			gen, err := db.UserTokenGeneration(claims.UserID)
+			if err == data.ErrNotFound || (err == nil && gen != claims.Generation) {
+				writeError(w, http.StatusUnauthorized, auth.ErrRevokedToken)
+				return
+			}
+			if err != nil {
+				writeError(w, http.StatusInternalServerError, err)
+				return
+			}
+			user = claims.User()
+		} else if key := r.Header.Get(apiKeyHeader); key != "" {
+			k, err := db.UseAPIKey(auth.HashToken(key))
+			if err == data.ErrNotFound {
+				writeError(w, http.StatusUnauthorized, auth.ErrInvalidToken)
+				return
+			}
+			if err != nil {
+				writeError(w, http.StatusInternalServerError, err)
+				return
+			}
+			if k.Scope != schema.ScopeWrite && r.Method != "GET" && r.Method != "HEAD" {
+				writeError(w, http.StatusForbidden, ErrReadOnlyKey)
+				return
+			}
+			user, err = db.GetUserByID(k.UserID)
+			if err != nil {
+				writeError(w, http.StatusUnauthorized, err)
+				return
+			}
+		} else {
+			writeError(w, http.StatusUnauthorized, nil)
+			return
+		}
+
+		ctx := auth.NewContext(r.Context(), user)
+		inner.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+func bearerToken(r *http.Request) string {
+	h := r.Header.Get("Authorization")
+	const prefix = "Bearer "
+	if len(h) < len(prefix) || !strings.EqualFold(h[:len(prefix)], prefix) {
+		return ""
+	}
+	return strings.TrimSpace(h[len(prefix):])
+}
+
+// RequireRoles rejects authenticated requests whose user holds none of roles.
+// It must be wrapped by Authenticate.
+func RequireRoles(inner http.Handler, roles ...schema.Role) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, ok := auth.FromContext(r.Context())
+		if !ok {
+			writeError(w, http.StatusUnauthorized, nil)
+			return
+		}
+		if !user.HasRole(roles...) {
+			writeError(w, http.StatusForbidden, ErrForbidden)
+			return
+		}
+		inner.ServeHTTP(w, r)
+	})
+}
+
+// canManageVenue reports whether user may edit venue and its menus: admins
+// may edit any venue, owners only the venues they own.
+func canManageVenue(user schema.User, venue schema.Venue) bool {
+	if user.HasRole(schema.RoleAdmin) {
+		return true
+	}
+	return user.HasRole(schema.RoleVenueOwner) && venue.OwnerID != 0 && venue.OwnerID == user.ID
+}
+
+// authorizeVenue checks that the request's user may edit the venue
+// identified by venueID and returns it. When they may not, an error is
+// written and false returned.
+func authorizeVenue(w http.ResponseWriter, r *http.Request, db data.Database, venueID int) (schema.Venue, bool) {
+	user, ok := auth.FromContext(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, nil)
+		return schema.Venue{}, false
+	}
+	venue, err := db.VenueGet(schema.Venue{ID: venueID})
+	if err == data.ErrNotFound {
+		writeError(w, http.StatusNotFound, err)
+		return venue, false
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return venue, false
+	}
+	if !canManageVenue(user, venue) {
+		writeError(w, http.StatusForbidden, ErrForbidden)
+		return venue, false
+	}
+	return venue, true
+}
+
+// authorizeMenu checks that the request's user may edit the menu identified
+// by menuID and returns it. When they may not, an error is written and
+// false returned.
+func authorizeMenu(w http.ResponseWriter, r *http.Request, db data.Database, menuID int) (schema.Menu, bool) {
+	menu, err := db.MenuGet(menuID)
+	if err == data.ErrNotFound {
+		writeError(w, http.StatusNotFound, err)
+		return menu, false
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return menu, false
+	}
+	_, ok := authorizeVenue(w, r, db, menu.VenueID)
+	return menu, ok
+}
+
+// clientIP returns the address a request came from. X-Forwarded-For is only
+// honoured when the app runs behind a proxy that sets it.
+func clientIP(r *http.Request, trustProxy bool) string {
+	if trustProxy {
+		if xff := r.Header.Get("X-Forwarded-For"); xff != "" {
+			return strings.TrimSpace(strings.Split(xff, ",")[0])
+		}
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// writeRetryAfter rejects a throttled request, telling the client how many
+// seconds to wait.
+func writeRetryAfter(w http.ResponseWriter, wait time.Duration, err error) {
+	code := http.StatusTooManyRequests
+	if err == throttle.ErrLocked {
+		code = http.StatusLocked
+	}
+	secs := int(math.Ceil(wait.Seconds()))
+	if secs < 1 {
+		secs = 1
+	}
+	w.Header().Set("Retry-After", strconv.Itoa(secs))
+	writeError(w, code, err)
+}
@@ -0,0 +1,37 @@
+package route
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kernkw/hhapp/internal/schema"
+)
+
+func TestRequireRoles(t *testing.T) {
+	inner := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	tests := []struct {
+		user *schema.User
+		want int
+	}{
+		{nil, http.StatusUnauthorized},
+		{&schema.User{ID: 1, Roles: []schema.Role{schema.RolePatron}}, http.StatusForbidden},
+		{&schema.User{ID: 1, Roles: []schema.Role{schema.RoleVenueOwner}}, http.StatusOK},
+		{&schema.User{ID: 1, Roles: []schema.Role{schema.RolePatron, schema.RoleAdmin}}, http.StatusOK},
+	}
+	for _, tt := range tests {
+		req, err := http.NewRequest("POST", "/create_venue", nil)
+		checkError(err, t)
+		if tt.user != nil {
+			req = withUser(req, *tt.user)
+		}
+		rr := httptest.NewRecorder()
+		RequireRoles(inner, schema.RoleAdmin, schema.RoleVenueOwner).ServeHTTP(rr, req)
+		if rr.Code != tt.want {
+			t.Errorf("user %+v: got status %v want %v", tt.user, rr.Code, tt.want)
+		}
+	}
+}
@@ -11,15 +11,20 @@ import (
 	"strconv"
 
 	"github.com/gorilla/mux"
+	"github.com/kernkw/hhapp/internal/auth"
+	"github.com/kernkw/hhapp/internal/config"
 	"github.com/kernkw/hhapp/internal/data"
+	"github.com/kernkw/hhapp/internal/geo"
+	"github.com/kernkw/hhapp/internal/mail"
 	"github.com/kernkw/hhapp/internal/schema"
+	"github.com/kernkw/hhapp/internal/throttle"
 )
 
 /*
 Test with this curl command:
-curl -H "Content-Type: application/json" -d '{"username":"test-user", "password": "password", "email": "kyle.kern@sendgrid.com"}' http://localhost:8080/create_account
+curl -H "Content-Type: application/json" -d '{"username":"test-user", "password": "correct-horse-battery", "email": "kyle.kern@sendgrid.com"}' http://localhost:8080/create_account
 */
-func UserCreate(db data.Database) http.HandlerFunc {
+func UserCreate(db data.Database, tokens *auth.Signer, m mail.Mailer, cfg *config.Config) http.HandlerFunc {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		decoder := json.NewDecoder(r.Body)
 		var user schema.User
@@ -30,21 +35,28 @@ func UserCreate(db data.Database) http.HandlerFunc {
 		}
 		defer r.Body.Close()
 
-		err = user.Validate()
+		policy := passwordPolicy(cfg)
+		err = user.Validate(policy)
 		if err != nil {
 			writeError(w, http.StatusUnprocessableEntity, err)
 			return
 		}
-		err = user.HashPassword()
+		err = user.HashPassword(policy.Cost)
 		if err != nil {
 			writeError(w, http.StatusUnprocessableEntity, err)
 			return
 		}
+		// Elevated roles are only ever granted by an admin.
+		user.Roles = []schema.Role{schema.RolePatron}
 		id, err := db.CreateUser(user)
 		if err != nil {
 			writeError(w, http.StatusUnprocessableEntity, err)
 			return
 		}
+		user.ID = id
+		// The account exists either way; a failed send can be retried
+		// through /verify_email/resend.
+		sendVerificationEmail(m, tokens, cfg, user)
 
 		type envelope struct {
 			Status string `json:"status"`
@@ -56,10 +68,15 @@ func UserCreate(db data.Database) http.HandlerFunc {
 
 /*
 Test with this curl command:
-curl -H "Content-Type: application/json" -d '{"user_id":"12345", "venue_id": "1"}' http://localhost:8080/create_user_favorite
+curl -H "Content-Type: application/json" -H "Authorization: Bearer $TOKEN" -d '{"venue_id": 1}' http://localhost:8080/create_user_favorite
 */
 func UserFavoriteCreate(db data.Database) http.HandlerFunc {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, ok := auth.FromContext(r.Context())
+		if !ok {
+			writeError(w, http.StatusUnauthorized, nil)
+			return
+		}
 		decoder := json.NewDecoder(r.Body)
 		var userFav schema.UserFavorite
 		err := decoder.Decode(&userFav)
@@ -68,6 +85,7 @@ func UserFavoriteCreate(db data.Database) http.HandlerFunc {
 			return
 		}
 		defer r.Body.Close()
+		userFav.UserID = user.ID
 
 		id, err := db.CreateUserFavorite(userFav)
 		if err != nil {
@@ -85,19 +103,17 @@ func UserFavoriteCreate(db data.Database) http.HandlerFunc {
 
 /*
 Test with this curl command:
-curl -H "Content-Type: application/json" -d '{"user_id":"1234"}' http://localhost:8080/user_favorites
+curl -H "Content-Type: application/json" -H "Authorization: Bearer $TOKEN" http://localhost:8080/user_favorites
 */
 func UserFavoritesList(db data.Database) http.HandlerFunc {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json; charset=UTF-8")
-		keys, ok := r.URL.Query()["user_id"]
-
-		if !ok || len(keys) < 1 {
-			log.Println("Url Param 'user_id' is missing")
+		user, ok := auth.FromContext(r.Context())
+		if !ok {
+			writeError(w, http.StatusUnauthorized, nil)
 			return
 		}
-		uid := keys[0]
-		u := schema.UserFavorite{UserID: uid}
+		u := schema.UserFavorite{UserID: user.ID}
 
 		favorites, err := db.UserFavoritesList(u)
 		if err != nil {
@@ -114,20 +130,23 @@ func UserFavoritesList(db data.Database) http.HandlerFunc {
 
 /*
 Test with this curl command:
-curl -H "Content-Type: application/json" http://localhost:8080/user_favorites/:venue_id/:user_id
+curl -H "Content-Type: application/json" -H "Authorization: Bearer $TOKEN" http://localhost:8080/user_favorites/:venue_id
 */
 func UserFavoritesGet(db data.Database) http.HandlerFunc {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+		user, ok := auth.FromContext(r.Context())
+		if !ok {
+			writeError(w, http.StatusUnauthorized, nil)
+			return
+		}
 		vars := mux.Vars(r)
 		vid, err := strconv.Atoi(vars["venue_id"])
 		if err != nil {
 			writeError(w, http.StatusConflict, err)
 			return
 		}
-
-		uid := vars["user_id"]
-		u := schema.UserFavorite{UserID: uid, VenueID: vid}
+		u := schema.UserFavorite{UserID: user.ID, VenueID: vid}
 
 		favorite, err := db.UserFavoritesGet(u)
 		if err != nil {
@@ -144,11 +163,16 @@ func UserFavoritesGet(db data.Database) http.HandlerFunc {
 
 /*
 Test with this curl command:
-curl -H "Content-Type: application/json" http://localhost:8080/user_favorites/:id
+curl -X POST -H "Content-Type: application/json" -H "Authorization: Bearer $TOKEN" http://localhost:8080/user_favorite/:id
 */
 func UserFavoritesRemove(db data.Database) http.HandlerFunc {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+		user, ok := auth.FromContext(r.Context())
+		if !ok {
+			writeError(w, http.StatusUnauthorized, nil)
+			return
+		}
 		vars := mux.Vars(r)
 		id, err := strconv.Atoi(vars["id"])
 		if err != nil {
@@ -156,7 +180,11 @@ func UserFavoritesRemove(db data.Database) http.HandlerFunc {
 			return
 		}
 
-		err = db.UserFavoritesDelete(id)
+		err = db.UserFavoritesDelete(schema.UserFavorite{ID: id, UserID: user.ID})
+		if err == data.ErrNotFound {
+			writeError(w, http.StatusNotFound, err)
+			return
+		}
 		if err != nil {
 			writeError(w, http.StatusConflict, err)
 			return
@@ -197,9 +225,9 @@ curl -H "Content-Type: application/json" -d '{"user_id":"1234", "venue_id": "1"}
 
 /*
 Test with this curl command:
-curl -H "Content-Type: application/json" -d '{"username":"test-user", "password": "password"}' http://localhost:8080/authenticate
+curl -H "Content-Type: application/json" -d '{"username":"test-user", "password": "correct-horse-battery"}' http://localhost:8080/authenticate
 */
-func UserLogin(db data.Database) http.HandlerFunc {
+func UserLogin(db data.Database, tokens *auth.Signer, guard *throttle.Guard, cfg *config.Config) http.HandlerFunc {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		var inuser schema.User
 		body, err := ioutil.ReadAll(io.LimitReader(r.Body, 1048576))
@@ -215,27 +243,52 @@ func UserLogin(db data.Database) http.HandlerFunc {
 			writeError(w, http.StatusUnprocessableEntity, err)
 			return
 		}
+		// Throttled attempts are turned away before the bcrypt compare so
+		// credential stuffing can't burn CPU. Allow reserves the attempt,
+		// so every path below settles it.
+		ip := clientIP(r, cfg.TrustProxyHeaders)
+		if wait, err := guard.Allow(inuser.UserName, ip); err != nil {
+			writeRetryAfter(w, wait, err)
+			return
+		}
 		dbuser, err := db.GetUser(inuser)
 		if err != nil {
+			guard.Release(inuser.UserName, ip)
 			writeError(w, http.StatusInternalServerError, err)
 			return
 		}
 		if !inuser.Authorized(dbuser) {
+			guard.Fail(inuser.UserName, ip)
 			writeError(w, http.StatusUnauthorized, err)
 			return
 		}
-		type envelope struct {
-			Status string `json:"status"`
+		// The plain password is only known here, so this is the one chance
+		// to move an old hash to the configured cost.
+		if dbuser.NeedsRehash(cfg.BcryptCost) {
+			rehashed := schema.User{Password: inuser.Password}
+			if err := rehashed.HashPassword(cfg.BcryptCost); err == nil {
+				if err := db.RehashUserPassword(dbuser.ID, dbuser.Password, rehashed.Password); err != nil {
+					log.Println("failed to rehash password:", err)
+				}
+			}
+		}
+		if dbuser.TwoFactorEnabled {
+			// The password step is not a successful login on its own, so
+			// the throttle is left alone until the second factor passes.
+			guard.Release(inuser.UserName, ip)
+			writeTwoFactorChallenge(w, tokens, dbuser)
+			return
 		}
-		writeJSON(w, http.StatusOK, envelope{http.StatusText(http.StatusOK)})
+		guard.Succeed(inuser.UserName, ip)
+		writeSession(w, db, tokens, dbuser)
 	})
 }
 
 /*
 Test with this curl command:
-curl -H "Content-Type: application/json" -d '{"name":"Panzano", "address": "909 17th St", "city": "Denver", "zip": "80202", "state": "CO", "image": "http://coloradobites.com/wp-content/uploads/2015/05/panzanococktail1.jpg", "country": "USA"}' http://localhost:8080/create_venue
+curl -H "Content-Type: application/json" -H "Authorization: Bearer $TOKEN" -d '{"name":"Panzano", "address": "909 17th St", "city": "Denver", "zip": "80202", "state": "CO", "image": "http://coloradobites.com/wp-content/uploads/2015/05/panzanococktail1.jpg", "country": "USA"}' http://localhost:8080/create_venue
 */
-func VenueCreate(db data.Database) http.HandlerFunc {
+func VenueCreate(db data.Database, g geo.Geocoder, zones *geo.TimeZones) http.HandlerFunc {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json; charset=UTF-8")
 		var venue schema.Venue
@@ -250,14 +303,35 @@ func VenueCreate(db data.Database) http.HandlerFunc {
 			w.WriteHeader(http.StatusUnprocessableEntity)
 			return
 		}
+		// Admins may create a venue on an owner's behalf; owners always
+		// own the venues they create.
+		user, _ := auth.FromContext(r.Context())
+		if !user.HasRole(schema.RoleAdmin) {
+			venue.OwnerID = user.ID
+		}
+		if venue.Location != nil {
+			if err := venue.Location.Validate(); err != nil {
+				writeError(w, http.StatusUnprocessableEntity, err)
+				return
+			}
+		} else {
+			locate(g, &venue)
+		}
+		if err := checkTimeZone(venue.TimeZone); err != nil {
+			writeError(w, http.StatusUnprocessableEntity, err)
+			return
+		}
+		if venue.TimeZone == "" {
+			venue.TimeZone = zones.Zone(venue)
+		}
 		id, err := db.CreateVenue(venue)
 		if err != nil {
 			writeError(w, http.StatusConflict, err)
 			return
 		}
 
-		menu := schema.Menu{VenueID: id}
-		menuID, err := db.CreateMenu(menu)
+		menu := schema.Menu{VenueID: id, Name: schema.DefaultMenuName, Type: schema.MenuHappyHour}
+		menuID, err := db.CreateMenu(menu, user.ID)
 		if err != nil {
 			writeError(w, http.StatusConflict, err)
 			return
@@ -274,7 +348,7 @@ func VenueCreate(db data.Database) http.HandlerFunc {
 
 /*
 Test with this curl command:
-curl -H "Content-Type: application/json" -d '{"name":"Popular"}' http://localhost:8080/create_venue_list
+curl -H "Content-Type: application/json" -H "Authorization: Bearer $TOKEN" -d '{"name":"Popular"}' http://localhost:8080/create_venue_list
 */
 func VenueListCreate(db data.Database) http.HandlerFunc {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -371,11 +445,27 @@ func VenueGet(db data.Database) http.HandlerFunc {
 /*
 Test with this curl command:
 curl -H "Content-Type: application/json"  http://localhost:8080/menu_items?venue_id=1
+curl "http://localhost:8080/menu_items?menu_id=1&category=drink"
+curl "http://localhost:8080/menu_items?venue_id=1&group=menu"
 */
 func MenuItemsGet(db data.Database) http.HandlerFunc {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json; charset=UTF-8")
-		keys, ok := r.URL.Query()["venue_id"]
+		q := r.URL.Query()
+		category, err := categoryQuery(q)
+		if err != nil {
+			writeError(w, http.StatusUnprocessableEntity, err)
+			return
+		}
+		if q.Get("menu_id") != "" {
+			menuItemsByMenu(w, db, q.Get("menu_id"), category)
+			return
+		}
+		if q.Get("group") == "menu" {
+			menuItemsGrouped(w, db, q.Get("venue_id"), category)
+			return
+		}
+		keys, ok := q["venue_id"]
 
 		if !ok || len(keys) < 1 {
 			log.Println("Url Param 'venue_id' is missing")
@@ -396,13 +486,13 @@ func MenuItemsGet(db data.Database) http.HandlerFunc {
 		type envelope struct {
 			Data []schema.MenuItem `json:"data"`
 		}
-		writeJSON(w, http.StatusCreated, envelope{menus})
+		writeJSON(w, http.StatusCreated, envelope{schema.FilterItems(menus, category)})
 	})
 }
 
 /*
 Test with this curl command:
-curl -H "Content-Type: application/json" -d '{"venue_name":"Panzano", "venue_list_name": "Popular"}' http://localhost:8080/venue_list_add
+curl -H "Content-Type: application/json" -H "Authorization: Bearer $TOKEN" -d '{"venue_name":"Panzano", "venue_list_name": "Popular"}' http://localhost:8080/venue_list_add
 */
 func VenueListAdd(db data.Database) http.HandlerFunc {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -436,7 +526,7 @@ func VenueListAdd(db data.Database) http.HandlerFunc {
 
 /*
 Test with this curl command:
-curl -H "Content-Type: application/json" -d '{"menu_id": 1, "category": "Drink", "price": 5.00, "description": "LOCAL DRAFT BEERS"}' http://localhost:8080/add_menu_item
+curl -H "Content-Type: application/json" -H "Authorization: Bearer $TOKEN" -d '{"menu_id": 1, "category": "drink", "price": "5.00", "regular_price": "7.00", "description": "LOCAL DRAFT BEERS"}' http://localhost:8080/add_menu_item
 */
 func MenuItemAdd(db data.Database) http.HandlerFunc {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -456,7 +546,25 @@ func MenuItemAdd(db data.Database) http.HandlerFunc {
 			w.WriteHeader(http.StatusUnprocessableEntity)
 			return
 		}
-		id, err := db.AddToMenu(m)
+		menu, ok := authorizeMenu(w, r, db, m.MenuID)
+		if !ok {
+			return
+		}
+		currency, err := venueCurrency(db, menu.VenueID)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		if m, err = m.InCurrency(currency); err != nil {
+			writeError(w, http.StatusUnprocessableEntity, err)
+			return
+		}
+		if err := m.Validate(); err != nil {
+			writeError(w, http.StatusUnprocessableEntity, err)
+			return
+		}
+		user, _ := auth.FromContext(r.Context())
+		id, err := db.AddToMenu(m, user.ID)
 		if err != nil {
 			writeError(w, http.StatusConflict, err)
 			return
@@ -481,12 +589,14 @@ func writeResponse(w http.ResponseWriter, obj map[string]string) {
 
 func writeError(w http.ResponseWriter, code int, err error) {
 	type envelope struct {
-		Status string `json:"status"`
+		Status string             `json:"status"`
+		Errors schema.FieldErrors `json:"errors,omitempty"`
 	}
 	if err == nil {
 		err = errors.New(http.StatusText(code))
 	}
-	writeJSON(w, code, envelope{err.Error()})
+	fields, _ := err.(schema.FieldErrors)
+	writeJSON(w, code, envelope{err.Error(), fields})
 }
 
 func writeJSON(w http.ResponseWriter, code int, v interface{}) {
@@ -7,11 +7,18 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/gorilla/mux"
+	"github.com/kernkw/hhapp/internal/auth"
+	"github.com/kernkw/hhapp/internal/config"
 	"github.com/kernkw/hhapp/internal/data"
 	"github.com/kernkw/hhapp/internal/data/datamock"
 	"github.com/kernkw/hhapp/internal/schema"
+	"github.com/kernkw/hhapp/internal/throttle"
+	"golang.org/x/crypto/bcrypt"
 )
 
 func checkError(err error, t *testing.T) {
@@ -20,6 +27,43 @@ func checkError(err error, t *testing.T) {
 	}
 }
 
+func withUser(req *http.Request, user schema.User) *http.Request {
+	return req.WithContext(auth.NewContext(req.Context(), user))
+}
+
+// testTokens signs the bearer tokens serve sends.
+var testTokens = auth.NewSigner("secret", time.Minute, time.Hour)
+
+// serve sends a request through the app's route table backed by db. A
+// request from a user with an ID carries a bearer token for them; one from
+// the zero user is anonymous.
+func serve(db data.Database, method, path, body string, user schema.User) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest(method, path, strings.NewReader(body))
+	if user.ID != 0 {
+		token, _, _ := testTokens.Sign(user)
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	// Tests that don't care about revocation get a store on which every
+	// token is still current.
+	if m, ok := db.(*datamock.Mock); ok && m.UserTokenGeneration_ == nil {
+		withGen := *m
+		withGen.UserTokenGeneration_ = func(id int) (int, error) { return user.TokenGeneration, nil }
+		db = &withGen
+	}
+	rr := httptest.NewRecorder()
+	newRouter(db, &config.Config{}, testTokens, nil, nil, nil).ServeHTTP(rr, req)
+	return rr
+}
+
+func testGuard() *throttle.Guard {
+	return throttle.NewGuard(&config.Config{
+		LoginMaxAttempts:      3,
+		LoginMaxAttemptsPerIP: 10,
+		LoginWindow:           time.Minute,
+		LoginLockout:          time.Minute,
+	})
+}
+
 func TestUserCreate(t *testing.T) {
 	wantID := 1234567
 	mockStore := &datamock.Mock{
@@ -41,7 +85,10 @@ func TestUserCreate(t *testing.T) {
 
 	rr := httptest.NewRecorder()
 
-	http.HandlerFunc(UserCreate(mockStore)).
+	outbox, cleanup := newOutbox(t)
+	defer cleanup()
+
+	http.HandlerFunc(UserCreate(mockStore, auth.NewSigner("secret", time.Minute, time.Hour), outbox, &config.Config{})).
 		ServeHTTP(rr, req)
 
 	if status := rr.Code; status != http.StatusCreated {
@@ -67,7 +114,10 @@ func TestUserCreate_bad_input(t *testing.T) {
 
 	rr := httptest.NewRecorder()
 
-	http.HandlerFunc(UserCreate(mockStore)).
+	outbox, cleanup := newOutbox(t)
+	defer cleanup()
+
+	http.HandlerFunc(UserCreate(mockStore, auth.NewSigner("secret", time.Minute, time.Hour), outbox, &config.Config{})).
 		ServeHTTP(rr, req)
 
 	if status := rr.Code; status != http.StatusUnprocessableEntity {
@@ -77,6 +127,27 @@ func TestUserCreate_bad_input(t *testing.T) {
 
 }
 
+func TestUserCreate_password_policy(t *testing.T) {
+	mockStore := &datamock.Mock{}
+
+	req, err := http.NewRequest("POST", "/create_account", bytes.NewReader([]byte(`{"username":"test","password":"qwerty123","email":"test@domain.com"}`)))
+	checkError(err, t)
+
+	rr := httptest.NewRecorder()
+
+	outbox, cleanup := newOutbox(t)
+	defer cleanup()
+
+	cfg := &config.Config{PasswordMinLength: 8, PasswordRejectCommon: true}
+	http.HandlerFunc(UserCreate(mockStore, auth.NewSigner("secret", time.Minute, time.Hour), outbox, cfg)).
+		ServeHTTP(rr, req)
+
+	expected := `{"status":"password is too common.","errors":{"password":"password is too common."}}`
+	if rr.Code != http.StatusUnprocessableEntity || rr.Body.String() != expected {
+		t.Errorf("got %v %v want %v %v", rr.Code, rr.Body.String(), http.StatusUnprocessableEntity, expected)
+	}
+}
+
 func TestUserCreate_bad_error(t *testing.T) {
 	wantErr := data.ErrDuplicateEntry
 	mockStore := &datamock.Mock{
@@ -97,7 +168,10 @@ func TestUserCreate_bad_error(t *testing.T) {
 
 	rr := httptest.NewRecorder()
 
-	http.HandlerFunc(UserCreate(mockStore)).
+	outbox, cleanup := newOutbox(t)
+	defer cleanup()
+
+	http.HandlerFunc(UserCreate(mockStore, auth.NewSigner("secret", time.Minute, time.Hour), outbox, &config.Config{})).
 		ServeHTTP(rr, req)
 
 	if status := rr.Code; status != http.StatusUnprocessableEntity {
@@ -116,12 +190,14 @@ func TestUserFavoriteCreate(t *testing.T) {
 	wantID := 1234567
 	mockStore := &datamock.Mock{
 		CreateUserFavorite_: func(userFav schema.UserFavorite) (int, error) {
+			if userFav.UserID != 12345 {
+				t.Errorf("favorite created for wrong user: got %v want %v", userFav.UserID, 12345)
+			}
 			return wantID, nil
 		},
 	}
 
 	u := schema.UserFavorite{
-		UserID:  "12345",
 		VenueID: 1,
 	}
 
@@ -129,6 +205,7 @@ func TestUserFavoriteCreate(t *testing.T) {
 	checkError(err, t)
 	req, err := http.NewRequest("POST", "/create_user_favorite", bytes.NewReader(jsonU))
 	checkError(err, t)
+	req = withUser(req, schema.User{ID: 12345})
 
 	rr := httptest.NewRecorder()
 
@@ -156,7 +233,6 @@ func TestUserFavoriteCreate_error(t *testing.T) {
 	}
 
 	u := schema.UserFavorite{
-		UserID:  "12345",
 		VenueID: 1,
 	}
 
@@ -164,6 +240,7 @@ func TestUserFavoriteCreate_error(t *testing.T) {
 	checkError(err, t)
 	req, err := http.NewRequest("POST", "/create_user_favorite", bytes.NewReader(jsonU))
 	checkError(err, t)
+	req = withUser(req, schema.User{ID: 12345})
 
 	rr := httptest.NewRecorder()
 
@@ -211,9 +288,9 @@ func TestUserFavoritesList(t *testing.T) {
 		},
 	}
 
-	uid := "12345"
-	req, err := http.NewRequest("GET", fmt.Sprintf("/user_favorites?user_id=%s", uid), nil)
+	req, err := http.NewRequest("GET", "/user_favorites", nil)
 	checkError(err, t)
+	req = withUser(req, schema.User{ID: 12345})
 
 	rr := httptest.NewRecorder()
 
@@ -244,9 +321,9 @@ func TestUserFavoritesList_error(t *testing.T) {
 		},
 	}
 
-	uid := "12345"
-	req, err := http.NewRequest("GET", fmt.Sprintf("/user_favorites?user_id=%s", uid), nil)
+	req, err := http.NewRequest("GET", "/user_favorites", nil)
 	checkError(err, t)
+	req = withUser(req, schema.User{ID: 12345})
 
 	rr := httptest.NewRecorder()
 
@@ -264,3 +341,423 @@ func TestUserFavoritesList_error(t *testing.T) {
 			rr.Body.String(), expected)
 	}
 }
+
+func TestUserFavoriteCreate_unauthenticated(t *testing.T) {
+	mockStore := &datamock.Mock{}
+
+	req, err := http.NewRequest("POST", "/create_user_favorite", bytes.NewReader([]byte(`{"user_id":1,"venue_id":1}`)))
+	checkError(err, t)
+
+	rr := httptest.NewRecorder()
+
+	http.HandlerFunc(UserFavoriteCreate(mockStore)).
+		ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusUnauthorized {
+		t.Errorf("handler returned wrong status code: got %v want %v",
+			status, http.StatusUnauthorized)
+	}
+}
+
+func TestUserFavoritesGet(t *testing.T) {
+	var got schema.UserFavorite
+	mockStore := &datamock.Mock{
+		UserFavoritesGet_: func(u schema.UserFavorite) (schema.Venue, error) {
+			got = u
+			return schema.Venue{ID: u.VenueID, Name: "Panzano"}, nil
+		},
+	}
+
+	rr := serve(mockStore, "GET", "/user_favorites/5", "", schema.User{ID: 42})
+	if rr.Code != http.StatusCreated || got != (schema.UserFavorite{UserID: 42, VenueID: 5}) {
+		t.Errorf("got %v %+v want %v the caller's favorite", rr.Code, got, http.StatusCreated)
+	}
+
+	got = schema.UserFavorite{}
+	if rr := serve(mockStore, "GET", "/user_favorites/5", "", schema.User{}); rr.Code != http.StatusUnauthorized || got.VenueID != 0 {
+		t.Errorf("without a token: got status %v want %v", rr.Code, http.StatusUnauthorized)
+	}
+	if rr := serve(mockStore, "GET", "/user_favorites/5/43", "", schema.User{ID: 42}); rr.Code != http.StatusNotFound {
+		t.Errorf("user_id in the path: got status %v want %v", rr.Code, http.StatusNotFound)
+	}
+}
+
+func TestUserFavoritesRemove(t *testing.T) {
+	var got schema.UserFavorite
+	mockStore := &datamock.Mock{
+		UserFavoritesDelete_: func(u schema.UserFavorite) error {
+			got = u
+			return nil
+		},
+	}
+
+	req, err := http.NewRequest("POST", "/user_favorite/7", nil)
+	checkError(err, t)
+	req = withUser(req, schema.User{ID: 12345})
+
+	rr := httptest.NewRecorder()
+
+	router := mux.NewRouter()
+	router.Handle("/user_favorite/{id:[0-9]+}", UserFavoritesRemove(mockStore))
+	router.ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusAccepted {
+		t.Errorf("handler returned wrong status code: got %v want %v",
+			status, http.StatusAccepted)
+	}
+
+	want := schema.UserFavorite{ID: 7, UserID: 12345}
+	if got != want {
+		t.Errorf("handler deleted wrong favorite: got %+v want %+v", got, want)
+	}
+}
+
+func TestUserLogin(t *testing.T) {
+	dbUser := schema.User{ID: 42, UserName: "test", Password: "password", Email: "test@domain.com"}
+	checkError(dbUser.HashPassword(0), t)
+	mockStore := &datamock.Mock{
+		GetUser_: func(user schema.User) (schema.User, error) {
+			return dbUser, nil
+		},
+		CreateRefreshToken_: func(rt schema.RefreshToken) (int, error) {
+			return 1, nil
+		},
+	}
+	tokens := auth.NewSigner("secret", time.Minute, time.Hour)
+
+	req, err := http.NewRequest("POST", "/authenticate", bytes.NewReader([]byte(`{"username":"test","password":"password"}`)))
+	checkError(err, t)
+
+	rr := httptest.NewRecorder()
+
+	http.HandlerFunc(UserLogin(mockStore, tokens, testGuard(), &config.Config{})).
+		ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusOK {
+		t.Fatalf("handler returned wrong status code: got %v want %v",
+			status, http.StatusOK)
+	}
+
+	var resp struct {
+		Token string `json:"token"`
+	}
+	checkError(json.Unmarshal(rr.Body.Bytes(), &resp), t)
+	claims, err := tokens.Verify(resp.Token)
+	checkError(err, t)
+	if claims.UserID != dbUser.ID {
+		t.Errorf("token issued for wrong user: got %v want %v", claims.UserID, dbUser.ID)
+	}
+}
+
+func TestUserLogin_rehash(t *testing.T) {
+	dbUser := schema.User{ID: 42, UserName: "test", Password: "password"}
+	checkError(dbUser.HashPassword(bcrypt.MinCost), t)
+	var stored string
+	mockStore := &datamock.Mock{
+		GetUser_: func(user schema.User) (schema.User, error) {
+			return dbUser, nil
+		},
+		RehashUserPassword_: func(userID int, oldHash, newHash string) error {
+			if oldHash != dbUser.Password {
+				t.Errorf("rehash guarded by the wrong hash: got %q want %q", oldHash, dbUser.Password)
+			}
+			stored = newHash
+			return nil
+		},
+		CreateRefreshToken_: func(rt schema.RefreshToken) (int, error) {
+			return 1, nil
+		},
+	}
+
+	req, err := http.NewRequest("POST", "/authenticate", bytes.NewReader([]byte(`{"username":"test","password":"password"}`)))
+	checkError(err, t)
+
+	rr := httptest.NewRecorder()
+
+	cfg := &config.Config{BcryptCost: bcrypt.MinCost + 1}
+	http.HandlerFunc(UserLogin(mockStore, auth.NewSigner("secret", time.Minute, time.Hour), testGuard(), cfg)).
+		ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("handler returned wrong status code: got %v want %v", rr.Code, http.StatusOK)
+	}
+	if cost, err := bcrypt.Cost([]byte(stored)); err != nil || cost != cfg.BcryptCost {
+		t.Errorf("password was not rehashed at cost %v: got %v %v", cfg.BcryptCost, cost, err)
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(stored), []byte("password")); err != nil {
+		t.Errorf("rehashed password does not match: %v", err)
+	}
+}
+
+func TestUserLogin_bad_password(t *testing.T) {
+	dbUser := schema.User{ID: 42, UserName: "test", Password: "password"}
+	checkError(dbUser.HashPassword(0), t)
+	mockStore := &datamock.Mock{
+		GetUser_: func(user schema.User) (schema.User, error) {
+			return dbUser, nil
+		},
+	}
+
+	req, err := http.NewRequest("POST", "/authenticate", bytes.NewReader([]byte(`{"username":"test","password":"wrong"}`)))
+	checkError(err, t)
+
+	rr := httptest.NewRecorder()
+
+	http.HandlerFunc(UserLogin(mockStore, auth.NewSigner("secret", time.Minute, time.Hour), testGuard(), &config.Config{})).
+		ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusUnauthorized {
+		t.Errorf("handler returned wrong status code: got %v want %v",
+			status, http.StatusUnauthorized)
+	}
+}
+
+func TestAuthenticate(t *testing.T) {
+	tokens := auth.NewSigner("secret", time.Minute, time.Hour)
+	token, _, err := tokens.Sign(schema.User{ID: 42, UserName: "test"})
+	checkError(err, t)
+
+	var got schema.User
+	inner := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got, _ = auth.FromContext(r.Context())
+	})
+
+	tests := []struct {
+		header string
+		want   int
+	}{
+		{"", http.StatusUnauthorized},
+		{"Bearer nope", http.StatusUnauthorized},
+		{"Bearer " + token + "x", http.StatusUnauthorized},
+		{"Bearer " + token, http.StatusOK},
+	}
+	for _, tt := range tests {
+		req, err := http.NewRequest("GET", "/user_favorites", nil)
+		checkError(err, t)
+		if tt.header != "" {
+			req.Header.Set("Authorization", tt.header)
+		}
+		rr := httptest.NewRecorder()
+		Authenticate(inner, tokens, currentGeneration(0)).ServeHTTP(rr, req)
+		if rr.Code != tt.want {
+			t.Errorf("Authorization %q: got status %v want %v", tt.header, rr.Code, tt.want)
+		}
+	}
+	if got.ID != 42 {
+		t.Errorf("user not stored in context: got %+v", got)
+	}
+}
+
+func TestAuthenticate_expired(t *testing.T) {
+	tokens := auth.NewSigner("secret", -time.Minute, time.Hour)
+	token, _, err := tokens.Sign(schema.User{ID: 42})
+	checkError(err, t)
+
+	req, err := http.NewRequest("GET", "/user_favorites", nil)
+	checkError(err, t)
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	rr := httptest.NewRecorder()
+	Authenticate(http.NotFoundHandler(), tokens, &datamock.Mock{}).ServeHTTP(rr, req)
+
+	expected := fmt.Sprintf(`{"status":"%s"}`, auth.ErrExpiredToken)
+	if rr.Code != http.StatusUnauthorized || rr.Body.String() != expected {
+		t.Errorf("got %v %v want %v %v", rr.Code, rr.Body.String(), http.StatusUnauthorized, expected)
+	}
+}
+
+// currentGeneration returns a store on which user 42 is at token
+// generation gen and every other user has been deleted.
+func currentGeneration(gen int) *datamock.Mock {
+	return &datamock.Mock{
+		UserTokenGeneration_: func(id int) (int, error) {
+			if id != 42 {
+				return 0, data.ErrNotFound
+			}
+			return gen, nil
+		},
+	}
+}
+
+func TestAuthenticate_revoked(t *testing.T) {
+	tokens := auth.NewSigner("secret", time.Minute, time.Hour)
+	tests := []struct {
+		name string
+		user schema.User
+		db   *datamock.Mock
+		want int
+	}{
+		{"current", schema.User{ID: 42, TokenGeneration: 3}, currentGeneration(3), http.StatusNotFound},
+		{"logged out everywhere", schema.User{ID: 42, TokenGeneration: 2}, currentGeneration(3), http.StatusUnauthorized},
+		{"deleted", schema.User{ID: 7}, currentGeneration(0), http.StatusUnauthorized},
+		{"store error", schema.User{ID: 42}, &datamock.Mock{
+			UserTokenGeneration_: func(id int) (int, error) { return 0, errors.New("db down") },
+		}, http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		token, _, err := tokens.Sign(tt.user)
+		checkError(err, t)
+		req, err := http.NewRequest("GET", "/user_favorites", nil)
+		checkError(err, t)
+		req.Header.Set("Authorization", "Bearer "+token)
+
+		rr := httptest.NewRecorder()
+		Authenticate(http.NotFoundHandler(), tokens, tt.db).ServeHTTP(rr, req)
+		if rr.Code != tt.want {
+			t.Errorf("%s: got status %v want %v", tt.name, rr.Code, tt.want)
+		}
+		if tt.want == http.StatusUnauthorized {
+			expected := fmt.Sprintf(`{"status":"%s"}`, auth.ErrRevokedToken)
+			if rr.Body.String() != expected {
+				t.Errorf("%s: got body %v want %v", tt.name, rr.Body.String(), expected)
+			}
+		}
+	}
+}
+
+func TestVenueCreate_sets_owner(t *testing.T) {
+	var got schema.Venue
+	mockStore := &datamock.Mock{
+		CreateVenue_: func(v schema.Venue) (int, error) {
+			got = v
+			return 1, nil
+		},
+		CreateMenu_: func(m schema.Menu, userID int) (int, error) {
+			return 1, nil
+		},
+	}
+
+	req, err := http.NewRequest("POST", "/create_venue", bytes.NewReader([]byte(`{"name":"Panzano","owner_id":99}`)))
+	checkError(err, t)
+	req = withUser(req, schema.User{ID: 42, Roles: []schema.Role{schema.RoleVenueOwner}})
+
+	rr := httptest.NewRecorder()
+
+	http.HandlerFunc(VenueCreate(mockStore, testGeocoder(t), nil)).
+		ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusCreated {
+		t.Errorf("handler returned wrong status code: got %v want %v",
+			status, http.StatusCreated)
+	}
+	if got.OwnerID != 42 {
+		t.Errorf("venue created with wrong owner: got %v want %v", got.OwnerID, 42)
+	}
+}
+
+func TestMenuItemAdd_ownership(t *testing.T) {
+	tests := []struct {
+		user schema.User
+		want int
+	}{
+		{schema.User{ID: 42, Roles: []schema.Role{schema.RoleVenueOwner}}, http.StatusCreated},
+		{schema.User{ID: 43, Roles: []schema.Role{schema.RoleVenueOwner}}, http.StatusForbidden},
+		{schema.User{ID: 1, Roles: []schema.Role{schema.RoleAdmin}}, http.StatusCreated},
+	}
+	for _, tt := range tests {
+		mockStore := &datamock.Mock{
+			MenuGet_: func(id int) (schema.Menu, error) {
+				return schema.Menu{ID: id, VenueID: 5}, nil
+			},
+			VenueGet_: func(v schema.Venue) (schema.Venue, error) {
+				return schema.Venue{ID: v.ID, OwnerID: 42}, nil
+			},
+			AddToMenu_: func(mi schema.MenuItem, userID int) (int, error) {
+				return 1, nil
+			},
+		}
+
+		req, err := http.NewRequest("POST", "/add_menu_item", bytes.NewReader([]byte(`{"menu_id":3,"category":"drink","price":5}`)))
+		checkError(err, t)
+		req = withUser(req, tt.user)
+
+		rr := httptest.NewRecorder()
+
+		http.HandlerFunc(MenuItemAdd(mockStore)).
+			ServeHTTP(rr, req)
+
+		if rr.Code != tt.want {
+			t.Errorf("user %+v: got status %v want %v", tt.user, rr.Code, tt.want)
+		}
+	}
+}
+
+func TestMenuItemAdd_category(t *testing.T) {
+	tests := []struct {
+		body string
+		want int
+	}{
+		{`{"menu_id":3,"category":" Drink ","price":5}`, http.StatusCreated},
+		{`{"menu_id":3,"category":"beer","price":5}`, http.StatusUnprocessableEntity},
+		{`{"menu_id":3,"price":5}`, http.StatusUnprocessableEntity},
+	}
+	for _, tt := range tests {
+		var added schema.MenuItem
+		mockStore := &datamock.Mock{
+			MenuGet_: func(id int) (schema.Menu, error) {
+				return schema.Menu{ID: id, VenueID: 5}, nil
+			},
+			VenueGet_: func(v schema.Venue) (schema.Venue, error) {
+				return testVenue(v.ID), nil
+			},
+			AddToMenu_: func(mi schema.MenuItem, userID int) (int, error) {
+				added = mi
+				return 1, nil
+			},
+		}
+
+		req, err := http.NewRequest("POST", "/add_menu_item", bytes.NewReader([]byte(tt.body)))
+		checkError(err, t)
+		req = withUser(req, testOwner)
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(MenuItemAdd(mockStore)).
+			ServeHTTP(rr, req)
+
+		if rr.Code != tt.want {
+			t.Errorf("%s: got status %v want %v", tt.body, rr.Code, tt.want)
+		}
+		if tt.want == http.StatusCreated && added.Category != schema.CategoryDrink {
+			t.Errorf("%s: stored category %q", tt.body, added.Category)
+		}
+		if tt.want == http.StatusUnprocessableEntity && !strings.Contains(rr.Body.String(), `"category":"category must be one of drink, food or all"`) {
+			t.Errorf("%s: got %v", tt.body, rr.Body.String())
+		}
+	}
+}
+
+func TestUserLogin_lockout(t *testing.T) {
+	dbUser := schema.User{ID: 42, UserName: "test", Password: "password"}
+	checkError(dbUser.HashPassword(0), t)
+	lookups := 0
+	mockStore := &datamock.Mock{
+		GetUser_: func(user schema.User) (schema.User, error) {
+			lookups++
+			return dbUser, nil
+		},
+	}
+	handler := UserLogin(mockStore, auth.NewSigner("secret", time.Minute, time.Hour), testGuard(), &config.Config{})
+
+	for i := 0; i < 4; i++ {
+		req, err := http.NewRequest("POST", "/authenticate", bytes.NewReader([]byte(`{"username":"Test","password":"wrong"}`)))
+		checkError(err, t)
+		req.RemoteAddr = "192.0.2.1:1234"
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+
+		if i < 3 {
+			if rr.Code != http.StatusUnauthorized {
+				t.Errorf("attempt %d: got status %v want %v", i, rr.Code, http.StatusUnauthorized)
+			}
+			continue
+		}
+		if rr.Code != http.StatusLocked {
+			t.Errorf("attempt %d: got status %v want %v", i, rr.Code, http.StatusLocked)
+		}
+		if rr.Header().Get("Retry-After") != "60" {
+			t.Errorf("Retry-After: got %q want %q", rr.Header().Get("Retry-After"), "60")
+		}
+	}
+	if lookups != 3 {
+		t.Errorf("locked out attempt still checked the password: %d lookups", lookups)
+	}
+}
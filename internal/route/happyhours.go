@@ -0,0 +1,335 @@
+package route
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/kernkw/hhapp/internal/auth"
+	"github.com/kernkw/hhapp/internal/config"
+	"github.com/kernkw/hhapp/internal/data"
+	"github.com/kernkw/hhapp/internal/geo"
+	"github.com/kernkw/hhapp/internal/schema"
+)
+
+// happyHour is a venue with the items on its menus being served now.
+type happyHour struct {
+	Venue      schema.Venue      `json:"venue"`
+	DistanceKM *float64          `json:"distance_km,omitempty"`
+	Items      []schema.MenuItem `json:"items"`
+}
+
+// defaultVenueLocation is the time zone of venues that have none.
+func defaultVenueLocation(cfg *config.Config) *time.Location {
+	loc, err := geo.LoadLocation(cfg.VenueTimeZone)
+	if err != nil {
+		log.Println("unknown default venue time zone, using UTC:", err)
+		return time.UTC
+	}
+	return loc
+}
+
+// venueLocation returns the venue's time zone, or def when it has none.
+func venueLocation(v schema.Venue, def *time.Location) *time.Location {
+	if v.TimeZone != "" {
+		loc, err := geo.LoadLocation(v.TimeZone)
+		if err == nil {
+			return loc
+		}
+		log.Println("unknown venue time zone:", err)
+	}
+	return def
+}
+
+// venueTime returns t on the venue's wall clock. Venues without a time zone
+// use def.
+func venueTime(v schema.Venue, t time.Time, def *time.Location) time.Time {
+	return t.In(venueLocation(v, def))
+}
+
+// menuTypeQuery reads the type parameter of a search for menus being
+// served, which defaults to happy hour menus.
+func menuTypeQuery(q url.Values) (schema.MenuType, error) {
+	if q.Get("type") == "" {
+		return schema.MenuHappyHour, nil
+	}
+	return schema.ParseMenuType(q.Get("type"))
+}
+
+/*
+Test with this curl command:
+curl "http://localhost:8080/happy_hours/now?city=Denver"
+curl "http://localhost:8080/happy_hours/now?lat=39.7508&lng=-104.9966&radius=2&category=drink"
+curl "http://localhost:8080/happy_hours/now?city=Denver&type=late_night"
+*/
+func HappyHoursNow(db data.Database, cfg *config.Config) http.HandlerFunc {
+	loc := defaultVenueLocation(cfg)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		category, err := categoryQuery(q)
+		if err != nil {
+			writeError(w, http.StatusUnprocessableEntity, err)
+			return
+		}
+		menuType, err := menuTypeQuery(q)
+		if err != nil {
+			writeError(w, http.StatusUnprocessableEntity, err)
+			return
+		}
+		f := schema.VenueFilter{City: q.Get("city"), Category: category, MenuType: menuType}
+		var center schema.Point
+		var radius float64
+		nearby := q.Get("lat") != "" || q.Get("lng") != ""
+		if nearby {
+			center, radius, err = nearbyQuery(q)
+			if err != nil {
+				writeError(w, http.StatusUnprocessableEntity, err)
+				return
+			}
+			min, max := geo.Bounds(center, radius)
+			f.Min, f.Max = &min, &max
+		}
+
+		schedules, err := db.MenuSchedules(f)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		now := time.Now()
+		happyHours := []happyHour{}
+		byVenue := make(map[int]int)
+		byMenu := make(map[int]int)
+		var menuIDs []int
+		for _, s := range schedules {
+			if !s.ActiveAt(venueTime(s.Venue, now, loc)) {
+				continue
+			}
+			i, ok := byVenue[s.Venue.ID]
+			if !ok {
+				hh := happyHour{Venue: s.Venue, Items: []schema.MenuItem{}}
+				if nearby {
+					if s.Venue.Location == nil {
+						continue
+					}
+					d := geo.Distance(center, *s.Venue.Location)
+					if d > radius {
+						continue
+					}
+					hh.DistanceKM = &d
+				}
+				i = len(happyHours)
+				byVenue[s.Venue.ID] = i
+				happyHours = append(happyHours, hh)
+			}
+			byMenu[s.MenuID] = i
+			menuIDs = append(menuIDs, s.MenuID)
+		}
+
+		items, err := db.MenuItemsByMenus(menuIDs)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		for _, item := range items {
+			if !category.Matches(item.Category) {
+				continue
+			}
+			i := byMenu[item.MenuID]
+			happyHours[i].Items = append(happyHours[i].Items, item)
+		}
+		if nearby {
+			sort.SliceStable(happyHours, func(i, j int) bool {
+				return *happyHours[i].DistanceKM < *happyHours[j].DistanceKM
+			})
+		}
+
+		type envelope struct {
+			Data []happyHour `json:"data"`
+		}
+		writeJSON(w, http.StatusOK, envelope{happyHours})
+	})
+}
+
+const (
+	defaultUpcomingWindow = 7 * 24 * time.Hour
+	maxUpcomingWindow     = 31 * 24 * time.Hour
+)
+
+var (
+	ErrUpcomingScope  = errors.New("give exactly one of venue_id, list_id or favorites=true")
+	ErrUpcomingWindow = errors.New("to must be after from and at most 31 days later")
+)
+
+// upcomingHappyHour is one serving of a venue's menu.
+type upcomingHappyHour struct {
+	Venue  schema.Venue      `json:"venue"`
+	MenuID int               `json:"menu_id"`
+	Start  time.Time         `json:"start"`
+	End    time.Time         `json:"end"`
+	Items  []schema.MenuItem `json:"items"`
+}
+
+// upcomingVenues returns the ids of the venues an upcoming search covers:
+// a single venue, a venue list or the caller's favorites. When the scope
+// is invalid an error is written and false returned.
+func upcomingVenues(w http.ResponseWriter, r *http.Request, db data.Database) ([]int, bool) {
+	q := r.URL.Query()
+	scopes := 0
+	for _, k := range []string{"venue_id", "list_id", "favorites"} {
+		if q.Get(k) != "" {
+			scopes++
+		}
+	}
+	if scopes != 1 {
+		writeError(w, http.StatusUnprocessableEntity, ErrUpcomingScope)
+		return nil, false
+	}
+
+	var venues []schema.Venue
+	var err error
+	switch {
+	case q.Get("venue_id") != "":
+		id, perr := strconv.Atoi(q.Get("venue_id"))
+		if perr != nil {
+			writeError(w, http.StatusUnprocessableEntity, perr)
+			return nil, false
+		}
+		var v schema.Venue
+		v, err = db.VenueGet(schema.Venue{ID: id})
+		venues = []schema.Venue{v}
+	case q.Get("list_id") != "":
+		id, perr := strconv.Atoi(q.Get("list_id"))
+		if perr != nil {
+			writeError(w, http.StatusUnprocessableEntity, perr)
+			return nil, false
+		}
+		venues, err = db.VenuesByList(id)
+	default:
+		if q.Get("favorites") != "true" {
+			writeError(w, http.StatusUnprocessableEntity, ErrUpcomingScope)
+			return nil, false
+		}
+		user, ok := auth.FromContext(r.Context())
+		if !ok {
+			writeError(w, http.StatusUnauthorized, nil)
+			return nil, false
+		}
+		venues, err = db.UserFavoritesList(schema.UserFavorite{UserID: user.ID})
+	}
+	if err == data.ErrNotFound {
+		writeError(w, http.StatusNotFound, err)
+		return nil, false
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return nil, false
+	}
+
+	ids := []int{}
+	for _, v := range venues {
+		ids = append(ids, v.ID)
+	}
+	return ids, true
+}
+
+// upcomingWindow reads the from and to parameters, which default to now
+// and a week later.
+func upcomingWindow(q url.Values) (time.Time, time.Time, error) {
+	from, to := time.Now(), time.Time{}
+	var err error
+	if v := q.Get("from"); v != "" {
+		if from, err = time.Parse(time.RFC3339, v); err != nil {
+			return from, to, err
+		}
+	}
+	to = from.Add(defaultUpcomingWindow)
+	if v := q.Get("to"); v != "" {
+		if to, err = time.Parse(time.RFC3339, v); err != nil {
+			return from, to, err
+		}
+	}
+	if !to.After(from) || to.Sub(from) > maxUpcomingWindow {
+		return from, to, ErrUpcomingWindow
+	}
+	return from, to, nil
+}
+
+/*
+Test with this curl command:
+curl -H "Authorization: Bearer $TOKEN" "http://localhost:8080/happy_hours/upcoming?venue_id=1"
+curl -H "Authorization: Bearer $TOKEN" "http://localhost:8080/happy_hours/upcoming?favorites=true&from=2018-03-09T00:00:00Z&to=2018-03-11T00:00:00Z"
+*/
+func HappyHoursUpcoming(db data.Database, cfg *config.Config) http.HandlerFunc {
+	loc := defaultVenueLocation(cfg)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		from, to, err := upcomingWindow(q)
+		if err != nil {
+			writeError(w, http.StatusUnprocessableEntity, err)
+			return
+		}
+		category, err := categoryQuery(q)
+		if err != nil {
+			writeError(w, http.StatusUnprocessableEntity, err)
+			return
+		}
+		menuType, err := menuTypeQuery(q)
+		if err != nil {
+			writeError(w, http.StatusUnprocessableEntity, err)
+			return
+		}
+		ids, ok := upcomingVenues(w, r, db)
+		if !ok {
+			return
+		}
+
+		schedules, err := db.MenuSchedules(schema.VenueFilter{IDs: ids, Category: category, MenuType: menuType})
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		upcoming := []upcomingHappyHour{}
+		var menuIDs []int
+		for _, s := range schedules {
+			occs := s.Occurrences(from, to, venueLocation(s.Venue, loc))
+			if len(occs) > 0 {
+				menuIDs = append(menuIDs, s.MenuID)
+			}
+			for _, o := range occs {
+				upcoming = append(upcoming, upcomingHappyHour{Venue: s.Venue, MenuID: s.MenuID, Start: o.Start, End: o.End})
+			}
+		}
+		sort.SliceStable(upcoming, func(i, j int) bool {
+			return upcoming[i].Start.Before(upcoming[j].Start)
+		})
+
+		items, err := db.MenuItemsByMenus(menuIDs)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		byMenu := make(map[int][]schema.MenuItem)
+		for _, item := range items {
+			if category.Matches(item.Category) {
+				byMenu[item.MenuID] = append(byMenu[item.MenuID], item)
+			}
+		}
+		for i := range upcoming {
+			upcoming[i].Items = byMenu[upcoming[i].MenuID]
+			if upcoming[i].Items == nil {
+				upcoming[i].Items = []schema.MenuItem{}
+			}
+		}
+
+		type envelope struct {
+			Data []upcomingHappyHour `json:"data"`
+		}
+		writeJSON(w, http.StatusOK, envelope{upcoming})
+	})
+}
@@ -0,0 +1,327 @@
+package route
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/kernkw/hhapp/internal/config"
+	"github.com/kernkw/hhapp/internal/data"
+	"github.com/kernkw/hhapp/internal/data/datamock"
+	"github.com/kernkw/hhapp/internal/schema"
+	"github.com/kernkw/hhapp/internal/schema/schematest"
+)
+
+var (
+	allWeek = schema.MenuDateTime{
+		Monday: true, Tuesday: true, Wednesday: true, Thursday: true, Friday: true, Saturday: true, Sunday: true,
+		StartAt: 0, EndAt: schema.EndOfDay,
+	}
+	never = schema.MenuDateTime{StartAt: 0, EndAt: schema.EndOfDay}
+)
+
+func TestHappyHoursNow(t *testing.T) {
+	var filter schema.VenueFilter
+	var menus []int
+	mockStore := &datamock.Mock{
+		MenuSchedules_: func(f schema.VenueFilter) ([]schema.MenuSchedule, error) {
+			filter = f
+			return []schema.MenuSchedule{
+				{Venue: schema.Venue{ID: 1, Name: "Open"}, MenuID: 10, Times: []schema.MenuDateTime{never, allWeek}},
+				{Venue: schema.Venue{ID: 2, Name: "Closed"}, MenuID: 20, Times: []schema.MenuDateTime{never}},
+			}, nil
+		},
+		MenuItemsByMenus_: func(ids []int) ([]schema.MenuItem, error) {
+			menus = ids
+			return []schema.MenuItem{{ID: 100, MenuID: 10, Category: "drink", Price: schematest.USD(400), Description: "Well drinks"}}, nil
+		},
+	}
+
+	req, err := http.NewRequest("GET", "/happy_hours/now?city=Denver", nil)
+	checkError(err, t)
+	rr := httptest.NewRecorder()
+	http.HandlerFunc(HappyHoursNow(mockStore, &config.Config{VenueTimeZone: "UTC"})).
+		ServeHTTP(rr, req)
+
+	expected := `{"data":[{"venue":{"id":1,"name":"Open","address":"","address2":"","city":"","state":"","zip":"","country":"","image":""},"items":[{"id":100,"menu_id":10,"category":"drink","price":{"amount":"4.00","currency":"USD"},"description":"Well drinks","position":0}]}]}`
+	if rr.Code != http.StatusOK || rr.Body.String() != expected {
+		t.Errorf("got %v %v want %v %v", rr.Code, rr.Body.String(), http.StatusOK, expected)
+	}
+	if !reflect.DeepEqual(filter, schema.VenueFilter{City: "Denver", MenuType: schema.MenuHappyHour}) {
+		t.Errorf("searched with filter %+v", filter)
+	}
+	if !reflect.DeepEqual(menus, []int{10}) {
+		t.Errorf("fetched items for menus %v want [10]", menus)
+	}
+}
+
+func TestHappyHoursNow_category(t *testing.T) {
+	var filter schema.VenueFilter
+	mockStore := &datamock.Mock{
+		MenuSchedules_: func(f schema.VenueFilter) ([]schema.MenuSchedule, error) {
+			filter = f
+			return []schema.MenuSchedule{
+				{Venue: schema.Venue{ID: 1, Name: "Open"}, MenuID: 10, Times: []schema.MenuDateTime{allWeek}},
+			}, nil
+		},
+		MenuItemsByMenus_: func(ids []int) ([]schema.MenuItem, error) {
+			return []schema.MenuItem{
+				{ID: 100, MenuID: 10, Category: "drink", Price: schematest.USD(400), Description: "Well drinks"},
+				{ID: 101, MenuID: 10, Category: "food", Price: schematest.USD(600), Description: "Fries"},
+				{ID: 102, MenuID: 10, Category: "all", Price: schematest.USD(0), Description: "Half off everything"},
+			}, nil
+		},
+	}
+	handler := HappyHoursNow(mockStore, &config.Config{VenueTimeZone: "UTC"})
+
+	req, err := http.NewRequest("GET", "/happy_hours/now?city=Denver&category=Food", nil)
+	checkError(err, t)
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	var resp struct {
+		Data []happyHour `json:"data"`
+	}
+	checkError(json.Unmarshal(rr.Body.Bytes(), &resp), t)
+	if rr.Code != http.StatusOK || len(resp.Data) != 1 || len(resp.Data[0].Items) != 2 ||
+		resp.Data[0].Items[0].ID != 101 || resp.Data[0].Items[1].ID != 102 {
+		t.Errorf("got %v %v, want the food and all items", rr.Code, rr.Body.String())
+	}
+	if filter.Category != schema.CategoryFood {
+		t.Errorf("searched with filter %+v", filter)
+	}
+
+	req, err = http.NewRequest("GET", "/happy_hours/now?city=Denver&category=snacks", nil)
+	checkError(err, t)
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	if rr.Code != http.StatusUnprocessableEntity {
+		t.Errorf("unknown category: got status %v want %v", rr.Code, http.StatusUnprocessableEntity)
+	}
+}
+
+func TestHappyHours_type(t *testing.T) {
+	var filter schema.VenueFilter
+	mockStore := &datamock.Mock{
+		VenueGet_: func(v schema.Venue) (schema.Venue, error) {
+			return v, nil
+		},
+		MenuSchedules_: func(f schema.VenueFilter) ([]schema.MenuSchedule, error) {
+			filter = f
+			return nil, nil
+		},
+		MenuItemsByMenus_: func(ids []int) ([]schema.MenuItem, error) {
+			return nil, nil
+		},
+	}
+	cfg := &config.Config{VenueTimeZone: "UTC"}
+	tests := []struct {
+		handler http.Handler
+		path    string
+		want    int
+		typ     schema.MenuType
+	}{
+		{HappyHoursNow(mockStore, cfg), "/happy_hours/now?city=Denver&type=Late_Night", http.StatusOK, schema.MenuLateNight},
+		{HappyHoursNow(mockStore, cfg), "/happy_hours/now?city=Denver&type=dinner", http.StatusUnprocessableEntity, ""},
+		{HappyHoursUpcoming(mockStore, cfg), "/happy_hours/upcoming?venue_id=1&type=brunch", http.StatusOK, schema.MenuBrunch},
+		{HappyHoursUpcoming(mockStore, cfg), "/happy_hours/upcoming?venue_id=1&type=dinner", http.StatusUnprocessableEntity, ""},
+	}
+	for _, tt := range tests {
+		filter = schema.VenueFilter{}
+		req, err := http.NewRequest("GET", tt.path, nil)
+		checkError(err, t)
+		rr := httptest.NewRecorder()
+		tt.handler.ServeHTTP(rr, req)
+
+		if rr.Code != tt.want || filter.MenuType != tt.typ {
+			t.Errorf("%s: got status %v searching %q menus want %v and %q", tt.path, rr.Code, filter.MenuType, tt.want, tt.typ)
+		}
+	}
+}
+
+func TestHappyHoursNow_nearby(t *testing.T) {
+	mockStore := &datamock.Mock{
+		MenuSchedules_: func(f schema.VenueFilter) ([]schema.MenuSchedule, error) {
+			if f.Min == nil || f.Max == nil {
+				t.Errorf("nearby search without a bounding box")
+			}
+			return []schema.MenuSchedule{
+				{Venue: schema.Venue{ID: 1, Location: &schema.Point{Lat: 39.7392, Lng: -104.9903}}, MenuID: 10, Times: []schema.MenuDateTime{allWeek}},
+				{Venue: schema.Venue{ID: 2, Location: &schema.Point{Lat: 39.7780, Lng: -105.0330}}, MenuID: 20, Times: []schema.MenuDateTime{allWeek}},
+				{Venue: schema.Venue{ID: 3, Location: &schema.Point{Lat: 39.7530, Lng: -105.0000}}, MenuID: 30, Times: []schema.MenuDateTime{allWeek}},
+				{Venue: schema.Venue{ID: 4}, MenuID: 40, Times: []schema.MenuDateTime{allWeek}},
+			}, nil
+		},
+		MenuItemsByMenus_: func(ids []int) ([]schema.MenuItem, error) {
+			if !reflect.DeepEqual(ids, []int{10, 30}) {
+				t.Errorf("fetched items for menus %v want [10 30]", ids)
+			}
+			return nil, nil
+		},
+	}
+
+	req, err := http.NewRequest("GET", "/happy_hours/now?lat=39.7528&lng=-104.9997&radius=3", nil)
+	checkError(err, t)
+	rr := httptest.NewRecorder()
+	http.HandlerFunc(HappyHoursNow(mockStore, &config.Config{VenueTimeZone: "UTC"})).
+		ServeHTTP(rr, req)
+
+	var resp struct {
+		Data []happyHour `json:"data"`
+	}
+	checkError(json.Unmarshal(rr.Body.Bytes(), &resp), t)
+	if rr.Code != http.StatusOK || len(resp.Data) != 2 || resp.Data[0].Venue.ID != 3 || resp.Data[1].Venue.ID != 1 {
+		t.Errorf("got %v %v, want venues 3 then 1", rr.Code, rr.Body.String())
+	}
+}
+
+func TestVenueTime_DST(t *testing.T) {
+	hours := func(h int) schema.TimeOfDay { return schema.TimeOfDay(h * 60 * 60) }
+	weekdays := []schema.MenuDateTime{{
+		Monday: true, Tuesday: true, Wednesday: true, Thursday: true, Friday: true,
+		StartAt: hours(16), EndAt: hours(18),
+	}}
+	saturdayNight := []schema.MenuDateTime{{Saturday: true, StartAt: hours(22), EndAt: hours(3), Overnight: true}}
+	newYork := schema.Venue{TimeZone: "America/New_York"}
+	denver := schema.Venue{TimeZone: "America/Denver"}
+	def, err := time.LoadLocation("America/Denver")
+	if err != nil {
+		t.Skip(err)
+	}
+
+	tests := []struct {
+		name  string
+		venue schema.Venue
+		times []schema.MenuDateTime
+		at    string
+		want  bool
+	}{
+		// Clocks sprang forward on 2018-03-11 in both zones.
+		{"new york before spring forward", newYork, weekdays, "2018-03-09T22:30:00Z", true},
+		{"new york after spring forward", newYork, weekdays, "2018-03-12T22:30:00Z", false},
+		{"new york after spring forward, an hour earlier", newYork, weekdays, "2018-03-12T21:30:00Z", true},
+		{"denver before spring forward", denver, weekdays, "2018-03-09T22:30:00Z", false},
+		{"denver after spring forward", denver, weekdays, "2018-03-12T22:30:00Z", true},
+		{"no zone uses the default", schema.Venue{}, weekdays, "2018-03-12T22:30:00Z", true},
+		// And fell back on 2018-11-04.
+		{"new york after fall back", newYork, weekdays, "2018-11-05T22:30:00Z", true},
+		{"new york after fall back, an hour later", newYork, weekdays, "2018-11-05T23:30:00Z", false},
+		// An overnight window through the missing and the repeated hour.
+		{"before the skipped hour", newYork, saturdayNight, "2018-03-11T06:30:00Z", true},
+		{"after the skipped hour", newYork, saturdayNight, "2018-03-11T07:30:00Z", false},
+		{"first 01:30 of the repeated hour", newYork, saturdayNight, "2018-11-04T05:30:00Z", true},
+		{"second 01:30 of the repeated hour", newYork, saturdayNight, "2018-11-04T06:30:00Z", true},
+		{"03:30 after fall back", newYork, saturdayNight, "2018-11-04T08:30:00Z", false},
+	}
+	for _, tt := range tests {
+		at, err := time.Parse(time.RFC3339, tt.at)
+		checkError(err, t)
+		s := schema.MenuSchedule{Venue: tt.venue, Times: tt.times}
+		if got := s.ActiveAt(venueTime(tt.venue, at, def)); got != tt.want {
+			t.Errorf("%s: got %v want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestHappyHoursUpcoming(t *testing.T) {
+	weekdays := schema.MenuDateTime{
+		Monday: true, Tuesday: true, Wednesday: true, Thursday: true, Friday: true,
+		StartAt: 16 * 60 * 60, EndAt: 18 * 60 * 60,
+	}
+	saturday := schema.MenuDateTime{Saturday: true, StartAt: 12 * 60 * 60, EndAt: 14 * 60 * 60}
+	var filter schema.VenueFilter
+	mockStore := &datamock.Mock{
+		UserFavoritesList_: func(u schema.UserFavorite) ([]schema.Venue, error) {
+			if u.UserID != 42 {
+				t.Errorf("listed favorites of user %v", u.UserID)
+			}
+			return []schema.Venue{{ID: 1}, {ID: 2}}, nil
+		},
+		MenuSchedules_: func(f schema.VenueFilter) ([]schema.MenuSchedule, error) {
+			filter = f
+			return []schema.MenuSchedule{
+				{Venue: schema.Venue{ID: 1, TimeZone: "America/New_York"}, MenuID: 10, Times: []schema.MenuDateTime{weekdays}},
+				{Venue: schema.Venue{ID: 2, TimeZone: "America/Denver"}, MenuID: 20, Times: []schema.MenuDateTime{weekdays, saturday}},
+			}, nil
+		},
+		MenuItemsByMenus_: func(ids []int) ([]schema.MenuItem, error) {
+			return []schema.MenuItem{{ID: 100, MenuID: 20, Description: "Tacos"}}, nil
+		},
+	}
+
+	// Friday afternoon to Saturday evening, UTC.
+	req, err := http.NewRequest("GET", "/happy_hours/upcoming?favorites=true&from=2018-03-09T12:00:00Z&to=2018-03-11T00:00:00Z", nil)
+	checkError(err, t)
+	req = withUser(req, schema.User{ID: 42})
+	rr := httptest.NewRecorder()
+	http.HandlerFunc(HappyHoursUpcoming(mockStore, &config.Config{VenueTimeZone: "UTC"})).
+		ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("got %v %v want %v", rr.Code, rr.Body.String(), http.StatusOK)
+	}
+	if !reflect.DeepEqual(filter.IDs, []int{1, 2}) || filter.MenuType != schema.MenuHappyHour {
+		t.Errorf("searched venues %v for %q menus want [1 2] and happy_hour", filter.IDs, filter.MenuType)
+	}
+	var resp struct {
+		Data []struct {
+			Venue schema.Venue      `json:"venue"`
+			Start string            `json:"start"`
+			Items []schema.MenuItem `json:"items"`
+		} `json:"data"`
+	}
+	checkError(json.Unmarshal(rr.Body.Bytes(), &resp), t)
+	want := []struct {
+		venue int
+		start string
+		items int
+	}{
+		{1, "2018-03-09T16:00:00-05:00", 0},
+		{2, "2018-03-09T16:00:00-07:00", 1},
+		{2, "2018-03-10T12:00:00-07:00", 1},
+	}
+	if len(resp.Data) != len(want) {
+		t.Fatalf("got %v, want %v occurrences", rr.Body.String(), len(want))
+	}
+	for i, w := range want {
+		got := resp.Data[i]
+		if got.Venue.ID != w.venue || got.Start != w.start || len(got.Items) != w.items {
+			t.Errorf("occurrence %v: got venue %v at %v with %v items, want %+v", i, got.Venue.ID, got.Start, len(got.Items), w)
+		}
+	}
+}
+
+func TestHappyHoursUpcoming_bad_query(t *testing.T) {
+	tests := []struct {
+		query string
+		want  int
+	}{
+		{"", http.StatusUnprocessableEntity},
+		{"venue_id=1&list_id=2", http.StatusUnprocessableEntity},
+		{"favorites=yes", http.StatusUnprocessableEntity},
+		{"venue_id=1&from=2018-03-09T00:00:00Z&to=2018-03-08T00:00:00Z", http.StatusUnprocessableEntity},
+		{"venue_id=1&from=2018-03-09T00:00:00Z&to=2018-06-09T00:00:00Z", http.StatusUnprocessableEntity},
+		{"venue_id=1&from=tomorrow", http.StatusUnprocessableEntity},
+		{"venue_id=9", http.StatusNotFound},
+	}
+	mockStore := &datamock.Mock{
+		VenueGet_: func(v schema.Venue) (schema.Venue, error) {
+			return schema.Venue{}, data.ErrNotFound
+		},
+	}
+	for _, tt := range tests {
+		req, err := http.NewRequest("GET", "/happy_hours/upcoming?"+tt.query, nil)
+		checkError(err, t)
+		req = withUser(req, schema.User{ID: 42})
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(HappyHoursUpcoming(mockStore, &config.Config{})).
+			ServeHTTP(rr, req)
+
+		if rr.Code != tt.want {
+			t.Errorf("%q: got status %v want %v", tt.query, rr.Code, tt.want)
+		}
+	}
+}
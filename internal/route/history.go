@@ -0,0 +1,189 @@
+package route
+
+import (
+	"errors"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/kernkw/hhapp/internal/data"
+	"github.com/kernkw/hhapp/internal/schema"
+)
+
+const (
+	defaultHistoryLimit = 100
+	maxHistoryLimit     = 500
+)
+
+var (
+	ErrHistoryLimit = errors.New("limit must be between 1 and 500")
+	ErrSnapshotTime = errors.New("at must be an RFC 3339 time")
+)
+
+// historyFilter reads the menu_id, after, until and limit parameters of a
+// history search of venueID.
+func historyFilter(r *http.Request, venueID int) (schema.MenuChangeFilter, error) {
+	q := r.URL.Query()
+	f := schema.MenuChangeFilter{VenueID: venueID, Limit: defaultHistoryLimit}
+	var err error
+	if v := q.Get("menu_id"); v != "" {
+		if f.MenuID, err = strconv.Atoi(v); err != nil {
+			return f, err
+		}
+	}
+	if v := q.Get("after"); v != "" {
+		if f.After, err = time.Parse(time.RFC3339, v); err != nil {
+			return f, err
+		}
+	}
+	if v := q.Get("until"); v != "" {
+		if f.Until, err = time.Parse(time.RFC3339, v); err != nil {
+			return f, err
+		}
+	}
+	if v := q.Get("limit"); v != "" {
+		if f.Limit, err = strconv.Atoi(v); err != nil || f.Limit < 1 || f.Limit > maxHistoryLimit {
+			return f, ErrHistoryLimit
+		}
+	}
+	return f, nil
+}
+
+/*
+Test with this curl command:
+curl -H "Authorization: Bearer $TOKEN" "http://localhost:8080/venues/1/menu/history"
+curl -H "Authorization: Bearer $TOKEN" "http://localhost:8080/venues/1/menu/history?menu_id=1&after=2018-03-01T00:00:00Z&limit=20"
+*/
+func MenuHistoryGet(db data.Database) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(mux.Vars(r)["id"])
+		if err != nil {
+			writeError(w, http.StatusUnprocessableEntity, err)
+			return
+		}
+		f, err := historyFilter(r, id)
+		if err != nil {
+			writeError(w, http.StatusUnprocessableEntity, err)
+			return
+		}
+		if _, ok := authorizeVenue(w, r, db, id); !ok {
+			return
+		}
+		changes, err := db.MenuChanges(f)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		if changes == nil {
+			changes = []schema.MenuChange{}
+		}
+
+		type envelope struct {
+			Data []schema.MenuChange `json:"data"`
+		}
+		writeJSON(w, http.StatusOK, envelope{changes})
+	})
+}
+
+/*
+Test with this curl command:
+curl -H "Authorization: Bearer $TOKEN" "http://localhost:8080/venues/1/menu?at=2018-03-01T18:00:00Z"
+*/
+func MenuSnapshotGet(db data.Database) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(mux.Vars(r)["id"])
+		if err != nil {
+			writeError(w, http.StatusUnprocessableEntity, err)
+			return
+		}
+		at, err := time.Parse(time.RFC3339, r.URL.Query().Get("at"))
+		if err != nil {
+			writeError(w, http.StatusUnprocessableEntity, ErrSnapshotTime)
+			return
+		}
+		if _, ok := authorizeVenue(w, r, db, id); !ok {
+			return
+		}
+
+		// Start from the venue's menus as they are now.
+		menus, err := db.MenusByVenue(id)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		byMenu := make(map[int]*schema.MenuState, len(menus))
+		var menuIDs []int
+		for _, m := range menus {
+			s := &schema.MenuState{Menu: m}
+			if s.Schedule, err = db.MenuDateTimesGet(m.ID); err != nil {
+				writeError(w, http.StatusInternalServerError, err)
+				return
+			}
+			if s.Exceptions, err = db.MenuExceptionsGet(m.ID); err != nil {
+				writeError(w, http.StatusInternalServerError, err)
+				return
+			}
+			byMenu[m.ID] = s
+			menuIDs = append(menuIDs, m.ID)
+		}
+		items, err := db.MenuItemsByMenus(menuIDs)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		for _, item := range items {
+			if s, ok := byMenu[item.MenuID]; ok {
+				s.Items = append(s.Items, item)
+			}
+		}
+
+		// Then undo every change made since, newest first. A menu deleted
+		// since is rebuilt from the values its delete changes recorded.
+		changes, err := db.MenuChanges(schema.MenuChangeFilter{VenueID: id, After: at})
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		for _, c := range changes {
+			s, ok := byMenu[c.MenuID]
+			if !ok {
+				if c.Action != schema.ChangeDelete {
+					continue
+				}
+				s = &schema.MenuState{Menu: schema.Menu{ID: c.MenuID, VenueID: id}}
+				byMenu[c.MenuID] = s
+			}
+			if err := s.Undo(c); err != nil {
+				writeError(w, http.StatusInternalServerError, err)
+				return
+			}
+		}
+
+		// Menus created since have had their creation undone and are left
+		// out.
+		states := []schema.MenuState{}
+		for _, s := range byMenu {
+			if s.ID == 0 {
+				continue
+			}
+			if s.Schedule == nil {
+				s.Schedule = []schema.MenuDateTime{}
+			}
+			if s.Exceptions == nil {
+				s.Exceptions = []schema.MenuException{}
+			}
+			if s.Items == nil {
+				s.Items = []schema.MenuItem{}
+			}
+			states = append(states, *s)
+		}
+		sort.Slice(states, func(i, j int) bool { return states[i].ID < states[j].ID })
+
+		type envelope struct {
+			Data []schema.MenuState `json:"data"`
+		}
+		writeJSON(w, http.StatusOK, envelope{states})
+	})
+}
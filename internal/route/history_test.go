@@ -0,0 +1,147 @@
+package route
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/kernkw/hhapp/internal/schema"
+	"github.com/kernkw/hhapp/internal/schema/schematest"
+)
+
+// mustJSON returns v as a change's before or after value.
+func mustJSON(t *testing.T, v interface{}) json.RawMessage {
+	b, err := json.Marshal(v)
+	checkError(err, t)
+	return b
+}
+
+func TestMenuHistoryGet(t *testing.T) {
+	at := time.Date(2018, 3, 1, 18, 0, 0, 0, time.UTC)
+	var filter schema.MenuChangeFilter
+	db := menuItemStore()
+	db.MenuChanges_ = func(f schema.MenuChangeFilter) ([]schema.MenuChange, error) {
+		filter = f
+		return []schema.MenuChange{{
+			ID: 3, VenueID: 5, MenuID: 7, Entity: schema.ChangeMenuItem, EntityID: 2, Action: schema.ChangeUpdate, UserID: 42,
+			Before: mustJSON(t, schema.MenuItem{ID: 2, MenuID: 7, Category: "food", Price: schematest.USD(450), Description: "Fries", Position: 1}),
+			After:  mustJSON(t, schema.MenuItem{ID: 2, MenuID: 7, Category: "food", Price: schematest.USD(600), Description: "Fries", Position: 1}),
+			At:     at,
+		}}, nil
+	}
+	rr := serve(db, "GET", "/venues/5/menu/history?menu_id=7&after=2018-03-01T00:00:00Z&limit=20", "", testOwner)
+
+	expected := `{"data":[{"id":3,"venue_id":5,"menu_id":7,"entity":"menu_item","entity_id":2,"action":"update","user_id":42,` +
+		`"before":{"id":2,"menu_id":7,"category":"food","price":{"amount":"4.50","currency":"USD"},"description":"Fries","position":1},` +
+		`"after":{"id":2,"menu_id":7,"category":"food","price":{"amount":"6.00","currency":"USD"},"description":"Fries","position":1},` +
+		`"at":"2018-03-01T18:00:00Z"}]}`
+	if rr.Code != http.StatusOK || rr.Body.String() != expected {
+		t.Errorf("got %v %v want %v %v", rr.Code, rr.Body.String(), http.StatusOK, expected)
+	}
+	want := schema.MenuChangeFilter{VenueID: 5, MenuID: 7, After: time.Date(2018, 3, 1, 0, 0, 0, 0, time.UTC), Limit: 20}
+	if filter != want {
+		t.Errorf("searched %+v want %+v", filter, want)
+	}
+
+	for _, path := range []string{"/venues/5/menu/history?limit=0", "/venues/5/menu/history?limit=501", "/venues/5/menu/history?after=yesterday"} {
+		if rr := serve(db, "GET", path, "", testOwner); rr.Code != http.StatusUnprocessableEntity {
+			t.Errorf("%s: got status %v want %v", path, rr.Code, http.StatusUnprocessableEntity)
+		}
+	}
+	if rr := serve(db, "GET", "/venues/5/menu/history", "", schema.User{ID: 43, Roles: []schema.Role{schema.RoleVenueOwner}}); rr.Code != http.StatusForbidden {
+		t.Errorf("another owner: got status %v want %v", rr.Code, http.StatusForbidden)
+	}
+}
+
+func TestMenuSnapshotGet(t *testing.T) {
+	at := time.Date(2018, 3, 1, 18, 0, 0, 0, time.UTC)
+	db := menuItemStore()
+	db.MenuChanges_ = func(f schema.MenuChangeFilter) ([]schema.MenuChange, error) {
+		if f.VenueID != 5 || !f.After.Equal(at) || f.Limit != 0 {
+			t.Errorf("searched %+v", f)
+		}
+		// Newest first: item 3 was added, item 2 repriced from 4.50, item 9
+		// deleted and the Friday window added, all after at.
+		return []schema.MenuChange{
+			{ID: 5, MenuID: 7, Entity: schema.ChangeMenuItem, EntityID: 3, Action: schema.ChangeCreate,
+				After: mustJSON(t, schema.MenuItem{ID: 3, MenuID: 7})},
+			{ID: 4, MenuID: 7, Entity: schema.ChangeMenuItem, EntityID: 2, Action: schema.ChangeUpdate,
+				Before: mustJSON(t, schema.MenuItem{ID: 2, MenuID: 7, Category: "food", Price: schematest.USD(450), Description: "Fries", Position: 1}),
+				After:  mustJSON(t, schema.MenuItem{ID: 2, MenuID: 7, Category: "food", Price: schematest.USD(600), Description: "Fries", Position: 1})},
+			{ID: 2, MenuID: 7, Entity: schema.ChangeMenuItem, EntityID: 9, Action: schema.ChangeDelete,
+				Before: mustJSON(t, schema.MenuItem{ID: 9, MenuID: 7, Category: "drink", Price: schematest.USD(300), RegularPrice: schematest.USDPtr(500), Description: "Shots", Position: 3})},
+			{ID: 1, MenuID: 7, Entity: schema.ChangeSchedule, EntityID: 1, Action: schema.ChangeCreate,
+				After: mustJSON(t, schema.MenuDateTime{ID: 1, MenuID: 7, Friday: true})},
+		}, nil
+	}
+	rr := serve(db, "GET", "/venues/5/menu?at=2018-03-01T18:00:00Z", "", testOwner)
+
+	expected := `{"data":[` +
+		`{"id":7,"venue_id":5,"name":"Happy Hour","type":"happy_hour","schedule":[],` +
+		`"exceptions":[{"id":2,"menu_id":7,"kind":"blackout","start_date":"2018-12-25","end_date":"2018-12-25","start_at":"00:00","end_at":"00:00","overnight":false,"note":"Closed"}],"items":[` +
+		`{"id":1,"menu_id":7,"category":"drink","price":{"amount":"5.00","currency":"USD"},"description":"Draft beer","position":0},` +
+		`{"id":2,"menu_id":7,"category":"food","price":{"amount":"4.50","currency":"USD"},"description":"Fries","position":1},` +
+		`{"id":9,"menu_id":7,"category":"drink","price":{"amount":"3.00","currency":"USD"},"regular_price":{"amount":"5.00","currency":"USD"},"description":"Shots","position":3,"savings":{"amount":"2.00","currency":"USD","percent":40}}]},` +
+		`{"id":8,"venue_id":5,"name":"Late Night","type":"late_night","schedule":[],"exceptions":[],"items":[` +
+		`{"id":80,"menu_id":8,"category":"drink","price":{"amount":"5.00","currency":"USD"},"description":"Well drinks","position":0}]}]}`
+	if rr.Code != http.StatusOK || rr.Body.String() != expected {
+		t.Errorf("got %v %v want %v %v", rr.Code, rr.Body.String(), http.StatusOK, expected)
+	}
+
+	if rr := serve(db, "GET", "/venues/5/menu", "", testOwner); rr.Code != http.StatusUnprocessableEntity {
+		t.Errorf("no time: got status %v want %v", rr.Code, http.StatusUnprocessableEntity)
+	}
+	if rr := serve(db, "GET", "/venues/6/menu?at=2018-03-01T18:00:00Z", "", testOwner); rr.Code != http.StatusNotFound {
+		t.Errorf("unknown venue: got status %v want %v", rr.Code, http.StatusNotFound)
+	}
+}
+
+func TestMenuSnapshotGet_menus(t *testing.T) {
+	christmas := schema.Date{Year: 2018, Month: time.December, Day: 25}
+	db := menuItemStore()
+	menusByVenue := db.MenusByVenue_
+	db.MenusByVenue_ = func(venueID int) ([]schema.Menu, error) {
+		menus, err := menusByVenue(venueID)
+		return append(menus, schema.Menu{ID: 12, VenueID: 5, Name: "Specials", Type: schema.MenuOther}), err
+	}
+	db.MenuChanges_ = func(f schema.MenuChangeFilter) ([]schema.MenuChange, error) {
+		// Newest first: menu 12 was created with an item, menu 9 deleted
+		// with its item, window and exception, and menu 8 renamed, all
+		// after at.
+		return []schema.MenuChange{
+			{ID: 9, MenuID: 12, Entity: schema.ChangeMenuItem, EntityID: 120, Action: schema.ChangeCreate,
+				After: mustJSON(t, schema.MenuItem{ID: 120, MenuID: 12})},
+			{ID: 8, MenuID: 12, Entity: schema.ChangeMenu, EntityID: 12, Action: schema.ChangeCreate,
+				After: mustJSON(t, schema.Menu{ID: 12, VenueID: 5, Name: "Specials", Type: schema.MenuOther})},
+			{ID: 7, MenuID: 9, Entity: schema.ChangeMenu, EntityID: 9, Action: schema.ChangeDelete,
+				Before: mustJSON(t, schema.Menu{ID: 9, VenueID: 5, Name: "Brunch", Type: schema.MenuBrunch})},
+			{ID: 6, MenuID: 9, Entity: schema.ChangeException, EntityID: 30, Action: schema.ChangeDelete,
+				Before: mustJSON(t, schema.MenuException{ID: 30, MenuID: 9, Kind: schema.ExceptionBlackout, StartDate: christmas, EndDate: christmas})},
+			{ID: 5, MenuID: 9, Entity: schema.ChangeSchedule, EntityID: 20, Action: schema.ChangeDelete,
+				Before: mustJSON(t, schema.MenuDateTime{ID: 20, MenuID: 9, Sunday: true, StartAt: 10 * 60 * 60, EndAt: 13 * 60 * 60})},
+			{ID: 4, MenuID: 9, Entity: schema.ChangeMenuItem, EntityID: 90, Action: schema.ChangeDelete,
+				Before: mustJSON(t, schema.MenuItem{ID: 90, MenuID: 9, Category: "drink", Price: schematest.USD(400), Description: "Mimosa"})},
+			{ID: 3, MenuID: 8, Entity: schema.ChangeMenu, EntityID: 8, Action: schema.ChangeUpdate,
+				Before: mustJSON(t, schema.Menu{ID: 8, VenueID: 5, Name: "Night Owl", Type: schema.MenuLateNight}),
+				After:  mustJSON(t, schema.Menu{ID: 8, VenueID: 5, Name: "Late Night", Type: schema.MenuLateNight})},
+		}, nil
+	}
+	rr := serve(db, "GET", "/venues/5/menu?at=2018-03-01T18:00:00Z", "", testOwner)
+
+	expected := `{"data":[` +
+		`{"id":7,"venue_id":5,"name":"Happy Hour","type":"happy_hour","schedule":[{"id":1,"menu_id":7,"monday":false,"tuesday":false,"wednesday":false,"thursday":false,"friday":true,"saturday":false,"sunday":false,"start_at":"15:00","end_at":"18:00","overnight":false}],` +
+		`"exceptions":[{"id":2,"menu_id":7,"kind":"blackout","start_date":"2018-12-25","end_date":"2018-12-25","start_at":"00:00","end_at":"00:00","overnight":false,"note":"Closed"}],"items":[` +
+		`{"id":1,"menu_id":7,"category":"drink","price":{"amount":"5.00","currency":"USD"},"description":"Draft beer","position":0},` +
+		`{"id":2,"menu_id":7,"category":"food","price":{"amount":"6.00","currency":"USD"},"description":"Fries","position":1},` +
+		`{"id":3,"menu_id":7,"category":"drink","price":{"amount":"7.00","currency":"USD"},"description":"House wine","position":2}]},` +
+		`{"id":8,"venue_id":5,"name":"Night Owl","type":"late_night","schedule":[],"exceptions":[],"items":[` +
+		`{"id":80,"menu_id":8,"category":"drink","price":{"amount":"5.00","currency":"USD"},"description":"Well drinks","position":0}]},` +
+		`{"id":9,"venue_id":5,"name":"Brunch","type":"brunch",` +
+		`"schedule":[{"id":20,"menu_id":9,"monday":false,"tuesday":false,"wednesday":false,"thursday":false,"friday":false,"saturday":false,"sunday":true,"start_at":"10:00","end_at":"13:00","overnight":false}],` +
+		`"exceptions":[{"id":30,"menu_id":9,"kind":"blackout","start_date":"2018-12-25","end_date":"2018-12-25","start_at":"00:00","end_at":"00:00","overnight":false,"note":""}],` +
+		`"items":[{"id":90,"menu_id":9,"category":"drink","price":{"amount":"4.00","currency":"USD"},"description":"Mimosa","position":0}]}]}`
+	if rr.Code != http.StatusOK || rr.Body.String() != expected {
+		t.Errorf("got %v %v want %v %v", rr.Code, rr.Body.String(), http.StatusOK, expected)
+	}
+}
@@ -0,0 +1,204 @@
+package route
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/kernkw/hhapp/internal/auth"
+	"github.com/kernkw/hhapp/internal/data"
+	"github.com/kernkw/hhapp/internal/geo"
+	"github.com/kernkw/hhapp/internal/schema"
+)
+
+// menuItemOrder is the body of a reorder request: the menu's item ids in
+// the order they should be listed.
+type menuItemOrder struct {
+	IDs []int `json:"ids"`
+}
+
+// categoryQuery reads the optional category parameter of a search.
+func categoryQuery(q url.Values) (schema.Category, error) {
+	if q.Get("category") == "" {
+		return "", nil
+	}
+	return schema.ParseCategory(q.Get("category"))
+}
+
+// venueCurrency returns the currency a venue's items default to.
+func venueCurrency(db data.Database, venueID int) (string, error) {
+	v, err := db.VenueGet(schema.Venue{ID: venueID})
+	if err != nil {
+		return "", err
+	}
+	return geo.Currency(v.Country), nil
+}
+
+// authorizeMenuItem checks that the request's user may edit the menu item
+// identified by id and returns it. When they may not, an error is written
+// and false returned.
+func authorizeMenuItem(w http.ResponseWriter, r *http.Request, db data.Database, id int) (schema.MenuItem, bool) {
+	item, err := db.MenuItemGet(id)
+	if err == data.ErrNotFound {
+		writeError(w, http.StatusNotFound, err)
+		return item, false
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return item, false
+	}
+	_, ok := authorizeMenu(w, r, db, item.MenuID)
+	return item, ok
+}
+
+/*
+Test with this curl command:
+curl -X PUT -H "Content-Type: application/json" -H "Authorization: Bearer $TOKEN" -d '{"category": "drink", "price": {"amount": "4.50", "currency": "USD"}, "description": "LOCAL DRAFT BEERS"}' http://localhost:8080/menu_items/1
+*/
+func MenuItemUpdate(db data.Database) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(mux.Vars(r)["id"])
+		if err != nil {
+			writeError(w, http.StatusUnprocessableEntity, err)
+			return
+		}
+		var m schema.MenuItem
+		if err := json.NewDecoder(r.Body).Decode(&m); err != nil {
+			writeError(w, http.StatusUnprocessableEntity, err)
+			return
+		}
+		defer r.Body.Close()
+
+		item, ok := authorizeMenuItem(w, r, db, id)
+		if !ok {
+			return
+		}
+		// An item stays on its menu, at its place in the menu's order, and
+		// keeps its currency unless the client gives another.
+		m.ID, m.MenuID, m.Position = item.ID, item.MenuID, item.Position
+		if m, err = m.InCurrency(item.Price.Currency); err != nil {
+			writeError(w, http.StatusUnprocessableEntity, err)
+			return
+		}
+		if err := m.Validate(); err != nil {
+			writeError(w, http.StatusUnprocessableEntity, err)
+			return
+		}
+
+		user, _ := auth.FromContext(r.Context())
+		err = db.UpdateMenuItem(m, user.ID)
+		if err == data.ErrNotFound {
+			writeError(w, http.StatusNotFound, err)
+			return
+		}
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		type envelope struct {
+			Data schema.MenuItem `json:"data"`
+		}
+		writeJSON(w, http.StatusOK, envelope{m})
+	})
+}
+
+/*
+Test with this curl command:
+curl -X DELETE -H "Authorization: Bearer $TOKEN" http://localhost:8080/menu_items/1
+*/
+func MenuItemDelete(db data.Database) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(mux.Vars(r)["id"])
+		if err != nil {
+			writeError(w, http.StatusUnprocessableEntity, err)
+			return
+		}
+		if _, ok := authorizeMenuItem(w, r, db, id); !ok {
+			return
+		}
+
+		user, _ := auth.FromContext(r.Context())
+		err = db.DeleteMenuItem(id, user.ID)
+		if err == data.ErrNotFound {
+			writeError(w, http.StatusNotFound, err)
+			return
+		}
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		type envelope struct {
+			Status string `json:"status"`
+		}
+		writeJSON(w, http.StatusOK, envelope{http.StatusText(http.StatusOK)})
+	})
+}
+
+/*
+Test with this curl command:
+curl -X PUT -H "Content-Type: application/json" -H "Authorization: Bearer $TOKEN" -d '{"ids": [3, 1, 2]}' http://localhost:8080/menus/1/items/order
+*/
+func MenuItemsReorder(db data.Database) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		menuID, err := strconv.Atoi(mux.Vars(r)["id"])
+		if err != nil {
+			writeError(w, http.StatusUnprocessableEntity, err)
+			return
+		}
+		var order menuItemOrder
+		if err := json.NewDecoder(r.Body).Decode(&order); err != nil {
+			writeError(w, http.StatusUnprocessableEntity, err)
+			return
+		}
+		defer r.Body.Close()
+
+		if _, ok := authorizeMenu(w, r, db, menuID); !ok {
+			return
+		}
+		items, err := db.MenuItemsByMenus([]int{menuID})
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		byID := make(map[int]schema.MenuItem, len(items))
+		for _, item := range items {
+			byID[item.ID] = item
+		}
+		if len(order.IDs) != len(items) {
+			writeError(w, http.StatusUnprocessableEntity, data.ErrInvalidOrder)
+			return
+		}
+		reordered := make([]schema.MenuItem, 0, len(items))
+		for i, id := range order.IDs {
+			item, ok := byID[id]
+			if !ok {
+				writeError(w, http.StatusUnprocessableEntity, data.ErrInvalidOrder)
+				return
+			}
+			delete(byID, id)
+			item.Position = i
+			reordered = append(reordered, item)
+		}
+
+		user, _ := auth.FromContext(r.Context())
+		err = db.ReorderMenuItems(menuID, order.IDs, user.ID)
+		// The items checked above changed before the store locked them.
+		if err == data.ErrInvalidOrder {
+			writeError(w, http.StatusConflict, err)
+			return
+		}
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		type envelope struct {
+			Data []schema.MenuItem `json:"data"`
+		}
+		writeJSON(w, http.StatusOK, envelope{reordered})
+	})
+}
@@ -0,0 +1,187 @@
+package route
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/kernkw/hhapp/internal/data"
+	"github.com/kernkw/hhapp/internal/data/datamock"
+	"github.com/kernkw/hhapp/internal/schema"
+	"github.com/kernkw/hhapp/internal/schema/schematest"
+)
+
+// menuItemStore extends menuStore with three items on menu 7 and item 80 on
+// menu 8.
+func menuItemStore() *datamock.Mock {
+	items := []schema.MenuItem{
+		{ID: 1, MenuID: 7, Category: "drink", Price: schematest.USD(500), Description: "Draft beer", Position: 0},
+		{ID: 2, MenuID: 7, Category: "food", Price: schematest.USD(600), Description: "Fries", Position: 1},
+		{ID: 3, MenuID: 7, Category: "drink", Price: schematest.USD(700), Description: "House wine", Position: 2},
+		{ID: 80, MenuID: 8, Category: "drink", Price: schematest.USD(500), Description: "Well drinks", Position: 0},
+	}
+	db := menuStore()
+	db.MenuItemGet_ = func(id int) (schema.MenuItem, error) {
+		for _, item := range items {
+			if item.ID == id {
+				return item, nil
+			}
+		}
+		return schema.MenuItem{}, data.ErrNotFound
+	}
+	db.MenuItemsByMenus_ = func(ids []int) ([]schema.MenuItem, error) {
+		var list []schema.MenuItem
+		for _, item := range items {
+			for _, id := range ids {
+				if item.MenuID == id {
+					list = append(list, item)
+				}
+			}
+		}
+		return list, nil
+	}
+	return db
+}
+
+func TestMenuItemUpdate(t *testing.T) {
+	var updated schema.MenuItem
+	db := menuItemStore()
+	db.UpdateMenuItem_ = func(m schema.MenuItem, userID int) error {
+		if userID != testOwner.ID {
+			t.Errorf("updated by user %d", userID)
+		}
+		updated = m
+		return nil
+	}
+	rr := serve(db, "PUT", "/menu_items/2", `{"menu_id":8,"category":"food","price":{"amount":"4.50","currency":"USD"},"description":"Fries","position":9}`, testOwner)
+
+	expected := `{"data":{"id":2,"menu_id":7,"category":"food","price":{"amount":"4.50","currency":"USD"},"description":"Fries","position":1}}`
+	if rr.Code != http.StatusOK || rr.Body.String() != expected {
+		t.Errorf("got %v %v want %v %v", rr.Code, rr.Body.String(), http.StatusOK, expected)
+	}
+	if updated.ID != 2 || updated.MenuID != 7 || updated.Price != schematest.USD(450) {
+		t.Errorf("stored %+v", updated)
+	}
+
+	if rr := serve(db, "PUT", "/menu_items/4", `{"category":"food","price":4.5}`, testOwner); rr.Code != http.StatusNotFound {
+		t.Errorf("unknown item: got status %v want %v", rr.Code, http.StatusNotFound)
+	}
+	if rr := serve(db, "PUT", "/menu_items/2", `{"category":"food","price":4.5}`, schema.User{ID: 43, Roles: []schema.Role{schema.RoleVenueOwner}}); rr.Code != http.StatusForbidden {
+		t.Errorf("another owner: got status %v want %v", rr.Code, http.StatusForbidden)
+	}
+
+	// An item added or removed between the check and the store's update.
+	db.ReorderMenuItems_ = func(menuID int, ids []int, userID int) error {
+		return data.ErrInvalidOrder
+	}
+	if rr := serve(db, "PUT", "/menus/7/items/order", `{"ids":[3,1,2]}`, testOwner); rr.Code != http.StatusConflict {
+		t.Errorf("order rejected by the store: got status %v want %v", rr.Code, http.StatusConflict)
+	}
+}
+
+func TestMenuItem_missing_price(t *testing.T) {
+	db := menuItemStore()
+	db.AddToMenu_ = func(mi schema.MenuItem, userID int) (int, error) {
+		t.Errorf("added %+v", mi)
+		return 1, nil
+	}
+	db.UpdateMenuItem_ = func(m schema.MenuItem, userID int) error {
+		t.Errorf("updated %+v", m)
+		return nil
+	}
+	tests := []struct {
+		method, path, body string
+	}{
+		{"POST", "/add_menu_item", `{"menu_id":7,"category":"drink","description":"Draft beer"}`},
+		{"PUT", "/menu_items/2", `{"category":"food","description":"Fries"}`},
+	}
+	for _, tt := range tests {
+		rr := serve(db, tt.method, tt.path, tt.body, testOwner)
+		expected := `{"status":"price is a required field.","errors":{"price":"price is a required field."}}`
+		if rr.Code != http.StatusUnprocessableEntity || rr.Body.String() != expected {
+			t.Errorf("%s %s: got %v %v want %v %v", tt.method, tt.path, rr.Code, rr.Body.String(), http.StatusUnprocessableEntity, expected)
+		}
+	}
+}
+
+func TestMenuItemDelete(t *testing.T) {
+	deleted := 0
+	db := menuItemStore()
+	db.DeleteMenuItem_ = func(id, userID int) error {
+		deleted = id
+		return nil
+	}
+	if rr := serve(db, "DELETE", "/menu_items/2", "", schema.User{ID: 43, Roles: []schema.Role{schema.RoleVenueOwner}}); rr.Code != http.StatusForbidden || deleted != 0 {
+		t.Errorf("another owner: got status %v want %v", rr.Code, http.StatusForbidden)
+	}
+	if rr := serve(db, "DELETE", "/menu_items/2", "", testOwner); rr.Code != http.StatusOK || deleted != 2 {
+		t.Errorf("got status %v, deleted %d", rr.Code, deleted)
+	}
+	if rr := serve(db, "DELETE", "/menu_items/4", "", testOwner); rr.Code != http.StatusNotFound {
+		t.Errorf("unknown item: got status %v want %v", rr.Code, http.StatusNotFound)
+	}
+}
+
+func TestMenuItemsReorder(t *testing.T) {
+	var stored []int
+	db := menuItemStore()
+	db.ReorderMenuItems_ = func(menuID int, ids []int, userID int) error {
+		if menuID != 7 {
+			t.Errorf("reordered menu %d", menuID)
+		}
+		stored = ids
+		return nil
+	}
+	rr := serve(db, "PUT", "/menus/7/items/order", `{"ids":[3,1,2]}`, testOwner)
+
+	expected := `{"data":[` +
+		`{"id":3,"menu_id":7,"category":"drink","price":{"amount":"7.00","currency":"USD"},"description":"House wine","position":0},` +
+		`{"id":1,"menu_id":7,"category":"drink","price":{"amount":"5.00","currency":"USD"},"description":"Draft beer","position":1},` +
+		`{"id":2,"menu_id":7,"category":"food","price":{"amount":"6.00","currency":"USD"},"description":"Fries","position":2}]}`
+	if rr.Code != http.StatusOK || rr.Body.String() != expected {
+		t.Errorf("got %v %v want %v %v", rr.Code, rr.Body.String(), http.StatusOK, expected)
+	}
+	if !reflect.DeepEqual(stored, []int{3, 1, 2}) {
+		t.Errorf("stored order %v", stored)
+	}
+
+	for _, body := range []string{
+		`{"ids":[3,1]}`,
+		`{"ids":[3,1,1]}`,
+		`{"ids":[3,1,80]}`,
+		`{"ids":[3,1,2,80]}`,
+	} {
+		stored = nil
+		rr := serve(db, "PUT", "/menus/7/items/order", body, testOwner)
+		if rr.Code != http.StatusUnprocessableEntity || stored != nil {
+			t.Errorf("%s: got status %v want %v", body, rr.Code, http.StatusUnprocessableEntity)
+		}
+	}
+	if rr := serve(db, "PUT", "/menus/7/items/order", `{"ids":[3,1,2]}`, schema.User{ID: 43, Roles: []schema.Role{schema.RoleVenueOwner}}); rr.Code != http.StatusForbidden {
+		t.Errorf("another owner: got status %v want %v", rr.Code, http.StatusForbidden)
+	}
+}
+
+func TestMenuItemsGet_category(t *testing.T) {
+	db := menuItemStore()
+	rr := serve(db, "GET", "/menu_items?menu_id=7&category=DRINK", "", schema.User{})
+
+	expected := `{"data":[` +
+		`{"id":1,"menu_id":7,"category":"drink","price":{"amount":"5.00","currency":"USD"},"description":"Draft beer","position":0},` +
+		`{"id":3,"menu_id":7,"category":"drink","price":{"amount":"7.00","currency":"USD"},"description":"House wine","position":2}]}`
+	if rr.Code != http.StatusOK || rr.Body.String() != expected {
+		t.Errorf("got %v %v want %v %v", rr.Code, rr.Body.String(), http.StatusOK, expected)
+	}
+
+	rr = serve(db, "GET", "/menu_items?venue_id=5&group=menu&category=food", "", schema.User{})
+	expected = `{"data":[` +
+		`{"menu":{"id":7,"venue_id":5,"name":"Happy Hour","type":"happy_hour"},"items":[{"id":2,"menu_id":7,"category":"food","price":{"amount":"6.00","currency":"USD"},"description":"Fries","position":1}]},` +
+		`{"menu":{"id":8,"venue_id":5,"name":"Late Night","type":"late_night"},"items":[]}]}`
+	if rr.Code != http.StatusOK || rr.Body.String() != expected {
+		t.Errorf("got %v %v want %v %v", rr.Code, rr.Body.String(), http.StatusOK, expected)
+	}
+
+	if rr := serve(db, "GET", "/menu_items?menu_id=7&category=beer", "", schema.User{}); rr.Code != http.StatusUnprocessableEntity {
+		t.Errorf("unknown category: got status %v want %v", rr.Code, http.StatusUnprocessableEntity)
+	}
+}
@@ -0,0 +1,301 @@
+package route
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/kernkw/hhapp/internal/auth"
+	"github.com/kernkw/hhapp/internal/data"
+	"github.com/kernkw/hhapp/internal/schema"
+)
+
+// venueMenu is a menu as listed for its venue, with its schedule and the
+// exceptions to it.
+type venueMenu struct {
+	schema.Menu
+	Schedule   []schema.MenuDateTime  `json:"schedule"`
+	Exceptions []schema.MenuException `json:"exceptions"`
+}
+
+// menuItems is a menu with its items, as returned by /menu_items grouped
+// by menu.
+type menuItems struct {
+	Menu  schema.Menu       `json:"menu"`
+	Items []schema.MenuItem `json:"items"`
+}
+
+// menuPatch holds the menu fields a client sent. Fields left out of the
+// request are unchanged.
+type menuPatch struct {
+	Name *string          `json:"name"`
+	Type *schema.MenuType `json:"type"`
+}
+
+/*
+Test with this curl command:
+curl http://localhost:8080/venues/1/menus
+*/
+func MenusGet(db data.Database) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(mux.Vars(r)["id"])
+		if err != nil {
+			writeError(w, http.StatusUnprocessableEntity, err)
+			return
+		}
+		if _, err := db.VenueGet(schema.Venue{ID: id}); err == data.ErrNotFound {
+			writeError(w, http.StatusNotFound, err)
+			return
+		} else if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		menus, err := db.MenusByVenue(id)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		list := []venueMenu{}
+		for _, m := range menus {
+			times, err := db.MenuDateTimesGet(m.ID)
+			if err != nil {
+				writeError(w, http.StatusInternalServerError, err)
+				return
+			}
+			if times == nil {
+				times = []schema.MenuDateTime{}
+			}
+			exceptions, err := db.MenuExceptionsGet(m.ID)
+			if err != nil {
+				writeError(w, http.StatusInternalServerError, err)
+				return
+			}
+			if exceptions == nil {
+				exceptions = []schema.MenuException{}
+			}
+			list = append(list, venueMenu{m, times, exceptions})
+		}
+
+		type envelope struct {
+			Data []venueMenu `json:"data"`
+		}
+		writeJSON(w, http.StatusOK, envelope{list})
+	})
+}
+
+/*
+Test with this curl command:
+curl -H "Content-Type: application/json" -H "Authorization: Bearer $TOKEN" -d '{"name":"Late Night","type":"late_night"}' http://localhost:8080/venues/1/menus
+*/
+func MenuCreate(db data.Database) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(mux.Vars(r)["id"])
+		if err != nil {
+			writeError(w, http.StatusUnprocessableEntity, err)
+			return
+		}
+		var menu schema.Menu
+		if err := json.NewDecoder(r.Body).Decode(&menu); err != nil {
+			writeError(w, http.StatusUnprocessableEntity, err)
+			return
+		}
+		defer r.Body.Close()
+		menu.ID, menu.VenueID = 0, id
+		if menu.Type == "" {
+			menu.Type = schema.MenuHappyHour
+		}
+		if err := menu.Validate(); err != nil {
+			writeError(w, http.StatusUnprocessableEntity, err)
+			return
+		}
+
+		if _, ok := authorizeVenue(w, r, db, id); !ok {
+			return
+		}
+		user, _ := auth.FromContext(r.Context())
+		menu.ID, err = db.CreateMenu(menu, user.ID)
+		if err == data.ErrDuplicateEntry {
+			writeError(w, http.StatusConflict, err)
+			return
+		}
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		type envelope struct {
+			Data schema.Menu `json:"data"`
+		}
+		writeJSON(w, http.StatusCreated, envelope{menu})
+	})
+}
+
+/*
+Test with this curl command:
+curl -X PATCH -H "Content-Type: application/json" -H "Authorization: Bearer $TOKEN" -d '{"name":"Brunch","type":"brunch"}' http://localhost:8080/menus/1
+*/
+func MenuUpdate(db data.Database) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(mux.Vars(r)["id"])
+		if err != nil {
+			writeError(w, http.StatusUnprocessableEntity, err)
+			return
+		}
+		var patch menuPatch
+		if err := json.NewDecoder(r.Body).Decode(&patch); err != nil {
+			writeError(w, http.StatusUnprocessableEntity, err)
+			return
+		}
+		defer r.Body.Close()
+
+		menu, ok := authorizeMenu(w, r, db, id)
+		if !ok {
+			return
+		}
+		if patch.Name != nil {
+			menu.Name = *patch.Name
+		}
+		if patch.Type != nil {
+			menu.Type = *patch.Type
+		}
+		if err := menu.Validate(); err != nil {
+			writeError(w, http.StatusUnprocessableEntity, err)
+			return
+		}
+
+		user, _ := auth.FromContext(r.Context())
+		err = db.UpdateMenu(menu, user.ID)
+		if err == data.ErrNotFound {
+			writeError(w, http.StatusNotFound, err)
+			return
+		}
+		if err == data.ErrDuplicateEntry {
+			writeError(w, http.StatusConflict, err)
+			return
+		}
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		type envelope struct {
+			Data schema.Menu `json:"data"`
+		}
+		writeJSON(w, http.StatusOK, envelope{menu})
+	})
+}
+
+/*
+Test with this curl command:
+curl -X DELETE -H "Authorization: Bearer $TOKEN" http://localhost:8080/menus/1
+*/
+func MenuDelete(db data.Database) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(mux.Vars(r)["id"])
+		if err != nil {
+			writeError(w, http.StatusUnprocessableEntity, err)
+			return
+		}
+		if _, ok := authorizeMenu(w, r, db, id); !ok {
+			return
+		}
+
+		user, _ := auth.FromContext(r.Context())
+		err = db.DeleteMenu(id, user.ID)
+		if err == data.ErrNotFound {
+			writeError(w, http.StatusNotFound, err)
+			return
+		}
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		type envelope struct {
+			Status string `json:"status"`
+		}
+		writeJSON(w, http.StatusOK, envelope{http.StatusText(http.StatusOK)})
+	})
+}
+
+// menuItemsByMenu writes the items on a single menu that match category.
+func menuItemsByMenu(w http.ResponseWriter, db data.Database, menuID string, category schema.Category) {
+	id, err := strconv.Atoi(menuID)
+	if err != nil {
+		writeError(w, http.StatusUnprocessableEntity, err)
+		return
+	}
+	if _, err := db.MenuGet(id); err == data.ErrNotFound {
+		writeError(w, http.StatusNotFound, err)
+		return
+	} else if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	items, err := db.MenuItemsByMenus([]int{id})
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	if items == nil {
+		items = []schema.MenuItem{}
+	}
+
+	type envelope struct {
+		Data []schema.MenuItem `json:"data"`
+	}
+	writeJSON(w, http.StatusOK, envelope{schema.FilterItems(items, category)})
+}
+
+// menuItemsGrouped writes a venue's items that match category, grouped under
+// each of its menus.
+func menuItemsGrouped(w http.ResponseWriter, db data.Database, venueID string, category schema.Category) {
+	if venueID == "" {
+		writeError(w, http.StatusUnprocessableEntity, schema.RequiredFieldError("venue_id"))
+		return
+	}
+	id, err := strconv.Atoi(venueID)
+	if err != nil {
+		writeError(w, http.StatusUnprocessableEntity, err)
+		return
+	}
+	if _, err := db.VenueGet(schema.Venue{ID: id}); err == data.ErrNotFound {
+		writeError(w, http.StatusNotFound, err)
+		return
+	} else if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	menus, err := db.MenusByVenue(id)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	groups := []menuItems{}
+	byMenu := make(map[int]int)
+	var ids []int
+	for _, m := range menus {
+		byMenu[m.ID] = len(groups)
+		groups = append(groups, menuItems{Menu: m, Items: []schema.MenuItem{}})
+		ids = append(ids, m.ID)
+	}
+	items, err := db.MenuItemsByMenus(ids)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	for _, item := range items {
+		if !category.Matches(item.Category) {
+			continue
+		}
+		i := byMenu[item.MenuID]
+		groups[i].Items = append(groups[i].Items, item)
+	}
+
+	type envelope struct {
+		Data []menuItems `json:"data"`
+	}
+	writeJSON(w, http.StatusOK, envelope{groups})
+}
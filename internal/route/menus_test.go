@@ -0,0 +1,205 @@
+package route
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/kernkw/hhapp/internal/data"
+	"github.com/kernkw/hhapp/internal/data/datamock"
+	"github.com/kernkw/hhapp/internal/schema"
+	"github.com/kernkw/hhapp/internal/schema/schematest"
+)
+
+// menuStore serves venue 5 with a happy hour menu (7) and a late night
+// menu (8), each with one item.
+func menuStore() *datamock.Mock {
+	menus := []schema.Menu{
+		{ID: 7, VenueID: 5, Name: "Happy Hour", Type: schema.MenuHappyHour},
+		{ID: 8, VenueID: 5, Name: "Late Night", Type: schema.MenuLateNight},
+	}
+	return &datamock.Mock{
+		VenueGet_: func(v schema.Venue) (schema.Venue, error) {
+			if v.ID != 5 {
+				return schema.Venue{}, data.ErrNotFound
+			}
+			return testVenue(v.ID), nil
+		},
+		MenuGet_: func(id int) (schema.Menu, error) {
+			for _, m := range menus {
+				if m.ID == id {
+					return m, nil
+				}
+			}
+			return schema.Menu{}, data.ErrNotFound
+		},
+		MenusByVenue_: func(venueID int) ([]schema.Menu, error) {
+			if venueID != 5 {
+				return nil, nil
+			}
+			return menus, nil
+		},
+		MenuDateTimesGet_: func(menuID int) ([]schema.MenuDateTime, error) {
+			if menuID != 7 {
+				return nil, nil
+			}
+			return []schema.MenuDateTime{{ID: 1, MenuID: 7, Friday: true, StartAt: 15 * 60 * 60, EndAt: 18 * 60 * 60}}, nil
+		},
+		MenuExceptionsGet_: func(menuID int) ([]schema.MenuException, error) {
+			if menuID != 7 {
+				return nil, nil
+			}
+			christmas := schema.Date{Year: 2018, Month: time.December, Day: 25}
+			return []schema.MenuException{{ID: 2, MenuID: 7, Kind: schema.ExceptionBlackout, StartDate: christmas, EndDate: christmas, Note: "Closed"}}, nil
+		},
+		MenuItemsByMenus_: func(ids []int) ([]schema.MenuItem, error) {
+			var items []schema.MenuItem
+			for _, id := range ids {
+				items = append(items, schema.MenuItem{ID: id * 10, MenuID: id, Category: "Drink", Price: schematest.USD(500), Description: "Well drinks"})
+			}
+			return items, nil
+		},
+	}
+}
+
+func TestMenusGet(t *testing.T) {
+	rr := serve(menuStore(), "GET", "/venues/5/menus", "", schema.User{})
+
+	expected := `{"data":[` +
+		`{"id":7,"venue_id":5,"name":"Happy Hour","type":"happy_hour","schedule":[{"id":1,"menu_id":7,"monday":false,"tuesday":false,"wednesday":false,"thursday":false,"friday":true,"saturday":false,"sunday":false,"start_at":"15:00","end_at":"18:00","overnight":false}],` +
+		`"exceptions":[{"id":2,"menu_id":7,"kind":"blackout","start_date":"2018-12-25","end_date":"2018-12-25","start_at":"00:00","end_at":"00:00","overnight":false,"note":"Closed"}]},` +
+		`{"id":8,"venue_id":5,"name":"Late Night","type":"late_night","schedule":[],"exceptions":[]}]}`
+	if rr.Code != http.StatusOK || rr.Body.String() != expected {
+		t.Errorf("got %v %v want %v %v", rr.Code, rr.Body.String(), http.StatusOK, expected)
+	}
+	if rr := serve(menuStore(), "GET", "/venues/6/menus", "", schema.User{}); rr.Code != http.StatusNotFound {
+		t.Errorf("unknown venue: got status %v want %v", rr.Code, http.StatusNotFound)
+	}
+}
+
+func TestMenuCreate(t *testing.T) {
+	tests := []struct {
+		path string
+		body string
+		user schema.User
+		want int
+	}{
+		{"/venues/5/menus", `{"name":"Brunch","type":"brunch"}`, testOwner, http.StatusCreated},
+		{"/venues/5/menus", `{"name":"Specials"}`, testOwner, http.StatusCreated},
+		{"/venues/5/menus", `{"name":"Late Night","type":"late_night"}`, testOwner, http.StatusConflict},
+		{"/venues/5/menus", `{"name":"Dinner","type":"other"}`, testOwner, http.StatusInternalServerError},
+		{"/venues/5/menus", `{"name":"Brunch","type":"breakfast"}`, testOwner, http.StatusUnprocessableEntity},
+		{"/venues/5/menus", `{"name":" ","type":"brunch"}`, testOwner, http.StatusUnprocessableEntity},
+		{"/venues/5/menus", `{"name":"Brunch","type":"brunch"}`, schema.User{ID: 43, Roles: []schema.Role{schema.RoleVenueOwner}}, http.StatusForbidden},
+		{"/venues/6/menus", `{"name":"Brunch","type":"brunch"}`, testOwner, http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		var created schema.Menu
+		var by int
+		db := menuStore()
+		db.CreateMenu_ = func(m schema.Menu, userID int) (int, error) {
+			switch m.Name {
+			case "Late Night":
+				return 0, data.ErrDuplicateEntry
+			case "Dinner":
+				return 0, errors.New("Maximum number of retries exceeded: driver: bad connection")
+			}
+			created, by = m, userID
+			return 9, nil
+		}
+		rr := serve(db, "POST", tt.path, tt.body, tt.user)
+		if rr.Code != tt.want {
+			t.Errorf("POST %s %s: got status %v want %v: %s", tt.path, tt.body, rr.Code, tt.want, rr.Body.String())
+			continue
+		}
+		if tt.want == http.StatusCreated && (created.VenueID != 5 || created.Type == "" || by != tt.user.ID) {
+			t.Errorf("POST %s: created %+v by %d", tt.body, created, by)
+		}
+	}
+}
+
+func TestMenuUpdate(t *testing.T) {
+	var updated schema.Menu
+	var by int
+	db := menuStore()
+	db.UpdateMenu_ = func(m schema.Menu, userID int) error {
+		switch m.Name {
+		case "Happy Hour":
+			return data.ErrDuplicateEntry
+		case "Dinner":
+			return errors.New("Maximum number of retries exceeded: driver: bad connection")
+		}
+		updated, by = m, userID
+		return nil
+	}
+	rr := serve(db, "PATCH", "/menus/8", `{"name":"After Hours"}`, testOwner)
+
+	expected := `{"data":{"id":8,"venue_id":5,"name":"After Hours","type":"late_night"}}`
+	if rr.Code != http.StatusOK || rr.Body.String() != expected {
+		t.Errorf("got %v %v want %v %v", rr.Code, rr.Body.String(), http.StatusOK, expected)
+	}
+	if updated.Name != "After Hours" || updated.Type != schema.MenuLateNight || by != testOwner.ID {
+		t.Errorf("stored %+v by %d", updated, by)
+	}
+
+	if rr := serve(db, "PATCH", "/menus/8", `{"name":"Happy Hour"}`, testOwner); rr.Code != http.StatusConflict {
+		t.Errorf("duplicate name: got status %v want %v", rr.Code, http.StatusConflict)
+	}
+	if rr := serve(db, "PATCH", "/menus/8", `{"name":"Dinner"}`, testOwner); rr.Code != http.StatusInternalServerError {
+		t.Errorf("store failure: got status %v want %v", rr.Code, http.StatusInternalServerError)
+	}
+	if rr := serve(db, "PATCH", "/menus/8", `{"type":"dinner"}`, testOwner); rr.Code != http.StatusUnprocessableEntity {
+		t.Errorf("invalid type: got status %v want %v", rr.Code, http.StatusUnprocessableEntity)
+	}
+	if rr := serve(db, "PATCH", "/menus/9", `{"name":"After Hours"}`, testOwner); rr.Code != http.StatusNotFound {
+		t.Errorf("unknown menu: got status %v want %v", rr.Code, http.StatusNotFound)
+	}
+}
+
+func TestMenuDelete(t *testing.T) {
+	deleted := 0
+	db := menuStore()
+	db.DeleteMenu_ = func(id, userID int) error {
+		deleted = id
+		return nil
+	}
+	if rr := serve(db, "DELETE", "/menus/8", "", schema.User{ID: 43, Roles: []schema.Role{schema.RoleVenueOwner}}); rr.Code != http.StatusForbidden || deleted != 0 {
+		t.Errorf("another owner: got status %v want %v", rr.Code, http.StatusForbidden)
+	}
+	if rr := serve(db, "DELETE", "/menus/8", "", testOwner); rr.Code != http.StatusOK || deleted != 8 {
+		t.Errorf("got status %v, deleted %d", rr.Code, deleted)
+	}
+}
+
+func TestMenuItemsGet_by_menu(t *testing.T) {
+	rr := serve(menuStore(), "GET", "/menu_items?menu_id=8", "", schema.User{})
+
+	expected := `{"data":[{"id":80,"menu_id":8,"category":"Drink","price":{"amount":"5.00","currency":"USD"},"description":"Well drinks","position":0}]}`
+	if rr.Code != http.StatusOK || rr.Body.String() != expected {
+		t.Errorf("got %v %v want %v %v", rr.Code, rr.Body.String(), http.StatusOK, expected)
+	}
+	if rr := serve(menuStore(), "GET", "/menu_items?menu_id=9", "", schema.User{}); rr.Code != http.StatusNotFound {
+		t.Errorf("unknown menu: got status %v want %v", rr.Code, http.StatusNotFound)
+	}
+}
+
+func TestMenuItemsGet_grouped(t *testing.T) {
+	rr := serve(menuStore(), "GET", "/menu_items?venue_id=5&group=menu", "", schema.User{})
+
+	expected := `{"data":[` +
+		`{"menu":{"id":7,"venue_id":5,"name":"Happy Hour","type":"happy_hour"},"items":[{"id":70,"menu_id":7,"category":"Drink","price":{"amount":"5.00","currency":"USD"},"description":"Well drinks","position":0}]},` +
+		`{"menu":{"id":8,"venue_id":5,"name":"Late Night","type":"late_night"},"items":[{"id":80,"menu_id":8,"category":"Drink","price":{"amount":"5.00","currency":"USD"},"description":"Well drinks","position":0}]}]}`
+	if rr.Code != http.StatusOK || rr.Body.String() != expected {
+		t.Errorf("got %v %v want %v %v", rr.Code, rr.Body.String(), http.StatusOK, expected)
+	}
+
+	if rr := serve(menuStore(), "GET", "/menu_items?venue_id=6&group=menu", "", schema.User{}); rr.Code != http.StatusNotFound {
+		t.Errorf("unknown venue: got status %v want %v", rr.Code, http.StatusNotFound)
+	}
+	rr = serve(menuStore(), "GET", "/menu_items?group=menu", "", schema.User{})
+	expected = `{"status":"venue_id is a required field."}`
+	if rr.Code != http.StatusUnprocessableEntity || rr.Body.String() != expected {
+		t.Errorf("no venue: got %v %v want %v %v", rr.Code, rr.Body.String(), http.StatusUnprocessableEntity, expected)
+	}
+}
@@ -0,0 +1,59 @@
+package route
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/kernkw/hhapp/internal/auth"
+	"github.com/kernkw/hhapp/internal/data"
+	"github.com/kernkw/hhapp/internal/schema"
+)
+
+var ErrEmailNotVerified = errors.New("email address has not been verified")
+
+/*
+Test with this curl command:
+curl -H "Content-Type: application/json" -H "Authorization: Bearer $TOKEN" -d '{"notification_id": 1}' http://localhost:8080/user_notifications
+*/
+func UserNotificationCreate(db data.Database) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		u, ok := auth.FromContext(r.Context())
+		if !ok {
+			writeError(w, http.StatusUnauthorized, nil)
+			return
+		}
+		var un schema.UserNotifications
+		if err := json.NewDecoder(r.Body).Decode(&un); err != nil {
+			writeError(w, http.StatusUnprocessableEntity, err)
+			return
+		}
+		defer r.Body.Close()
+
+		// Notifications are delivered by email, so only addresses the user
+		// has proven they own may be subscribed.
+		user, err := db.GetUserByID(u.ID)
+		if err != nil {
+			writeError(w, http.StatusUnauthorized, err)
+			return
+		}
+		if !user.EmailVerified {
+			writeError(w, http.StatusForbidden, ErrEmailNotVerified)
+			return
+		}
+		un.UserID = user.ID
+		un.Email = user.Email
+
+		id, err := db.CreateUserNotification(un)
+		if err != nil {
+			writeError(w, http.StatusConflict, err)
+			return
+		}
+
+		type envelope struct {
+			Status string `json:"status"`
+			Result int    `json:"result"`
+		}
+		writeJSON(w, http.StatusCreated, envelope{http.StatusText(http.StatusCreated), id})
+	})
+}
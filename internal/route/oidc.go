@@ -0,0 +1,250 @@
+package route
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/kernkw/hhapp/internal/auth"
+	"github.com/kernkw/hhapp/internal/config"
+	"github.com/kernkw/hhapp/internal/data"
+	"github.com/kernkw/hhapp/internal/oidc"
+	"github.com/kernkw/hhapp/internal/schema"
+)
+
+const (
+	oidcStateCookie = "hhapp_oidc_state"
+	// oidcStateTTL bounds how long the user may spend at the provider.
+	oidcStateTTL = 10 * time.Minute
+)
+
+var (
+	ErrStateMismatch   = errors.New("login state does not match this browser")
+	ErrIdentityInUse   = errors.New("identity is linked to another account")
+	ErrProviderRefused = errors.New("identity provider did not authorize the login")
+)
+
+// startOIDC binds a random state to the browser with a cookie and returns
+// the provider URL to send the user to. The cookie also carries a signed
+// token for user, the zero value for a plain login and the signed in user
+// when linking.
+func startOIDC(w http.ResponseWriter, r *http.Request, p *oidc.Provider, tokens *auth.Signer, cfg *config.Config, user schema.User) (string, error) {
+	state, _, err := auth.NewOpaqueToken()
+	if err != nil {
+		return "", err
+	}
+	signed, _, err := tokens.SignPurpose(user, auth.PurposeOIDCState, oidcStateTTL)
+	if err != nil {
+		return "", err
+	}
+	// The nonce is derived from the state so the ID token is tied to this
+	// exact flow without storing anything server side.
+	u, err := p.AuthCodeURL(r.Context(), state, auth.HashToken(state))
+	if err != nil {
+		return "", err
+	}
+	http.SetCookie(w, &http.Cookie{
+		Name:     oidcStateCookie,
+		Value:    state + "." + signed,
+		Path:     "/oidc",
+		MaxAge:   int(oidcStateTTL / time.Second),
+		HttpOnly: true,
+		Secure:   strings.HasPrefix(cfg.PublicURL, "https://"),
+	})
+	return u, nil
+}
+
+/*
+Test in a browser:
+http://localhost:8080/oidc/login
+*/
+func OIDCLogin(p *oidc.Provider, tokens *auth.Signer, cfg *config.Config) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		u, err := startOIDC(w, r, p, tokens, cfg, schema.User{})
+		if err != nil {
+			writeError(w, http.StatusBadGateway, err)
+			return
+		}
+		http.Redirect(w, r, u, http.StatusFound)
+	})
+}
+
+/*
+Test with this curl command:
+curl -X POST -H "Authorization: Bearer $TOKEN" http://localhost:8080/oidc/link
+*/
+func OIDCLink(p *oidc.Provider, tokens *auth.Signer, cfg *config.Config) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, ok := auth.FromContext(r.Context())
+		if !ok {
+			writeError(w, http.StatusUnauthorized, nil)
+			return
+		}
+		u, err := startOIDC(w, r, p, tokens, cfg, user)
+		if err != nil {
+			writeError(w, http.StatusBadGateway, err)
+			return
+		}
+
+		type envelope struct {
+			URL string `json:"url"`
+		}
+		writeJSON(w, http.StatusOK, envelope{u})
+	})
+}
+
+/*
+The provider redirects the browser here:
+http://localhost:8080/oidc/callback?code=...&state=...
+*/
+func OIDCCallback(db data.Database, p *oidc.Provider, tokens *auth.Signer) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("error") != "" {
+			writeError(w, http.StatusUnauthorized, ErrProviderRefused)
+			return
+		}
+		state := q.Get("state")
+		cookie, err := r.Cookie(oidcStateCookie)
+		if err != nil {
+			writeError(w, http.StatusUnauthorized, ErrStateMismatch)
+			return
+		}
+		parts := strings.SplitN(cookie.Value, ".", 2)
+		if len(parts) != 2 || state == "" || parts[0] != state {
+			writeError(w, http.StatusUnauthorized, ErrStateMismatch)
+			return
+		}
+		http.SetCookie(w, &http.Cookie{Name: oidcStateCookie, Path: "/oidc", MaxAge: -1})
+		st, err := tokens.VerifyPurpose(parts[1], auth.PurposeOIDCState)
+		if err != nil {
+			writeError(w, http.StatusUnauthorized, err)
+			return
+		}
+
+		raw, err := p.Exchange(r.Context(), q.Get("code"))
+		if err != nil {
+			writeError(w, http.StatusUnauthorized, err)
+			return
+		}
+		claims, err := p.Verify(r.Context(), raw, auth.HashToken(state))
+		if err != nil {
+			writeError(w, http.StatusUnauthorized, err)
+			return
+		}
+
+		identity, err := db.IdentityGet(p.Name, claims.Subject)
+		switch {
+		case err == data.ErrNotFound:
+			identity, err = linkIdentity(db, p.Name, claims, st.UserID)
+			if err == data.ErrDuplicateEntry {
+				writeError(w, http.StatusConflict, ErrIdentityInUse)
+				return
+			}
+			if err != nil {
+				writeError(w, http.StatusInternalServerError, err)
+				return
+			}
+		case err != nil:
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		case st.UserID != 0 && identity.UserID != st.UserID:
+			writeError(w, http.StatusConflict, ErrIdentityInUse)
+			return
+		}
+
+		if st.UserID != 0 {
+			type envelope struct {
+				Status string          `json:"status"`
+				Data   schema.Identity `json:"data"`
+			}
+			writeJSON(w, http.StatusOK, envelope{http.StatusText(http.StatusOK), identity})
+			return
+		}
+		user, err := db.GetUserByID(identity.UserID)
+		if err != nil {
+			writeError(w, http.StatusUnauthorized, err)
+			return
+		}
+		if user.TwoFactorEnabled {
+			writeTwoFactorChallenge(w, tokens, user)
+			return
+		}
+		writeSession(w, db, tokens, user)
+	})
+}
+
+// linkIdentity links a provider account seen for the first time. It goes to
+// userID when the flow was started signed in, otherwise to the existing
+// account with the same email if both sides have verified it, otherwise to
+// a newly created account.
+func linkIdentity(db data.Database, provider string, claims oidc.Claims, userID int) (schema.Identity, error) {
+	identity := schema.Identity{
+		UserID:    userID,
+		Provider:  provider,
+		Subject:   claims.Subject,
+		Email:     claims.Email,
+		CreatedAt: time.Now().UTC(),
+	}
+	if identity.UserID == 0 && claims.Email != "" && claims.EmailVerified {
+		user, err := db.GetUserByEmail(claims.Email)
+		if err != nil && err != data.ErrNotFound {
+			return identity, err
+		}
+		// An unverified local address proves nothing about who owns it,
+		// so linking to it would hand the account to the provider user.
+		if err == nil && user.EmailVerified {
+			identity.UserID = user.ID
+		}
+	}
+	if identity.UserID == 0 {
+		id, err := createOIDCUser(db, provider, claims)
+		if err != nil {
+			return identity, err
+		}
+		identity.UserID = id
+	}
+	id, err := db.CreateIdentity(identity)
+	identity.ID = id
+	return identity, err
+}
+
+// createOIDCUser creates an account without a password for a provider user.
+// They can set one later through the password reset flow.
+func createOIDCUser(db data.Database, provider string, claims oidc.Claims) (int, error) {
+	name := claims.PreferredUsername
+	if name == "" && claims.Email != "" {
+		name = strings.SplitN(claims.Email, "@", 2)[0]
+	}
+	if name == "" {
+		name = provider + "-" + claims.Subject
+	}
+	if len(name) > 48 {
+		name = name[:48]
+	}
+	user := schema.User{
+		UserName:  name,
+		Email:     claims.Email,
+		FirstName: claims.GivenName,
+		LastName:  claims.FamilyName,
+	}
+	id, err := db.CreateUser(user)
+	if err == data.ErrDuplicateEntry {
+		_, suffix, terr := auth.NewOpaqueToken()
+		if terr != nil {
+			return 0, terr
+		}
+		user.UserName = name + "-" + suffix[:6]
+		id, err = db.CreateUser(user)
+	}
+	if err != nil {
+		return 0, err
+	}
+	if claims.Email != "" && claims.EmailVerified {
+		if err := db.VerifyUserEmail(id, claims.Email); err != nil {
+			return 0, err
+		}
+	}
+	return id, nil
+}
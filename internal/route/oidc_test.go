@@ -0,0 +1,227 @@
+package route
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kernkw/hhapp/internal/auth"
+	"github.com/kernkw/hhapp/internal/config"
+	"github.com/kernkw/hhapp/internal/data"
+	"github.com/kernkw/hhapp/internal/data/datamock"
+	"github.com/kernkw/hhapp/internal/oidc"
+	"github.com/kernkw/hhapp/internal/oidc/oidctest"
+	"github.com/kernkw/hhapp/internal/schema"
+)
+
+func newTestProvider(t *testing.T) (*oidc.Provider, *oidctest.Issuer, *config.Config) {
+	iss, err := oidctest.NewIssuer("client", "secret")
+	checkError(err, t)
+	cfg := &config.Config{
+		OIDCProvider:     "test",
+		OIDCIssuer:       iss.URL,
+		OIDCClientID:     "client",
+		OIDCClientSecret: "secret",
+		OIDCScopes:       []string{"openid"},
+		PublicURL:        "http://hhapp.test",
+	}
+	return oidc.NewProvider(cfg), iss, cfg
+}
+
+// startLogin runs /oidc/login and returns the state cookie it set.
+func startLogin(t *testing.T, handler http.HandlerFunc, user *schema.User) *http.Cookie {
+	req, err := http.NewRequest("GET", "/oidc/login", nil)
+	checkError(err, t)
+	if user != nil {
+		req = withUser(req, *user)
+	}
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	cookies := rr.Result().Cookies()
+	if len(cookies) != 1 || cookies[0].Name != oidcStateCookie {
+		t.Fatalf("expected a state cookie, got %v %v", rr.Code, cookies)
+	}
+	return cookies[0]
+}
+
+// stateOf returns the state parameter bound to a state cookie.
+func stateOf(c *http.Cookie) string {
+	return strings.SplitN(c.Value, ".", 2)[0]
+}
+
+func callback(t *testing.T, handler http.HandlerFunc, code string, state *http.Cookie) *httptest.ResponseRecorder {
+	q := url.Values{"code": {code}, "state": {stateOf(state)}}
+	req, err := http.NewRequest("GET", "/oidc/callback?"+q.Encode(), nil)
+	checkError(err, t)
+	req.AddCookie(state)
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	return rr
+}
+
+func TestOIDCLogin_new_user(t *testing.T) {
+	p, iss, cfg := newTestProvider(t)
+	defer iss.Close()
+	tokens := auth.NewSigner("secret", time.Minute, time.Hour)
+
+	var created schema.User
+	var linked schema.Identity
+	var verified string
+	mockStore := &datamock.Mock{
+		IdentityGet_: func(provider, subject string) (schema.Identity, error) {
+			return schema.Identity{}, data.ErrNotFound
+		},
+		GetUserByEmail_: func(email string) (schema.User, error) {
+			return schema.User{}, data.ErrNotFound
+		},
+		CreateUser_: func(user schema.User) (int, error) {
+			created = user
+			return 7, nil
+		},
+		VerifyUserEmail_: func(id int, email string) error {
+			verified = email
+			return nil
+		},
+		CreateIdentity_: func(identity schema.Identity) (int, error) {
+			linked = identity
+			return 1, nil
+		},
+		GetUserByID_: func(id int) (schema.User, error) {
+			return schema.User{ID: id, UserName: created.UserName}, nil
+		},
+		CreateRefreshToken_: func(rt schema.RefreshToken) (int, error) {
+			return 1, nil
+		},
+	}
+
+	state := startLogin(t, OIDCLogin(p, tokens, cfg), nil)
+	claims := iss.Claims("sub-1", auth.HashToken(stateOf(state)))
+	claims["email"] = "new@domain.com"
+	claims["email_verified"] = true
+	claims["given_name"] = "New"
+	iss.AddCode("code", claims)
+
+	rr := callback(t, OIDCCallback(mockStore, p, tokens), "code", state)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("handler returned wrong status code: got %v want %v: %v", rr.Code, http.StatusOK, rr.Body.String())
+	}
+	if created.UserName != "new" || created.FirstName != "New" || created.Password != "" {
+		t.Errorf("unexpected user created %+v", created)
+	}
+	if verified != "new@domain.com" {
+		t.Errorf("provider verified email was not marked verified")
+	}
+	if linked.UserID != 7 || linked.Provider != "test" || linked.Subject != "sub-1" {
+		t.Errorf("unexpected identity linked %+v", linked)
+	}
+	var resp struct {
+		Token string `json:"token"`
+	}
+	checkError(json.Unmarshal(rr.Body.Bytes(), &resp), t)
+	c, err := tokens.Verify(resp.Token)
+	checkError(err, t)
+	if c.UserID != 7 {
+		t.Errorf("token issued for wrong user: got %v want %v", c.UserID, 7)
+	}
+}
+
+func TestOIDCLogin_unverified_local_email(t *testing.T) {
+	p, iss, cfg := newTestProvider(t)
+	defer iss.Close()
+	tokens := auth.NewSigner("secret", time.Minute, time.Hour)
+
+	var linked schema.Identity
+	mockStore := &datamock.Mock{
+		IdentityGet_: func(provider, subject string) (schema.Identity, error) {
+			return schema.Identity{}, data.ErrNotFound
+		},
+		GetUserByEmail_: func(email string) (schema.User, error) {
+			return schema.User{ID: 3, Email: email}, nil
+		},
+		CreateUser_: func(user schema.User) (int, error) {
+			return 8, nil
+		},
+		VerifyUserEmail_: func(id int, email string) error {
+			return nil
+		},
+		CreateIdentity_: func(identity schema.Identity) (int, error) {
+			linked = identity
+			return 1, nil
+		},
+		GetUserByID_: func(id int) (schema.User, error) {
+			return schema.User{ID: id}, nil
+		},
+		CreateRefreshToken_: func(rt schema.RefreshToken) (int, error) {
+			return 1, nil
+		},
+	}
+
+	state := startLogin(t, OIDCLogin(p, tokens, cfg), nil)
+	claims := iss.Claims("sub-1", auth.HashToken(stateOf(state)))
+	claims["email"] = "taken@domain.com"
+	claims["email_verified"] = true
+	iss.AddCode("code", claims)
+
+	rr := callback(t, OIDCCallback(mockStore, p, tokens), "code", state)
+	if rr.Code != http.StatusOK || linked.UserID != 8 {
+		t.Errorf("got %v linked to %v, want a new account rather than the unverified one", rr.Code, linked.UserID)
+	}
+}
+
+func TestOIDCLink(t *testing.T) {
+	p, iss, cfg := newTestProvider(t)
+	defer iss.Close()
+	tokens := auth.NewSigner("secret", time.Minute, time.Hour)
+
+	var linked schema.Identity
+	mockStore := &datamock.Mock{
+		IdentityGet_: func(provider, subject string) (schema.Identity, error) {
+			if subject == "other" {
+				return schema.Identity{UserID: 99}, nil
+			}
+			return schema.Identity{}, data.ErrNotFound
+		},
+		CreateIdentity_: func(identity schema.Identity) (int, error) {
+			linked = identity
+			return 1, nil
+		},
+	}
+
+	user := schema.User{ID: 42, UserName: "test"}
+	state := startLogin(t, OIDCLink(p, tokens, cfg), &user)
+	iss.AddCode("mine", iss.Claims("sub-1", auth.HashToken(stateOf(state))))
+	iss.AddCode("other", iss.Claims("other", auth.HashToken(stateOf(state))))
+
+	rr := callback(t, OIDCCallback(mockStore, p, tokens), "mine", state)
+	if rr.Code != http.StatusOK || linked.UserID != 42 {
+		t.Errorf("got %v linked to %v, want %v", rr.Code, linked.UserID, 42)
+	}
+	rr = callback(t, OIDCCallback(mockStore, p, tokens), "other", state)
+	if rr.Code != http.StatusConflict {
+		t.Errorf("handler returned wrong status code: got %v want %v", rr.Code, http.StatusConflict)
+	}
+}
+
+func TestOIDCCallback_state_mismatch(t *testing.T) {
+	p, iss, cfg := newTestProvider(t)
+	defer iss.Close()
+	tokens := auth.NewSigner("secret", time.Minute, time.Hour)
+
+	state := startLogin(t, OIDCLogin(p, tokens, cfg), nil)
+	iss.AddCode("code", iss.Claims("sub-1", auth.HashToken(stateOf(state))))
+	other := startLogin(t, OIDCLogin(p, tokens, cfg), nil)
+
+	q := url.Values{"code": {"code"}, "state": {stateOf(state)}}
+	req, err := http.NewRequest("GET", "/oidc/callback?"+q.Encode(), nil)
+	checkError(err, t)
+	req.AddCookie(other)
+	rr := httptest.NewRecorder()
+	OIDCCallback(&datamock.Mock{}, p, tokens).ServeHTTP(rr, req)
+	if rr.Code != http.StatusUnauthorized {
+		t.Errorf("handler returned wrong status code: got %v want %v", rr.Code, http.StatusUnauthorized)
+	}
+}
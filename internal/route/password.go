@@ -0,0 +1,191 @@
+package route
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/kernkw/hhapp/internal/auth"
+	"github.com/kernkw/hhapp/internal/config"
+	"github.com/kernkw/hhapp/internal/data"
+	"github.com/kernkw/hhapp/internal/mail"
+	"github.com/kernkw/hhapp/internal/schema"
+	"github.com/kernkw/hhapp/internal/throttle"
+)
+
+var (
+	ErrInvalidResetToken       = errors.New("password reset token is invalid or has expired")
+	ErrPasswordForgotThrottled = errors.New("too many password reset requests, try again later")
+)
+
+// passwordPolicy returns the rules new passwords are held to.
+func passwordPolicy(cfg *config.Config) schema.PasswordPolicy {
+	return schema.PasswordPolicy{
+		MinLength:    cfg.PasswordMinLength,
+		RejectCommon: cfg.PasswordRejectCommon,
+		Cost:         cfg.BcryptCost,
+	}
+}
+
+/*
+Test with this curl command:
+curl -H "Content-Type: application/json" -d '{"email":"kyle.kern@sendgrid.com"}' http://localhost:8080/password/forgot
+*/
+func PasswordForgot(db data.Database, m mail.Mailer, guard *throttle.Guard, cfg *config.Config) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Email string `json:"email"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, http.StatusUnprocessableEntity, err)
+			return
+		}
+		defer r.Body.Close()
+		if req.Email == "" {
+			writeError(w, http.StatusUnprocessableEntity, schema.RequiredFieldError("email"))
+			return
+		}
+		// Every request counts against the address and the client, whether
+		// or not the address has an account, so the endpoint can't be used
+		// to flood an inbox.
+		ip := clientIP(r, cfg.TrustProxyHeaders)
+		if wait, err := guard.Allow(req.Email, ip); err != nil {
+			writeRetryAfter(w, wait, ErrPasswordForgotThrottled)
+			return
+		}
+		guard.Fail(req.Email, ip)
+
+		type envelope struct {
+			Status string `json:"status"`
+		}
+		// The response is the same whether or not the address belongs to
+		// an account so the endpoint can't be used to discover users.
+		accepted := envelope{http.StatusText(http.StatusAccepted)}
+
+		user, err := db.GetUserByEmail(req.Email)
+		if err == data.ErrNotFound {
+			writeJSON(w, http.StatusAccepted, accepted)
+			return
+		}
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		token, hash, err := auth.NewOpaqueToken()
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		pr := schema.PasswordReset{
+			UserID:    user.ID,
+			TokenHash: hash,
+			ExpiresAt: time.Now().UTC().Add(cfg.PasswordResetTTL),
+		}
+		if _, err := db.CreatePasswordReset(pr); err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		link, err := url.Parse(cfg.PasswordResetURL)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		q := link.Query()
+		q.Set("token", token)
+		link.RawQuery = q.Encode()
+		msg := mail.Message{
+			To:      user.Email,
+			Subject: "Reset your password",
+			Body: fmt.Sprintf("Hi %s,\n\nUse the link below to choose a new password. It expires in %s.\n\n%s\n\nIf you didn't ask to reset your password you can ignore this email.\n",
+				user.UserName, cfg.PasswordResetTTL, link.String()),
+		}
+		if err := m.Send(msg); err != nil {
+			// Answer as for any other address; a mail outage must not
+			// reveal which ones have accounts.
+			log.Println("failed to send password reset email:", err)
+		}
+
+		writeJSON(w, http.StatusAccepted, accepted)
+	})
+}
+
+/*
+Test with this curl command:
+curl -H "Content-Type: application/json" -d '{"token":"...", "password": "new-horse-battery"}' http://localhost:8080/password/reset
+*/
+func PasswordReset(db data.Database, cfg *config.Config) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Token    string `json:"token"`
+			Password string `json:"password"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, http.StatusUnprocessableEntity, err)
+			return
+		}
+		defer r.Body.Close()
+		// Everything but the username and email rules can be checked
+		// before the token is looked up.
+		policy := passwordPolicy(cfg)
+		if err := policy.CheckField("password", req.Password, schema.User{}); err != nil {
+			writeError(w, http.StatusUnprocessableEntity, err)
+			return
+		}
+
+		hash := auth.HashToken(req.Token)
+		pr, err := db.GetPasswordReset(hash)
+		if err == data.ErrNotFound {
+			writeError(w, http.StatusUnprocessableEntity, ErrInvalidResetToken)
+			return
+		}
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		dbuser, err := db.GetUserByID(pr.UserID)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		if err := policy.CheckField("password", req.Password, dbuser); err != nil {
+			writeError(w, http.StatusUnprocessableEntity, err)
+			return
+		}
+
+		// Only a password that passed every rule spends the token. Using
+		// it fails if a concurrent reset got there first.
+		if _, err := db.UsePasswordReset(hash); err == data.ErrNotFound {
+			writeError(w, http.StatusUnprocessableEntity, ErrInvalidResetToken)
+			return
+		} else if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		user := schema.User{Password: req.Password}
+		if err := user.HashPassword(policy.Cost); err != nil {
+			writeError(w, http.StatusUnprocessableEntity, err)
+			return
+		}
+		if err := db.UpdateUserPassword(pr.UserID, user.Password); err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		// Whoever held the old password shouldn't keep a session.
+		if err := db.RevokeUserRefreshTokens(pr.UserID); err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		type envelope struct {
+			Status string `json:"status"`
+		}
+		writeJSON(w, http.StatusOK, envelope{http.StatusText(http.StatusOK)})
+	})
+}
@@ -0,0 +1,323 @@
+package route
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kernkw/hhapp/internal/auth"
+	"github.com/kernkw/hhapp/internal/config"
+	"github.com/kernkw/hhapp/internal/data"
+	"github.com/kernkw/hhapp/internal/data/datamock"
+	"github.com/kernkw/hhapp/internal/mail"
+	"github.com/kernkw/hhapp/internal/schema"
+	"github.com/kernkw/hhapp/internal/throttle"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func newOutbox(t *testing.T) (*mail.Outbox, func()) {
+	dir, err := ioutil.TempDir("", "outbox")
+	checkError(err, t)
+	outbox, err := mail.NewOutbox(dir)
+	checkError(err, t)
+	return outbox, func() { os.RemoveAll(dir) }
+}
+
+func TestPasswordForgot(t *testing.T) {
+	outbox, cleanup := newOutbox(t)
+	defer cleanup()
+
+	var created schema.PasswordReset
+	mockStore := &datamock.Mock{
+		GetUserByEmail_: func(email string) (schema.User, error) {
+			return schema.User{ID: 42, UserName: "test", Email: email}, nil
+		},
+		CreatePasswordReset_: func(pr schema.PasswordReset) (int, error) {
+			created = pr
+			return 1, nil
+		},
+	}
+	cfg := &config.Config{PasswordResetURL: "http://app.hhapp.test/reset?from=email", PasswordResetTTL: time.Hour}
+
+	req, err := http.NewRequest("POST", "/password/forgot", bytes.NewReader([]byte(`{"email":"test@domain.com"}`)))
+	checkError(err, t)
+
+	rr := httptest.NewRecorder()
+
+	http.HandlerFunc(PasswordForgot(mockStore, outbox, testForgotGuard(), cfg)).
+		ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusAccepted {
+		t.Errorf("handler returned wrong status code: got %v want %v",
+			status, http.StatusAccepted)
+	}
+
+	msgs, err := outbox.Messages()
+	checkError(err, t)
+	if len(msgs) != 1 {
+		t.Fatalf("expected 1 message, got %d", len(msgs))
+	}
+	if msgs[0].To != "test@domain.com" {
+		t.Errorf("message sent to wrong address: got %v", msgs[0].To)
+	}
+
+	prefix := "http://app.hhapp.test/reset?from=email&token="
+	i := strings.Index(msgs[0].Body, prefix)
+	if i < 0 {
+		t.Fatalf("message has no reset link: %q", msgs[0].Body)
+	}
+	token, err := url.QueryUnescape(strings.Fields(msgs[0].Body[i+len(prefix):])[0])
+	checkError(err, t)
+	if created.UserID != 42 || created.TokenHash != auth.HashToken(token) {
+		t.Errorf("stored reset does not match emailed token: %+v", created)
+	}
+}
+
+func testForgotGuard() *throttle.Guard {
+	return throttle.NewPasswordForgotGuard(&config.Config{
+		PasswordForgotMaxAttempts:      2,
+		PasswordForgotMaxAttemptsPerIP: 3,
+		PasswordForgotWindow:           time.Hour,
+	})
+}
+
+func TestPasswordForgot_throttle(t *testing.T) {
+	outbox, cleanup := newOutbox(t)
+	defer cleanup()
+
+	mockStore := &datamock.Mock{
+		GetUserByEmail_: func(email string) (schema.User, error) {
+			return schema.User{ID: 42, UserName: "test", Email: email}, nil
+		},
+		CreatePasswordReset_: func(pr schema.PasswordReset) (int, error) {
+			return 1, nil
+		},
+	}
+	handler := PasswordForgot(mockStore, outbox, testForgotGuard(), &config.Config{PasswordResetURL: "http://app.hhapp.test/reset"})
+
+	tests := []struct {
+		email, ip string
+		want      int
+	}{
+		{"test@domain.com", "192.0.2.1", http.StatusAccepted},
+		{"Test@Domain.com", "192.0.2.2", http.StatusAccepted},
+		// The address has had its two requests, from any client.
+		{"test@domain.com", "192.0.2.3", http.StatusTooManyRequests},
+		{"other@domain.com", "192.0.2.1", http.StatusAccepted},
+		{"more@domain.com", "192.0.2.1", http.StatusAccepted},
+		// The client has had its three.
+		{"last@domain.com", "192.0.2.1", http.StatusTooManyRequests},
+	}
+	for _, tt := range tests {
+		req, err := http.NewRequest("POST", "/password/forgot", strings.NewReader(fmt.Sprintf(`{"email":%q}`, tt.email)))
+		checkError(err, t)
+		req.RemoteAddr = tt.ip + ":1234"
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+
+		if rr.Code != tt.want {
+			t.Errorf("%s from %s: got status %v want %v", tt.email, tt.ip, rr.Code, tt.want)
+		}
+		if tt.want == http.StatusTooManyRequests && rr.Header().Get("Retry-After") != "3600" {
+			t.Errorf("%s from %s: Retry-After %q want %q", tt.email, tt.ip, rr.Header().Get("Retry-After"), "3600")
+		}
+	}
+	msgs, err := outbox.Messages()
+	checkError(err, t)
+	if len(msgs) != 4 {
+		t.Errorf("sent %d messages want 4", len(msgs))
+	}
+}
+
+func TestPasswordForgot_unknown_email(t *testing.T) {
+	outbox, cleanup := newOutbox(t)
+	defer cleanup()
+
+	mockStore := &datamock.Mock{
+		GetUserByEmail_: func(email string) (schema.User, error) {
+			return schema.User{}, data.ErrNotFound
+		},
+	}
+
+	req, err := http.NewRequest("POST", "/password/forgot", bytes.NewReader([]byte(`{"email":"nobody@domain.com"}`)))
+	checkError(err, t)
+
+	rr := httptest.NewRecorder()
+
+	http.HandlerFunc(PasswordForgot(mockStore, outbox, testForgotGuard(), &config.Config{})).
+		ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusAccepted {
+		t.Errorf("handler returned wrong status code: got %v want %v",
+			status, http.StatusAccepted)
+	}
+	msgs, err := outbox.Messages()
+	checkError(err, t)
+	if len(msgs) != 0 {
+		t.Errorf("expected no messages, got %d", len(msgs))
+	}
+}
+
+// failingMailer fails to send every message.
+type failingMailer struct{}
+
+func (failingMailer) Send(m mail.Message) error {
+	return errors.New("smtp: connection refused")
+}
+
+func TestPasswordForgot_send_failure(t *testing.T) {
+	mockStore := &datamock.Mock{
+		GetUserByEmail_: func(email string) (schema.User, error) {
+			return schema.User{ID: 42, UserName: "test", Email: email}, nil
+		},
+		CreatePasswordReset_: func(pr schema.PasswordReset) (int, error) {
+			return 1, nil
+		},
+	}
+
+	req, err := http.NewRequest("POST", "/password/forgot", bytes.NewReader([]byte(`{"email":"test@domain.com"}`)))
+	checkError(err, t)
+
+	rr := httptest.NewRecorder()
+
+	http.HandlerFunc(PasswordForgot(mockStore, failingMailer{}, testForgotGuard(), &config.Config{})).
+		ServeHTTP(rr, req)
+
+	// The same response as for an unknown address.
+	expected := `{"status":"Accepted"}`
+	if rr.Code != http.StatusAccepted || rr.Body.String() != expected {
+		t.Errorf("got %v %v want %v %v", rr.Code, rr.Body.String(), http.StatusAccepted, expected)
+	}
+}
+
+func TestPasswordReset(t *testing.T) {
+	var used, password string
+	var revoked int
+	mockStore := &datamock.Mock{
+		GetPasswordReset_: func(hash string) (schema.PasswordReset, error) {
+			return schema.PasswordReset{ID: 1, UserID: 42}, nil
+		},
+		UsePasswordReset_: func(hash string) (schema.PasswordReset, error) {
+			used = hash
+			return schema.PasswordReset{ID: 1, UserID: 42}, nil
+		},
+		GetUserByID_: func(id int) (schema.User, error) {
+			return schema.User{ID: id, UserName: "test", Email: "test@domain.com"}, nil
+		},
+		UpdateUserPassword_: func(userID int, p string) error {
+			password = p
+			return nil
+		},
+		RevokeUserRefreshTokens_: func(userID int) error {
+			revoked = userID
+			return nil
+		},
+	}
+
+	req, err := http.NewRequest("POST", "/password/reset", bytes.NewReader([]byte(`{"token":"abc","password":"new-password"}`)))
+	checkError(err, t)
+
+	rr := httptest.NewRecorder()
+
+	http.HandlerFunc(PasswordReset(mockStore, &config.Config{})).
+		ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusOK {
+		t.Fatalf("handler returned wrong status code: got %v want %v",
+			status, http.StatusOK)
+	}
+	if used != auth.HashToken("abc") {
+		t.Errorf("wrong token used: got %v", used)
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(password), []byte("new-password")); err != nil {
+		t.Errorf("stored password is not a hash of the new password: %v", err)
+	}
+	if revoked != 42 {
+		t.Errorf("sessions not revoked after reset")
+	}
+}
+
+func TestPasswordReset_invalid_token(t *testing.T) {
+	mockStore := &datamock.Mock{
+		GetPasswordReset_: func(hash string) (schema.PasswordReset, error) {
+			return schema.PasswordReset{}, data.ErrNotFound
+		},
+	}
+
+	req, err := http.NewRequest("POST", "/password/reset", bytes.NewReader([]byte(`{"token":"abc","password":"new-password"}`)))
+	checkError(err, t)
+
+	rr := httptest.NewRecorder()
+
+	http.HandlerFunc(PasswordReset(mockStore, &config.Config{})).
+		ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusUnprocessableEntity {
+		t.Errorf("handler returned wrong status code: got %v want %v",
+			status, http.StatusUnprocessableEntity)
+	}
+	expected := fmt.Sprintf(`{"status":"%s"}`, ErrInvalidResetToken)
+	if rr.Body.String() != expected {
+		t.Errorf("handler returned unexpected body: got %v want %v",
+			rr.Body.String(), expected)
+	}
+}
+
+func TestPasswordReset_policy(t *testing.T) {
+	mockStore := &datamock.Mock{}
+
+	req, err := http.NewRequest("POST", "/password/reset", bytes.NewReader([]byte(`{"token":"abc","password":"password"}`)))
+	checkError(err, t)
+
+	rr := httptest.NewRecorder()
+
+	// The mock has no GetPasswordReset, so reaching it would panic: a weak
+	// password must be rejected before the token is looked up.
+	http.HandlerFunc(PasswordReset(mockStore, &config.Config{PasswordMinLength: 10, PasswordRejectCommon: true})).
+		ServeHTTP(rr, req)
+
+	expected := `{"status":"password must be at least 10 characters.","errors":{"password":"password must be at least 10 characters."}}`
+	if rr.Code != http.StatusUnprocessableEntity || rr.Body.String() != expected {
+		t.Errorf("got %v %v want %v %v", rr.Code, rr.Body.String(), http.StatusUnprocessableEntity, expected)
+	}
+}
+
+func TestPasswordReset_user_policy(t *testing.T) {
+	used := false
+	mockStore := &datamock.Mock{
+		GetPasswordReset_: func(hash string) (schema.PasswordReset, error) {
+			return schema.PasswordReset{ID: 1, UserID: 42}, nil
+		},
+		GetUserByID_: func(id int) (schema.User, error) {
+			return schema.User{ID: id, UserName: "bartender42", Email: "test@domain.com"}, nil
+		},
+		UsePasswordReset_: func(hash string) (schema.PasswordReset, error) {
+			used = true
+			return schema.PasswordReset{ID: 1, UserID: 42}, nil
+		},
+	}
+
+	req, err := http.NewRequest("POST", "/password/reset", bytes.NewReader([]byte(`{"token":"abc","password":"Bartender42"}`)))
+	checkError(err, t)
+
+	rr := httptest.NewRecorder()
+
+	http.HandlerFunc(PasswordReset(mockStore, &config.Config{})).
+		ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusUnprocessableEntity {
+		t.Errorf("handler returned wrong status code: got %v want %v",
+			rr.Code, http.StatusUnprocessableEntity)
+	}
+	if used {
+		t.Error("a rejected password spent the reset token")
+	}
+}
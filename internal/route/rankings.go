@@ -0,0 +1,159 @@
+package route
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/kernkw/hhapp/internal/data"
+	"github.com/kernkw/hhapp/internal/schema"
+)
+
+var (
+	ErrRankingScope = errors.New("give exactly one of city or list_id")
+	ErrRankingBy    = errors.New("by must be savings or price")
+)
+
+// rankedVenue is a venue with the figure it was ranked by.
+type rankedVenue struct {
+	Venue          schema.Venue     `json:"venue"`
+	AverageSavings *float64         `json:"average_savings_percent,omitempty"`
+	Cheapest       *schema.MenuItem `json:"cheapest_item,omitempty"`
+}
+
+// cheaper orders prices by amount. Amounts in different currencies can't be
+// compared, so they are kept apart in currency order.
+func cheaper(a, b schema.Money) bool {
+	if a.Currency != b.Currency {
+		return a.Currency < b.Currency
+	}
+	return a.Amount < b.Amount
+}
+
+// rankingVenues returns the venues a ranking covers: those in a city or on
+// a venue list. When the scope is invalid an error is written and false
+// returned.
+func rankingVenues(w http.ResponseWriter, r *http.Request, db data.Database, category schema.Category) ([]schema.Venue, bool) {
+	q := r.URL.Query()
+	city, list := q.Get("city"), q.Get("list_id")
+	if (city == "") == (list == "") {
+		writeError(w, http.StatusUnprocessableEntity, ErrRankingScope)
+		return nil, false
+	}
+
+	var venues []schema.Venue
+	var err error
+	if city != "" {
+		venues, err = db.Venues(schema.VenueFilter{City: city, Category: category})
+	} else {
+		id, perr := strconv.Atoi(list)
+		if perr != nil {
+			writeError(w, http.StatusUnprocessableEntity, perr)
+			return nil, false
+		}
+		venues, err = db.VenuesByList(id)
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return nil, false
+	}
+	return venues, true
+}
+
+/*
+Test with this curl command:
+curl "http://localhost:8080/venues/ranked?city=Denver&by=savings"
+curl "http://localhost:8080/venues/ranked?list_id=1&by=price&category=drink"
+*/
+func VenuesRanked(db data.Database) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		by := q.Get("by")
+		if by == "" {
+			by = "savings"
+		}
+		if by != "savings" && by != "price" {
+			writeError(w, http.StatusUnprocessableEntity, ErrRankingBy)
+			return
+		}
+		category, err := categoryQuery(q)
+		if err != nil {
+			writeError(w, http.StatusUnprocessableEntity, err)
+			return
+		}
+		venues, ok := rankingVenues(w, r, db, category)
+		if !ok {
+			return
+		}
+
+		var venueIDs []int
+		for _, v := range venues {
+			venueIDs = append(venueIDs, v.ID)
+		}
+		menus, err := db.MenusByVenues(venueIDs)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		venueOf := make(map[int]int)
+		var menuIDs []int
+		for _, m := range menus {
+			venueOf[m.ID] = m.VenueID
+			menuIDs = append(menuIDs, m.ID)
+		}
+		items, err := db.MenuItemsByMenus(menuIDs)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		byVenue := make(map[int][]schema.MenuItem)
+		for _, item := range schema.FilterItems(items, category) {
+			v := venueOf[item.MenuID]
+			byVenue[v] = append(byVenue[v], item)
+		}
+
+		ranked := []rankedVenue{}
+		for _, v := range venues {
+			rv := rankedVenue{Venue: v}
+			if by == "savings" {
+				total, n := 0.0, 0
+				for _, item := range byVenue[v.ID] {
+					if s := item.Savings(); s != nil {
+						total += s.Percent
+						n++
+					}
+				}
+				if n == 0 {
+					continue
+				}
+				// Rounded to one decimal, like each item's percentage.
+				avg := math.Floor(total/float64(n)*10+0.5) / 10
+				rv.AverageSavings = &avg
+			} else {
+				for _, item := range byVenue[v.ID] {
+					if rv.Cheapest == nil || cheaper(item.Price, rv.Cheapest.Price) {
+						cheapest := item
+						rv.Cheapest = &cheapest
+					}
+				}
+				if rv.Cheapest == nil {
+					continue
+				}
+			}
+			ranked = append(ranked, rv)
+		}
+		sort.SliceStable(ranked, func(i, j int) bool {
+			if by == "savings" {
+				return *ranked[i].AverageSavings > *ranked[j].AverageSavings
+			}
+			return cheaper(ranked[i].Cheapest.Price, ranked[j].Cheapest.Price)
+		})
+
+		type envelope struct {
+			Data []rankedVenue `json:"data"`
+		}
+		writeJSON(w, http.StatusOK, envelope{ranked})
+	})
+}
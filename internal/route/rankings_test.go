@@ -0,0 +1,104 @@
+package route
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/kernkw/hhapp/internal/data/datamock"
+	"github.com/kernkw/hhapp/internal/schema"
+	"github.com/kernkw/hhapp/internal/schema/schematest"
+)
+
+// rankingStore serves three Denver venues, each with one menu whose id is
+// ten times the venue's.
+func rankingStore(filter *schema.VenueFilter) *datamock.Mock {
+	return &datamock.Mock{
+		Venues_: func(f schema.VenueFilter) ([]schema.Venue, error) {
+			*filter = f
+			return []schema.Venue{{ID: 1, Name: "One"}, {ID: 2, Name: "Two"}, {ID: 3, Name: "Three"}}, nil
+		},
+		VenuesByList_: func(id int) ([]schema.Venue, error) {
+			return []schema.Venue{{ID: 1, Name: "One"}, {ID: 2, Name: "Two"}}, nil
+		},
+		MenusByVenues_: func(ids []int) ([]schema.Menu, error) {
+			var menus []schema.Menu
+			for _, id := range ids {
+				menus = append(menus, schema.Menu{ID: id * 10, VenueID: id})
+			}
+			return menus, nil
+		},
+		MenuItemsByMenus_: func(ids []int) ([]schema.MenuItem, error) {
+			return []schema.MenuItem{
+				{ID: 1, MenuID: 10, Category: "drink", Price: schematest.USD(500), RegularPrice: schematest.USDPtr(600)},
+				{ID: 2, MenuID: 10, Category: "food", Price: schematest.USD(300), RegularPrice: schematest.USDPtr(600)},
+				{ID: 3, MenuID: 20, Category: "drink", Price: schematest.USD(400), RegularPrice: schematest.USDPtr(800)},
+				{ID: 4, MenuID: 20, Category: "food", Price: schematest.USD(700)},
+				{ID: 5, MenuID: 30, Category: "drink", Price: schematest.USD(200)},
+			}, nil
+		},
+	}
+}
+
+func rankedIDs(t *testing.T, db *datamock.Mock, query string) []int {
+	req, err := http.NewRequest("GET", "/venues/ranked?"+query, nil)
+	checkError(err, t)
+	rr := httptest.NewRecorder()
+	VenuesRanked(db).ServeHTTP(rr, req)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("%s: got status %v: %s", query, rr.Code, rr.Body.String())
+	}
+	var resp struct {
+		Data []rankedVenue `json:"data"`
+	}
+	checkError(json.Unmarshal(rr.Body.Bytes(), &resp), t)
+	ids := []int{}
+	for _, rv := range resp.Data {
+		ids = append(ids, rv.Venue.ID)
+	}
+	return ids
+}
+
+func TestVenuesRanked(t *testing.T) {
+	var filter schema.VenueFilter
+	db := rankingStore(&filter)
+	tests := []struct {
+		query string
+		want  []int
+	}{
+		// One averages 33.3% off (16.7 and 50), Two 50%; Three has no
+		// regular prices.
+		{"city=Denver&by=savings", []int{2, 1}},
+		{"city=Denver", []int{2, 1}},
+		{"city=Denver&by=savings&category=drink", []int{2, 1}},
+		{"city=Denver&by=savings&category=food", []int{1}},
+		{"city=Denver&by=price", []int{3, 1, 2}},
+		{"city=Denver&by=price&category=drink", []int{3, 2, 1}},
+		{"list_id=1&by=price&category=food", []int{1, 2}},
+	}
+	for _, tt := range tests {
+		if got := rankedIDs(t, db, tt.query); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: got %v want %v", tt.query, got, tt.want)
+		}
+	}
+
+	rankedIDs(t, db, "city=Boulder&category=Food")
+	if filter.City != "Boulder" || filter.Category != schema.CategoryFood {
+		t.Errorf("searched with filter %+v", filter)
+	}
+}
+
+func TestVenuesRanked_bad_query(t *testing.T) {
+	for _, q := range []string{"", "city=Denver&list_id=1", "city=Denver&by=distance", "list_id=x", "city=Denver&category=beer"} {
+		req, err := http.NewRequest("GET", "/venues/ranked?"+q, nil)
+		checkError(err, t)
+		rr := httptest.NewRecorder()
+		VenuesRanked(&datamock.Mock{}).ServeHTTP(rr, req)
+
+		if rr.Code != http.StatusUnprocessableEntity {
+			t.Errorf("%q: got status %v want %v", q, rr.Code, http.StatusUnprocessableEntity)
+		}
+	}
+}
@@ -4,21 +4,40 @@ import (
 	"net/http"
 
 	"github.com/gorilla/mux"
+	"github.com/kernkw/hhapp/internal/auth"
+	"github.com/kernkw/hhapp/internal/config"
 	"github.com/kernkw/hhapp/internal/data"
 	"github.com/kernkw/hhapp/internal/event"
+	"github.com/kernkw/hhapp/internal/geo"
+	"github.com/kernkw/hhapp/internal/mail"
 	"github.com/rs/cors"
 )
 
-func NewRouter(db *data.Store) *mux.Router {
+func NewRouter(db *data.Store, m mail.Mailer, g geo.Geocoder, zones *geo.TimeZones, cfg *config.Config) *mux.Router {
+	tokens := auth.NewSigner(cfg.TokenSecret, cfg.TokenTTL, cfg.RefreshTokenTTL)
+	return newRouter(db, cfg, tokens, m, g, zones)
+}
+
+// newRouter registers the routes of getRoutes, wrapping protected routes in
+// Authenticate and RequireRoles.
+func newRouter(db data.Database, cfg *config.Config, tokens *auth.Signer, m mail.Mailer, g geo.Geocoder, zones *geo.TimeZones) *mux.Router {
 
 	router := mux.NewRouter().StrictSlash(true)
-	routes := getRoutes(db)
+	routes := getRoutes(db, cfg, tokens, m, g, zones)
 	for _, route := range routes {
 		var handler http.Handler
 		c := cors.New(cors.Options{
-			AllowedMethods: []string{"GET", "POST", "HEAD", "DELETE", "PUT", "OPTION"},
+			AllowedMethods: []string{"GET", "POST", "HEAD", "DELETE", "PUT", "PATCH", "OPTION"},
+			AllowedHeaders: []string{"Origin", "Accept", "Content-Type", "Authorization", apiKeyHeader},
 		})
-		handler = c.Handler(route.HandlerFunc)
+		handler = route.HandlerFunc
+		if len(route.Roles) > 0 {
+			handler = RequireRoles(handler, route.Roles...)
+		}
+		if route.Protected || len(route.Roles) > 0 {
+			handler = Authenticate(handler, tokens, db)
+		}
+		handler = c.Handler(handler)
 		handler = event.Logger(handler, route.Name)
 
 		router.
@@ -3,98 +3,523 @@ package route
 import (
 	"net/http"
 
+	"github.com/kernkw/hhapp/internal/auth"
+	"github.com/kernkw/hhapp/internal/config"
 	"github.com/kernkw/hhapp/internal/data"
+	"github.com/kernkw/hhapp/internal/geo"
+	"github.com/kernkw/hhapp/internal/mail"
+	"github.com/kernkw/hhapp/internal/oidc"
+	"github.com/kernkw/hhapp/internal/schema"
+	"github.com/kernkw/hhapp/internal/throttle"
 )
 
+var (
+	adminOnly   = []schema.Role{schema.RoleAdmin}
+	venueOwners = []schema.Role{schema.RoleAdmin, schema.RoleVenueOwner}
+)
+
+// Route describes a single endpoint. Protected routes are wrapped in
+// Authenticate by NewRouter. Routes listing Roles are implicitly protected
+// and further restricted to users holding at least one of them.
 type Route struct {
 	Name        string
 	Method      string
 	Pattern     string
 	HandlerFunc http.HandlerFunc
+	Protected   bool
+	Roles       []schema.Role
 }
 
 type Routes []Route
 
-func getRoutes(s *data.Store) Routes {
+func getRoutes(s data.Database, cfg *config.Config, tokens *auth.Signer, m mail.Mailer, g geo.Geocoder, zones *geo.TimeZones) Routes {
+	guard := throttle.NewGuard(cfg)
+	forgotGuard := throttle.NewPasswordForgotGuard(cfg)
 	routes := Routes{
 		Route{
 			"VenueCreate",
 			"POST",
 			"/create_venue",
-			VenueCreate(s),
+			VenueCreate(s, g, zones),
+			false,
+			venueOwners,
 		},
 		Route{
 			"VenueListCreate",
 			"POST",
 			"/create_venue_list",
 			VenueListCreate(s),
+			false,
+			adminOnly,
 		},
 		Route{
 			"VenueListAdd",
 			"POST",
 			"/venue_list_add",
 			VenueListAdd(s),
+			false,
+			adminOnly,
 		},
 		Route{
 			"VenueListGet",
 			"GET",
 			"/venue_list",
 			VenueListGet(s),
+			false,
+			nil,
 		},
 		Route{
 			"VenueGet",
 			"GET",
 			"/venue/{id:[0-9]+}",
 			VenueGet(s),
+			false,
+			nil,
+		},
+		Route{
+			"VenuesNearby",
+			"GET",
+			"/venues/nearby",
+			VenuesNearby(s),
+			false,
+			nil,
+		},
+		Route{
+			"VenuesRanked",
+			"GET",
+			"/venues/ranked",
+			VenuesRanked(s),
+			false,
+			nil,
+		},
+		Route{
+			"HappyHoursNow",
+			"GET",
+			"/happy_hours/now",
+			HappyHoursNow(s, cfg),
+			false,
+			nil,
+		},
+		Route{
+			"HappyHoursUpcoming",
+			"GET",
+			"/happy_hours/upcoming",
+			HappyHoursUpcoming(s, cfg),
+			true,
+			nil,
+		},
+		Route{
+			"VenueUpdate",
+			"PUT",
+			"/venues/{id:[0-9]+}",
+			VenueUpdate(s, g, zones),
+			false,
+			venueOwners,
+		},
+		Route{
+			"VenuePatch",
+			"PATCH",
+			"/venues/{id:[0-9]+}",
+			VenueUpdate(s, g, zones),
+			false,
+			venueOwners,
+		},
+		Route{
+			"VenueDelete",
+			"DELETE",
+			"/venues/{id:[0-9]+}",
+			VenueDelete(s),
+			false,
+			venueOwners,
+		},
+		Route{
+			"MenusGet",
+			"GET",
+			"/venues/{id:[0-9]+}/menus",
+			MenusGet(s),
+			false,
+			nil,
+		},
+		Route{
+			"MenuCreate",
+			"POST",
+			"/venues/{id:[0-9]+}/menus",
+			MenuCreate(s),
+			false,
+			venueOwners,
+		},
+		Route{
+			"MenuSnapshotGet",
+			"GET",
+			"/venues/{id:[0-9]+}/menu",
+			MenuSnapshotGet(s),
+			false,
+			venueOwners,
+		},
+		Route{
+			"MenuHistoryGet",
+			"GET",
+			"/venues/{id:[0-9]+}/menu/history",
+			MenuHistoryGet(s),
+			false,
+			venueOwners,
+		},
+		Route{
+			"MenuUpdate",
+			"PATCH",
+			"/menus/{id:[0-9]+}",
+			MenuUpdate(s),
+			false,
+			venueOwners,
+		},
+		Route{
+			"MenuDelete",
+			"DELETE",
+			"/menus/{id:[0-9]+}",
+			MenuDelete(s),
+			false,
+			venueOwners,
+		},
+		Route{
+			"MenuScheduleGet",
+			"GET",
+			"/menus/{id:[0-9]+}/schedule",
+			MenuScheduleGet(s),
+			false,
+			nil,
+		},
+		Route{
+			"MenuScheduleCreate",
+			"POST",
+			"/menus/{id:[0-9]+}/schedule",
+			MenuScheduleCreate(s),
+			false,
+			venueOwners,
+		},
+		Route{
+			"MenuScheduleUpdate",
+			"PUT",
+			"/menus/{id:[0-9]+}/schedule/{schedule_id:[0-9]+}",
+			MenuScheduleUpdate(s),
+			false,
+			venueOwners,
+		},
+		Route{
+			"MenuScheduleDelete",
+			"DELETE",
+			"/menus/{id:[0-9]+}/schedule/{schedule_id:[0-9]+}",
+			MenuScheduleDelete(s),
+			false,
+			venueOwners,
+		},
+		Route{
+			"MenuExceptionsGet",
+			"GET",
+			"/menus/{id:[0-9]+}/exceptions",
+			MenuExceptionsGet(s),
+			false,
+			nil,
+		},
+		Route{
+			"MenuExceptionCreate",
+			"POST",
+			"/menus/{id:[0-9]+}/exceptions",
+			MenuExceptionCreate(s),
+			false,
+			venueOwners,
+		},
+		Route{
+			"MenuExceptionUpdate",
+			"PUT",
+			"/menus/{id:[0-9]+}/exceptions/{exception_id:[0-9]+}",
+			MenuExceptionUpdate(s),
+			false,
+			venueOwners,
+		},
+		Route{
+			"MenuExceptionDelete",
+			"DELETE",
+			"/menus/{id:[0-9]+}/exceptions/{exception_id:[0-9]+}",
+			MenuExceptionDelete(s),
+			false,
+			venueOwners,
 		},
 		Route{
 			"MenuItemAdd",
 			"POST",
 			"/add_menu_item",
 			MenuItemAdd(s),
+			false,
+			venueOwners,
 		},
 		Route{
 			"MenuItemsGet",
 			"GET",
 			"/menu_items",
 			MenuItemsGet(s),
+			false,
+			nil,
+		},
+		Route{
+			"MenuItemUpdate",
+			"PUT",
+			"/menu_items/{id:[0-9]+}",
+			MenuItemUpdate(s),
+			false,
+			venueOwners,
+		},
+		Route{
+			"MenuItemDelete",
+			"DELETE",
+			"/menu_items/{id:[0-9]+}",
+			MenuItemDelete(s),
+			false,
+			venueOwners,
+		},
+		Route{
+			"MenuItemsReorder",
+			"PUT",
+			"/menus/{id:[0-9]+}/items/order",
+			MenuItemsReorder(s),
+			false,
+			venueOwners,
 		},
 		Route{
 			"AccountCreate",
 			"POST",
 			"/create_account",
-			UserCreate(s),
+			UserCreate(s, tokens, m, cfg),
+			false,
+			nil,
 		},
 		Route{
 			"UserFavoriteCreate",
 			"POST",
 			"/create_user_favorite",
 			UserFavoriteCreate(s),
+			true,
+			nil,
 		},
 		Route{
 			"UserFavoritesList",
 			"GET",
 			"/user_favorites",
 			UserFavoritesList(s),
+			true,
+			nil,
 		},
 		Route{
 			"UserFavoritesGet",
 			"GET",
-			"/user_favorites/{venue_id:[0-9]+}/{user_id}",
+			"/user_favorites/{venue_id:[0-9]+}",
 			UserFavoritesGet(s),
+			true,
+			nil,
 		},
 		Route{
 			"UserFavoritesRemove",
 			"POST",
 			"/user_favorite/{id:[0-9]+}",
 			UserFavoritesRemove(s),
+			true,
+			nil,
 		},
 		Route{
 			"UserLogin",
 			"POST",
 			"/authenticate",
-			UserLogin(s),
+			UserLogin(s, tokens, guard, cfg),
+			false,
+			nil,
+		},
+		Route{
+			"UserLogin2FA",
+			"POST",
+			"/authenticate/2fa",
+			UserLogin2FA(s, tokens, guard, cfg.TrustProxyHeaders),
+			false,
+			nil,
+		},
+		Route{
+			"TokenRefresh",
+			"POST",
+			"/token/refresh",
+			TokenRefresh(s, tokens),
+			false,
+			nil,
+		},
+		Route{
+			"Logout",
+			"POST",
+			"/logout",
+			Logout(s),
+			false,
+			nil,
+		},
+		Route{
+			"LogoutAll",
+			"POST",
+			"/logout_all",
+			LogoutAll(s),
+			true,
+			nil,
+		},
+		Route{
+			"PasswordForgot",
+			"POST",
+			"/password/forgot",
+			PasswordForgot(s, m, forgotGuard, cfg),
+			false,
+			nil,
+		},
+		Route{
+			"PasswordReset",
+			"POST",
+			"/password/reset",
+			PasswordReset(s, cfg),
+			false,
+			nil,
+		},
+		Route{
+			"VerifyEmail",
+			"GET",
+			"/verify_email",
+			VerifyEmail(s, tokens),
+			false,
+			nil,
 		},
+		Route{
+			"VerifyEmailResend",
+			"POST",
+			"/verify_email/resend",
+			VerifyEmailResend(s, tokens, m, cfg),
+			true,
+			nil,
+		},
+		Route{
+			"UserNotificationCreate",
+			"POST",
+			"/user_notifications",
+			UserNotificationCreate(s),
+			true,
+			nil,
+		},
+		Route{
+			"UserMeGet",
+			"GET",
+			"/users/me",
+			UserMeGet(s),
+			true,
+			nil,
+		},
+		Route{
+			"UserMeUpdate",
+			"PUT",
+			"/users/me",
+			UserMeUpdate(s, tokens, m, cfg),
+			true,
+			nil,
+		},
+		Route{
+			"UserMePassword",
+			"POST",
+			"/users/me/password",
+			UserMePassword(s, tokens, cfg),
+			true,
+			nil,
+		},
+		Route{
+			"UserMeDelete",
+			"DELETE",
+			"/users/me",
+			UserMeDelete(s),
+			true,
+			nil,
+		},
+		Route{
+			"TwoFactorEnroll",
+			"POST",
+			"/2fa/enroll",
+			TwoFactorEnroll(s, cfg),
+			true,
+			nil,
+		},
+		Route{
+			"TwoFactorConfirm",
+			"POST",
+			"/2fa/confirm",
+			TwoFactorConfirm(s),
+			true,
+			nil,
+		},
+		Route{
+			"TwoFactorDisable",
+			"POST",
+			"/2fa/disable",
+			TwoFactorDisable(s),
+			true,
+			nil,
+		},
+		Route{
+			"UserRolesUpdate",
+			"PUT",
+			"/users/{id:[0-9]+}/roles",
+			UserRolesUpdate(s),
+			true,
+			adminOnly,
+		},
+		Route{
+			"APIKeyCreate",
+			"POST",
+			"/api_keys",
+			APIKeyCreate(s),
+			true,
+			adminOnly,
+		},
+		Route{
+			"APIKeysList",
+			"GET",
+			"/api_keys",
+			APIKeysList(s),
+			true,
+			adminOnly,
+		},
+		Route{
+			"APIKeyRevoke",
+			"DELETE",
+			"/api_keys/{id:[0-9]+}",
+			APIKeyRevoke(s),
+			true,
+			adminOnly,
+		},
+	}
+	if p := oidc.NewProvider(cfg); p != nil {
+		routes = append(routes,
+			Route{
+				"OIDCLogin",
+				"GET",
+				"/oidc/login",
+				OIDCLogin(p, tokens, cfg),
+				false,
+				nil,
+			},
+			Route{
+				"OIDCLink",
+				"POST",
+				"/oidc/link",
+				OIDCLink(p, tokens, cfg),
+				true,
+				nil,
+			},
+			Route{
+				"OIDCCallback",
+				"GET",
+				"/oidc/callback",
+				OIDCCallback(s, p, tokens),
+				false,
+				nil,
+			},
+		)
 	}
 	return routes
 }
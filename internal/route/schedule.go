@@ -0,0 +1,348 @@
+package route
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/kernkw/hhapp/internal/auth"
+	"github.com/kernkw/hhapp/internal/data"
+	"github.com/kernkw/hhapp/internal/schema"
+)
+
+// scheduleVars reads the menu id and, when present, the id of the schedule
+// window or exception named by idVar from the request path.
+func scheduleVars(r *http.Request, idVar string) (menuID, id int, err error) {
+	vars := mux.Vars(r)
+	if menuID, err = strconv.Atoi(vars["id"]); err != nil {
+		return 0, 0, err
+	}
+	if v, ok := vars[idVar]; ok {
+		if id, err = strconv.Atoi(v); err != nil {
+			return 0, 0, err
+		}
+	}
+	return menuID, id, nil
+}
+
+/*
+Test with this curl command:
+curl http://localhost:8080/menus/1/schedule
+*/
+func MenuScheduleGet(db data.Database) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		menuID, _, err := scheduleVars(r, "schedule_id")
+		if err != nil {
+			writeError(w, http.StatusUnprocessableEntity, err)
+			return
+		}
+		if _, err := db.MenuGet(menuID); err == data.ErrNotFound {
+			writeError(w, http.StatusNotFound, err)
+			return
+		} else if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		times, err := db.MenuDateTimesGet(menuID)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		if times == nil {
+			times = []schema.MenuDateTime{}
+		}
+
+		type envelope struct {
+			Data []schema.MenuDateTime `json:"data"`
+		}
+		writeJSON(w, http.StatusOK, envelope{times})
+	})
+}
+
+/*
+Test with this curl command:
+curl -H "Content-Type: application/json" -H "Authorization: Bearer $TOKEN" -d '{"monday":true,"tuesday":true,"wednesday":true,"thursday":true,"friday":true,"start_at":"15:00","end_at":"18:00"}' http://localhost:8080/menus/1/schedule
+*/
+func MenuScheduleCreate(db data.Database) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		menuID, _, err := scheduleVars(r, "schedule_id")
+		if err != nil {
+			writeError(w, http.StatusUnprocessableEntity, err)
+			return
+		}
+		var md schema.MenuDateTime
+		if err := json.NewDecoder(r.Body).Decode(&md); err != nil {
+			writeError(w, http.StatusUnprocessableEntity, err)
+			return
+		}
+		defer r.Body.Close()
+		md.ID, md.MenuID = 0, menuID
+
+		if _, ok := authorizeMenu(w, r, db, menuID); !ok {
+			return
+		}
+		if err := md.Validate(); err != nil {
+			writeError(w, http.StatusUnprocessableEntity, err)
+			return
+		}
+		// The store checks the window against the menu's others.
+		user, _ := auth.FromContext(r.Context())
+		md.ID, err = db.CreateMenuDateTime(md, user.ID)
+		if err == data.ErrNotFound {
+			writeError(w, http.StatusNotFound, err)
+			return
+		}
+		if err == schema.ErrOverlappingWindows {
+			writeError(w, http.StatusUnprocessableEntity, err)
+			return
+		}
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		type envelope struct {
+			Data schema.MenuDateTime `json:"data"`
+		}
+		writeJSON(w, http.StatusCreated, envelope{md})
+	})
+}
+
+/*
+Test with this curl command:
+curl -X PUT -H "Content-Type: application/json" -H "Authorization: Bearer $TOKEN" -d '{"friday":true,"saturday":true,"start_at":"22:00","end_at":"02:00","overnight":true}' http://localhost:8080/menus/1/schedule/1
+*/
+func MenuScheduleUpdate(db data.Database) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		menuID, id, err := scheduleVars(r, "schedule_id")
+		if err != nil {
+			writeError(w, http.StatusUnprocessableEntity, err)
+			return
+		}
+		var md schema.MenuDateTime
+		if err := json.NewDecoder(r.Body).Decode(&md); err != nil {
+			writeError(w, http.StatusUnprocessableEntity, err)
+			return
+		}
+		defer r.Body.Close()
+		md.ID, md.MenuID = id, menuID
+
+		if _, ok := authorizeMenu(w, r, db, menuID); !ok {
+			return
+		}
+		if err := md.Validate(); err != nil {
+			writeError(w, http.StatusUnprocessableEntity, err)
+			return
+		}
+		user, _ := auth.FromContext(r.Context())
+		err = db.UpdateMenuDateTime(md, user.ID)
+		if err == data.ErrNotFound {
+			writeError(w, http.StatusNotFound, err)
+			return
+		}
+		if err == schema.ErrOverlappingWindows {
+			writeError(w, http.StatusUnprocessableEntity, err)
+			return
+		}
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		type envelope struct {
+			Data schema.MenuDateTime `json:"data"`
+		}
+		writeJSON(w, http.StatusOK, envelope{md})
+	})
+}
+
+/*
+Test with this curl command:
+curl -X DELETE -H "Authorization: Bearer $TOKEN" http://localhost:8080/menus/1/schedule/1
+*/
+func MenuScheduleDelete(db data.Database) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		menuID, id, err := scheduleVars(r, "schedule_id")
+		if err != nil {
+			writeError(w, http.StatusUnprocessableEntity, err)
+			return
+		}
+		if _, ok := authorizeMenu(w, r, db, menuID); !ok {
+			return
+		}
+
+		user, _ := auth.FromContext(r.Context())
+		err = db.DeleteMenuDateTime(menuID, id, user.ID)
+		if err == data.ErrNotFound {
+			writeError(w, http.StatusNotFound, err)
+			return
+		}
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		type envelope struct {
+			Status string `json:"status"`
+		}
+		writeJSON(w, http.StatusOK, envelope{http.StatusText(http.StatusOK)})
+	})
+}
+
+/*
+Test with this curl command:
+curl http://localhost:8080/menus/1/exceptions
+*/
+func MenuExceptionsGet(db data.Database) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		menuID, _, err := scheduleVars(r, "exception_id")
+		if err != nil {
+			writeError(w, http.StatusUnprocessableEntity, err)
+			return
+		}
+		if _, err := db.MenuGet(menuID); err == data.ErrNotFound {
+			writeError(w, http.StatusNotFound, err)
+			return
+		} else if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		exceptions, err := db.MenuExceptionsGet(menuID)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		if exceptions == nil {
+			exceptions = []schema.MenuException{}
+		}
+
+		type envelope struct {
+			Data []schema.MenuException `json:"data"`
+		}
+		writeJSON(w, http.StatusOK, envelope{exceptions})
+	})
+}
+
+// readMenuException decodes and validates an exception to the schedule of
+// menuID. When the body is invalid an error is written and false returned.
+func readMenuException(w http.ResponseWriter, r *http.Request, menuID, id int) (schema.MenuException, bool) {
+	var e schema.MenuException
+	if err := json.NewDecoder(r.Body).Decode(&e); err != nil {
+		writeError(w, http.StatusUnprocessableEntity, err)
+		return e, false
+	}
+	defer r.Body.Close()
+	e.ID, e.MenuID = id, menuID
+	// A blackout cancels whole days, so it has no times.
+	if e.Kind == schema.ExceptionBlackout {
+		e.StartAt, e.EndAt, e.Overnight = 0, 0, false
+	}
+	if err := e.Validate(); err != nil {
+		writeError(w, http.StatusUnprocessableEntity, err)
+		return e, false
+	}
+	return e, true
+}
+
+/*
+Test with this curl command:
+curl -H "Content-Type: application/json" -H "Authorization: Bearer $TOKEN" -d '{"kind":"special","start_date":"2018-02-04","end_date":"2018-02-04","start_at":"16:00","end_at":"23:00","note":"Super Bowl Sunday"}' http://localhost:8080/menus/1/exceptions
+curl -H "Content-Type: application/json" -H "Authorization: Bearer $TOKEN" -d '{"kind":"blackout","start_date":"2018-12-25","end_date":"2018-12-25","note":"Closed for Christmas"}' http://localhost:8080/menus/1/exceptions
+*/
+func MenuExceptionCreate(db data.Database) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		menuID, _, err := scheduleVars(r, "exception_id")
+		if err != nil {
+			writeError(w, http.StatusUnprocessableEntity, err)
+			return
+		}
+		if _, ok := authorizeMenu(w, r, db, menuID); !ok {
+			return
+		}
+		e, ok := readMenuException(w, r, menuID, 0)
+		if !ok {
+			return
+		}
+		user, _ := auth.FromContext(r.Context())
+		e.ID, err = db.CreateMenuException(e, user.ID)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		type envelope struct {
+			Data schema.MenuException `json:"data"`
+		}
+		writeJSON(w, http.StatusCreated, envelope{e})
+	})
+}
+
+/*
+Test with this curl command:
+curl -X PUT -H "Content-Type: application/json" -H "Authorization: Bearer $TOKEN" -d '{"kind":"special","start_date":"2018-03-01","end_date":"2018-03-31","start_at":"17:00","end_at":"19:00","note":"March promo"}' http://localhost:8080/menus/1/exceptions/1
+*/
+func MenuExceptionUpdate(db data.Database) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		menuID, id, err := scheduleVars(r, "exception_id")
+		if err != nil {
+			writeError(w, http.StatusUnprocessableEntity, err)
+			return
+		}
+		if _, ok := authorizeMenu(w, r, db, menuID); !ok {
+			return
+		}
+		e, ok := readMenuException(w, r, menuID, id)
+		if !ok {
+			return
+		}
+		user, _ := auth.FromContext(r.Context())
+		err = db.UpdateMenuException(e, user.ID)
+		if err == data.ErrNotFound {
+			writeError(w, http.StatusNotFound, err)
+			return
+		}
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		type envelope struct {
+			Data schema.MenuException `json:"data"`
+		}
+		writeJSON(w, http.StatusOK, envelope{e})
+	})
+}
+
+/*
+Test with this curl command:
+curl -X DELETE -H "Authorization: Bearer $TOKEN" http://localhost:8080/menus/1/exceptions/1
+*/
+func MenuExceptionDelete(db data.Database) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		menuID, id, err := scheduleVars(r, "exception_id")
+		if err != nil {
+			writeError(w, http.StatusUnprocessableEntity, err)
+			return
+		}
+		if _, ok := authorizeMenu(w, r, db, menuID); !ok {
+			return
+		}
+
+		user, _ := auth.FromContext(r.Context())
+		err = db.DeleteMenuException(menuID, id, user.ID)
+		if err == data.ErrNotFound {
+			writeError(w, http.StatusNotFound, err)
+			return
+		}
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		type envelope struct {
+			Status string `json:"status"`
+		}
+		writeJSON(w, http.StatusOK, envelope{http.StatusText(http.StatusOK)})
+	})
+}
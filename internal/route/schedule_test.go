@@ -0,0 +1,229 @@
+package route
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/kernkw/hhapp/internal/data"
+	"github.com/kernkw/hhapp/internal/data/datamock"
+	"github.com/kernkw/hhapp/internal/schema"
+)
+
+// scheduleStore extends menuStore so that menu 7 has a single Mon-Fri
+// 15:00-18:00 window. Like the real store, it refuses to create or update
+// a window that would overlap it.
+func scheduleStore() *datamock.Mock {
+	window := func(menuID int) []schema.MenuDateTime {
+		return []schema.MenuDateTime{{
+			ID: 1, MenuID: menuID,
+			Monday: true, Tuesday: true, Wednesday: true, Thursday: true, Friday: true,
+			StartAt: 15 * 60 * 60, EndAt: 18 * 60 * 60,
+		}}
+	}
+	db := menuStore()
+	db.MenuDateTimesGet_ = func(menuID int) ([]schema.MenuDateTime, error) {
+		return window(menuID), nil
+	}
+	db.CreateMenuDateTime_ = func(md schema.MenuDateTime, userID int) (int, error) {
+		if err := schema.ValidateSchedule(append(window(md.MenuID), md)); err != nil {
+			return 0, err
+		}
+		return 2, nil
+	}
+	db.UpdateMenuDateTime_ = func(md schema.MenuDateTime, userID int) error {
+		if md.ID != 1 {
+			return data.ErrNotFound
+		}
+		return schema.ValidateSchedule([]schema.MenuDateTime{md})
+	}
+	return db
+}
+
+func TestMenuScheduleGet(t *testing.T) {
+	rr := serve(scheduleStore(), "GET", "/menus/7/schedule", "", schema.User{})
+
+	expected := `{"data":[{"id":1,"menu_id":7,"monday":true,"tuesday":true,"wednesday":true,"thursday":true,"friday":true,"saturday":false,"sunday":false,"start_at":"15:00","end_at":"18:00","overnight":false}]}`
+	if rr.Code != http.StatusOK || rr.Body.String() != expected {
+		t.Errorf("got %v %v want %v %v", rr.Code, rr.Body.String(), http.StatusOK, expected)
+	}
+	if rr := serve(scheduleStore(), "GET", "/menus/9/schedule", "", schema.User{}); rr.Code != http.StatusNotFound {
+		t.Errorf("unknown menu: got status %v want %v", rr.Code, http.StatusNotFound)
+	}
+}
+
+func TestMenuScheduleCreate(t *testing.T) {
+	tests := []struct {
+		body string
+		user schema.User
+		want int
+	}{
+		{`{"sunday":true,"start_at":"00:00","end_at":"24:00"}`, testOwner, http.StatusCreated},
+		{`{"friday":true,"saturday":true,"start_at":"22:00","end_at":"02:00","overnight":true}`, testOwner, http.StatusCreated},
+		{`{"sunday":true,"start_at":"00:00","end_at":"24:00"}`, schema.User{ID: 43, Roles: []schema.Role{schema.RoleVenueOwner}}, http.StatusForbidden},
+		{`{"friday":true,"start_at":"17:00","end_at":"19:00"}`, testOwner, http.StatusUnprocessableEntity},
+		{`{"sunday":true,"start_at":"18:00","end_at":"15:00"}`, testOwner, http.StatusUnprocessableEntity},
+		{`{"sunday":true,"start_at":"3pm","end_at":"6pm"}`, testOwner, http.StatusUnprocessableEntity},
+		{`{"start_at":"15:00","end_at":"18:00"}`, testOwner, http.StatusUnprocessableEntity},
+	}
+	for _, tt := range tests {
+		var created schema.MenuDateTime
+		db := scheduleStore()
+		create := db.CreateMenuDateTime_
+		db.CreateMenuDateTime_ = func(md schema.MenuDateTime, userID int) (int, error) {
+			created = md
+			return create(md, userID)
+		}
+
+		rr := serve(db, "POST", "/menus/7/schedule", tt.body, tt.user)
+		if rr.Code != tt.want {
+			t.Errorf("%s: got status %v want %v", tt.body, rr.Code, tt.want)
+		}
+		if tt.want == http.StatusCreated && created.MenuID != 7 {
+			t.Errorf("%s: window created on menu %v want 7", tt.body, created.MenuID)
+		}
+	}
+}
+
+func TestMenuScheduleUpdate(t *testing.T) {
+	tests := []struct {
+		path string
+		body string
+		want int
+	}{
+		// A window may be moved over the time it covered itself.
+		{"/menus/7/schedule/1", `{"monday":true,"tuesday":true,"start_at":"16:00","end_at":"19:00"}`, http.StatusOK},
+		{"/menus/7/schedule/1", `{"monday":true,"start_at":"19:00","end_at":"16:00"}`, http.StatusUnprocessableEntity},
+		{"/menus/7/schedule/2", `{"monday":true,"start_at":"16:00","end_at":"19:00"}`, http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		var updated schema.MenuDateTime
+		db := scheduleStore()
+		update := db.UpdateMenuDateTime_
+		db.UpdateMenuDateTime_ = func(md schema.MenuDateTime, userID int) error {
+			updated = md
+			return update(md, userID)
+		}
+
+		rr := serve(db, "PUT", tt.path, tt.body, testOwner)
+		if rr.Code != tt.want {
+			t.Errorf("%s %s: got status %v want %v", tt.path, tt.body, rr.Code, tt.want)
+		}
+		if tt.want == http.StatusOK && (updated.ID != 1 || updated.MenuID != 7 || updated.Wednesday) {
+			t.Errorf("%s %s: stored %+v", tt.path, tt.body, updated)
+		}
+	}
+}
+
+func TestMenuScheduleDelete(t *testing.T) {
+	db := scheduleStore()
+	db.DeleteMenuDateTime_ = func(menuID, id, userID int) error {
+		if menuID != 7 || id != 1 {
+			return data.ErrNotFound
+		}
+		return nil
+	}
+
+	if rr := serve(db, "DELETE", "/menus/7/schedule/1", "", testOwner); rr.Code != http.StatusOK {
+		t.Errorf("got status %v want %v", rr.Code, http.StatusOK)
+	}
+	if rr := serve(db, "DELETE", "/menus/7/schedule/2", "", testOwner); rr.Code != http.StatusNotFound {
+		t.Errorf("got status %v want %v", rr.Code, http.StatusNotFound)
+	}
+}
+
+func TestMenuExceptionsGet(t *testing.T) {
+	db := scheduleStore()
+	db.MenuExceptionsGet_ = func(menuID int) ([]schema.MenuException, error) {
+		superBowl := schema.Date{Year: 2018, Month: time.February, Day: 4}
+		return []schema.MenuException{{
+			ID: 3, MenuID: menuID, Kind: schema.ExceptionSpecial, StartDate: superBowl, EndDate: superBowl,
+			StartAt: 16 * 60 * 60, EndAt: 23 * 60 * 60, Note: "Super Bowl Sunday",
+		}}, nil
+	}
+	rr := serve(db, "GET", "/menus/7/exceptions", "", schema.User{})
+
+	expected := `{"data":[{"id":3,"menu_id":7,"kind":"special","start_date":"2018-02-04","end_date":"2018-02-04","start_at":"16:00","end_at":"23:00","overnight":false,"note":"Super Bowl Sunday"}]}`
+	if rr.Code != http.StatusOK || rr.Body.String() != expected {
+		t.Errorf("got %v %v want %v %v", rr.Code, rr.Body.String(), http.StatusOK, expected)
+	}
+	if rr := serve(db, "GET", "/menus/9/exceptions", "", schema.User{}); rr.Code != http.StatusNotFound {
+		t.Errorf("unknown menu: got status %v want %v", rr.Code, http.StatusNotFound)
+	}
+}
+
+func TestMenuExceptionCreate(t *testing.T) {
+	tests := []struct {
+		body string
+		user schema.User
+		want int
+	}{
+		{`{"kind":"special","start_date":"2018-03-01","end_date":"2018-03-31","start_at":"17:00","end_at":"19:00"}`, testOwner, http.StatusCreated},
+		{`{"kind":"blackout","start_date":"2018-12-25","end_date":"2018-12-25","start_at":"10:00"}`, testOwner, http.StatusCreated},
+		{`{"kind":"blackout","start_date":"2018-12-25","end_date":"2018-12-25"}`, schema.User{ID: 43, Roles: []schema.Role{schema.RoleVenueOwner}}, http.StatusForbidden},
+		{`{"kind":"holiday","start_date":"2018-12-25","end_date":"2018-12-25"}`, testOwner, http.StatusUnprocessableEntity},
+		{`{"kind":"blackout","start_date":"2018-12-25","end_date":"2018-12-24"}`, testOwner, http.StatusUnprocessableEntity},
+		{`{"kind":"blackout","start_date":"12/25/2018","end_date":"12/25/2018"}`, testOwner, http.StatusUnprocessableEntity},
+		{`{"kind":"blackout","end_date":"2018-12-25"}`, testOwner, http.StatusUnprocessableEntity},
+		{`{"kind":"special","start_date":"2018-02-04","end_date":"2018-02-04","start_at":"23:00","end_at":"16:00"}`, testOwner, http.StatusUnprocessableEntity},
+	}
+	for _, tt := range tests {
+		var created schema.MenuException
+		db := scheduleStore()
+		db.CreateMenuException_ = func(e schema.MenuException, userID int) (int, error) {
+			created = e
+			return 4, nil
+		}
+
+		rr := serve(db, "POST", "/menus/7/exceptions", tt.body, tt.user)
+		if rr.Code != tt.want {
+			t.Errorf("%s: got status %v want %v", tt.body, rr.Code, tt.want)
+		}
+		if tt.want == http.StatusCreated && created.MenuID != 7 {
+			t.Errorf("%s: exception created on menu %v want 7", tt.body, created.MenuID)
+		}
+		if created.Kind == schema.ExceptionBlackout && created.StartAt != 0 {
+			t.Errorf("%s: blackout stored with times %+v", tt.body, created)
+		}
+	}
+}
+
+func TestMenuExceptionUpdate(t *testing.T) {
+	db := scheduleStore()
+	var updated schema.MenuException
+	db.UpdateMenuException_ = func(e schema.MenuException, userID int) error {
+		if e.ID != 3 {
+			return data.ErrNotFound
+		}
+		updated = e
+		return nil
+	}
+	body := `{"kind":"special","start_date":"2018-02-04","end_date":"2018-02-04","start_at":"16:00","end_at":"01:00","overnight":true}`
+
+	if rr := serve(db, "PUT", "/menus/7/exceptions/3", body, testOwner); rr.Code != http.StatusOK {
+		t.Errorf("got status %v want %v", rr.Code, http.StatusOK)
+	}
+	if updated.ID != 3 || updated.MenuID != 7 || !updated.Overnight {
+		t.Errorf("stored %+v", updated)
+	}
+	if rr := serve(db, "PUT", "/menus/7/exceptions/4", body, testOwner); rr.Code != http.StatusNotFound {
+		t.Errorf("unknown exception: got status %v want %v", rr.Code, http.StatusNotFound)
+	}
+}
+
+func TestMenuExceptionDelete(t *testing.T) {
+	db := scheduleStore()
+	db.DeleteMenuException_ = func(menuID, id, userID int) error {
+		if menuID != 7 || id != 3 {
+			return data.ErrNotFound
+		}
+		return nil
+	}
+
+	if rr := serve(db, "DELETE", "/menus/7/exceptions/3", "", testOwner); rr.Code != http.StatusOK {
+		t.Errorf("got status %v want %v", rr.Code, http.StatusOK)
+	}
+	if rr := serve(db, "DELETE", "/menus/7/exceptions/4", "", testOwner); rr.Code != http.StatusNotFound {
+		t.Errorf("got status %v want %v", rr.Code, http.StatusNotFound)
+	}
+}
@@ -0,0 +1,140 @@
+package route
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/kernkw/hhapp/internal/auth"
+	"github.com/kernkw/hhapp/internal/data"
+	"github.com/kernkw/hhapp/internal/schema"
+)
+
+type refreshRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+/*
+Test with this curl command:
+curl -H "Content-Type: application/json" -d '{"refresh_token":"..."}' http://localhost:8080/token/refresh
+*/
+func TokenRefresh(db data.Database, tokens *auth.Signer) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req refreshRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, http.StatusUnprocessableEntity, err)
+			return
+		}
+		defer r.Body.Close()
+
+		hash := auth.HashToken(req.RefreshToken)
+		rt, err := db.RefreshTokenGet(hash)
+		if err == data.ErrNotFound {
+			writeError(w, http.StatusUnauthorized, auth.ErrInvalidToken)
+			return
+		}
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		if !rt.Active(time.Now()) {
+			writeError(w, http.StatusUnauthorized, auth.ErrExpiredToken)
+			return
+		}
+
+		// Refresh tokens are single use; revoking before issuing the
+		// replacement means a concurrent replay of the same token fails.
+		err = db.RevokeRefreshToken(hash)
+		if err == data.ErrNotFound {
+			writeError(w, http.StatusUnauthorized, auth.ErrExpiredToken)
+			return
+		}
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		user, err := db.GetUserByID(rt.UserID)
+		if err != nil {
+			writeError(w, http.StatusUnauthorized, err)
+			return
+		}
+		writeSession(w, db, tokens, user)
+	})
+}
+
+/*
+Test with this curl command:
+curl -H "Content-Type: application/json" -d '{"refresh_token":"..."}' http://localhost:8080/logout
+*/
+func Logout(db data.Database) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req refreshRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, http.StatusUnprocessableEntity, err)
+			return
+		}
+		defer r.Body.Close()
+
+		err := db.RevokeRefreshToken(auth.HashToken(req.RefreshToken))
+		if err != nil && err != data.ErrNotFound {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		type envelope struct {
+			Status string `json:"status"`
+		}
+		writeJSON(w, http.StatusOK, envelope{http.StatusText(http.StatusOK)})
+	})
+}
+
+/*
+Test with this curl command:
+curl -X POST -H "Authorization: Bearer $TOKEN" http://localhost:8080/logout_all
+*/
+func LogoutAll(db data.Database) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, ok := auth.FromContext(r.Context())
+		if !ok {
+			writeError(w, http.StatusUnauthorized, nil)
+			return
+		}
+		if err := db.RevokeUserRefreshTokens(user.ID); err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		type envelope struct {
+			Status string `json:"status"`
+		}
+		writeJSON(w, http.StatusOK, envelope{http.StatusText(http.StatusOK)})
+	})
+}
+
+// writeSession issues a new access and refresh token pair for user and
+// writes them as the response.
+func writeSession(w http.ResponseWriter, db data.Database, tokens *auth.Signer, user schema.User) {
+	token, expires, err := tokens.Sign(user)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	refresh, rt, err := tokens.NewRefreshToken(user)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	if _, err := db.CreateRefreshToken(rt); err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	type envelope struct {
+		Status           string    `json:"status"`
+		Token            string    `json:"token"`
+		TokenType        string    `json:"token_type"`
+		ExpiresAt        time.Time `json:"expires_at"`
+		RefreshToken     string    `json:"refresh_token"`
+		RefreshExpiresAt time.Time `json:"refresh_expires_at"`
+	}
+	writeJSON(w, http.StatusOK, envelope{http.StatusText(http.StatusOK), token, "Bearer", expires, refresh, rt.ExpiresAt})
+}
@@ -0,0 +1,171 @@
+package route
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/kernkw/hhapp/internal/auth"
+	"github.com/kernkw/hhapp/internal/data"
+	"github.com/kernkw/hhapp/internal/data/datamock"
+	"github.com/kernkw/hhapp/internal/schema"
+)
+
+func TestTokenRefresh(t *testing.T) {
+	tokens := auth.NewSigner("secret", time.Minute, time.Hour)
+	refresh, stored, err := tokens.NewRefreshToken(schema.User{ID: 42})
+	checkError(err, t)
+
+	var revoked string
+	var created schema.RefreshToken
+	mockStore := &datamock.Mock{
+		RefreshTokenGet_: func(hash string) (schema.RefreshToken, error) {
+			if hash != stored.TokenHash {
+				return schema.RefreshToken{}, data.ErrNotFound
+			}
+			return stored, nil
+		},
+		RevokeRefreshToken_: func(hash string) error {
+			revoked = hash
+			return nil
+		},
+		GetUserByID_: func(id int) (schema.User, error) {
+			return schema.User{ID: id, UserName: "test"}, nil
+		},
+		CreateRefreshToken_: func(rt schema.RefreshToken) (int, error) {
+			created = rt
+			return 2, nil
+		},
+	}
+
+	body := fmt.Sprintf(`{"refresh_token":%q}`, refresh)
+	req, err := http.NewRequest("POST", "/token/refresh", bytes.NewReader([]byte(body)))
+	checkError(err, t)
+
+	rr := httptest.NewRecorder()
+
+	http.HandlerFunc(TokenRefresh(mockStore, tokens)).
+		ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusOK {
+		t.Fatalf("handler returned wrong status code: got %v want %v",
+			status, http.StatusOK)
+	}
+	if revoked != stored.TokenHash {
+		t.Errorf("old refresh token was not revoked")
+	}
+
+	var resp struct {
+		Token        string `json:"token"`
+		RefreshToken string `json:"refresh_token"`
+	}
+	checkError(json.Unmarshal(rr.Body.Bytes(), &resp), t)
+	if resp.RefreshToken == refresh || auth.HashToken(resp.RefreshToken) != created.TokenHash {
+		t.Errorf("refresh token was not rotated")
+	}
+	claims, err := tokens.Verify(resp.Token)
+	checkError(err, t)
+	if claims.UserID != 42 {
+		t.Errorf("token issued for wrong user: got %v want %v", claims.UserID, 42)
+	}
+}
+
+func TestTokenRefresh_revoked(t *testing.T) {
+	now := time.Now()
+	mockStore := &datamock.Mock{
+		RefreshTokenGet_: func(hash string) (schema.RefreshToken, error) {
+			return schema.RefreshToken{ID: 1, UserID: 42, ExpiresAt: now.Add(time.Hour), RevokedAt: &now}, nil
+		},
+	}
+
+	req, err := http.NewRequest("POST", "/token/refresh", bytes.NewReader([]byte(`{"refresh_token":"abc"}`)))
+	checkError(err, t)
+
+	rr := httptest.NewRecorder()
+
+	http.HandlerFunc(TokenRefresh(mockStore, auth.NewSigner("secret", time.Minute, time.Hour))).
+		ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusUnauthorized {
+		t.Errorf("handler returned wrong status code: got %v want %v",
+			status, http.StatusUnauthorized)
+	}
+}
+
+func TestTokenRefresh_unknown(t *testing.T) {
+	mockStore := &datamock.Mock{
+		RefreshTokenGet_: func(hash string) (schema.RefreshToken, error) {
+			return schema.RefreshToken{}, data.ErrNotFound
+		},
+	}
+
+	req, err := http.NewRequest("POST", "/token/refresh", bytes.NewReader([]byte(`{"refresh_token":"abc"}`)))
+	checkError(err, t)
+
+	rr := httptest.NewRecorder()
+
+	http.HandlerFunc(TokenRefresh(mockStore, auth.NewSigner("secret", time.Minute, time.Hour))).
+		ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusUnauthorized {
+		t.Errorf("handler returned wrong status code: got %v want %v",
+			status, http.StatusUnauthorized)
+	}
+}
+
+func TestLogout(t *testing.T) {
+	var revoked string
+	mockStore := &datamock.Mock{
+		RevokeRefreshToken_: func(hash string) error {
+			revoked = hash
+			return nil
+		},
+	}
+
+	req, err := http.NewRequest("POST", "/logout", bytes.NewReader([]byte(`{"refresh_token":"abc"}`)))
+	checkError(err, t)
+
+	rr := httptest.NewRecorder()
+
+	http.HandlerFunc(Logout(mockStore)).
+		ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusOK {
+		t.Errorf("handler returned wrong status code: got %v want %v",
+			status, http.StatusOK)
+	}
+	if revoked != auth.HashToken("abc") {
+		t.Errorf("wrong token revoked: got %v want %v", revoked, auth.HashToken("abc"))
+	}
+}
+
+func TestLogoutAll(t *testing.T) {
+	var revoked int
+	mockStore := &datamock.Mock{
+		RevokeUserRefreshTokens_: func(userID int) error {
+			revoked = userID
+			return nil
+		},
+	}
+
+	req, err := http.NewRequest("POST", "/logout_all", nil)
+	checkError(err, t)
+	req = withUser(req, schema.User{ID: 42})
+
+	rr := httptest.NewRecorder()
+
+	http.HandlerFunc(LogoutAll(mockStore)).
+		ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusOK {
+		t.Errorf("handler returned wrong status code: got %v want %v",
+			status, http.StatusOK)
+	}
+	if revoked != 42 {
+		t.Errorf("sessions revoked for wrong user: got %v want %v", revoked, 42)
+	}
+}
@@ -0,0 +1,289 @@
+package route
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/kernkw/hhapp/internal/auth"
+	"github.com/kernkw/hhapp/internal/config"
+	"github.com/kernkw/hhapp/internal/data"
+	"github.com/kernkw/hhapp/internal/schema"
+	"github.com/kernkw/hhapp/internal/throttle"
+)
+
+const (
+	// twoFactorTTL bounds how long a user has to enter their code after
+	// the password step of a two-step login.
+	twoFactorTTL      = 5 * time.Minute
+	recoveryCodeCount = 10
+	twoFactorRequired = "two_factor_required"
+)
+
+var (
+	ErrInvalidCode       = errors.New("invalid two-factor code")
+	ErrTwoFactorEnabled  = errors.New("two-factor authentication is already enabled")
+	ErrTwoFactorDisabled = errors.New("two-factor authentication is not enabled")
+	ErrNotEnrolled       = errors.New("two-factor enrollment has not been started")
+)
+
+type twoFactorRequest struct {
+	TwoFactorToken string `json:"two_factor_token,omitempty"`
+	Code           string `json:"code"`
+	RecoveryCode   string `json:"recovery_code"`
+}
+
+// writeTwoFactorChallenge answers the password step of a login for a user
+// with two-factor enabled. The token it returns is only accepted by
+// UserLogin2FA.
+func writeTwoFactorChallenge(w http.ResponseWriter, tokens *auth.Signer, user schema.User) {
+	token, expires, err := tokens.SignPurpose(user, auth.PurposeTwoFactor, twoFactorTTL)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	type envelope struct {
+		Status         string    `json:"status"`
+		TwoFactorToken string    `json:"two_factor_token"`
+		ExpiresAt      time.Time `json:"expires_at"`
+	}
+	writeJSON(w, http.StatusOK, envelope{twoFactorRequired, token, expires})
+}
+
+// checkSecondFactor validates a TOTP code or consumes a recovery code for
+// user. It returns ErrInvalidCode if neither is valid.
+func checkSecondFactor(db data.Database, userID int, req twoFactorRequest) error {
+	if req.RecoveryCode != "" {
+		err := db.UseRecoveryCode(userID, auth.HashRecoveryCode(req.RecoveryCode))
+		if err == data.ErrNotFound {
+			return ErrInvalidCode
+		}
+		return err
+	}
+	secret, err := db.TOTPSecretGet(userID)
+	if err != nil {
+		return err
+	}
+	return checkTOTP(db, userID, secret, req.Code)
+}
+
+// checkTOTP validates a TOTP code for user and records its time step so the
+// code can't be used again. It returns ErrInvalidCode if the code is wrong
+// or has already been used.
+func checkTOTP(db data.Database, userID int, secret, code string) error {
+	step, ok := auth.ValidateTOTP(secret, code, time.Now())
+	if secret == "" || !ok {
+		return ErrInvalidCode
+	}
+	err := db.UseTOTPStep(userID, step)
+	if err == data.ErrNotFound {
+		return ErrInvalidCode
+	}
+	return err
+}
+
+/*
+Test with this curl command:
+curl -H "Content-Type: application/json" -d '{"two_factor_token":"...", "code": "123456"}' http://localhost:8080/authenticate/2fa
+*/
+func UserLogin2FA(db data.Database, tokens *auth.Signer, guard *throttle.Guard, trustProxy bool) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req twoFactorRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, http.StatusUnprocessableEntity, err)
+			return
+		}
+		defer r.Body.Close()
+
+		claims, err := tokens.VerifyPurpose(req.TwoFactorToken, auth.PurposeTwoFactor)
+		if err != nil {
+			writeError(w, http.StatusUnauthorized, err)
+			return
+		}
+		// Six digit codes are far easier to guess than passwords, so the
+		// second step shares the login throttle.
+		ip := clientIP(r, trustProxy)
+		if wait, err := guard.Allow(claims.UserName, ip); err != nil {
+			writeRetryAfter(w, wait, err)
+			return
+		}
+		err = checkSecondFactor(db, claims.UserID, req)
+		if err == ErrInvalidCode {
+			guard.Fail(claims.UserName, ip)
+			writeError(w, http.StatusUnauthorized, err)
+			return
+		}
+		if err != nil {
+			guard.Release(claims.UserName, ip)
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		guard.Succeed(claims.UserName, ip)
+
+		user, err := db.GetUserByID(claims.UserID)
+		if err != nil {
+			writeError(w, http.StatusUnauthorized, err)
+			return
+		}
+		writeSession(w, db, tokens, user)
+	})
+}
+
+/*
+Test with this curl command:
+curl -X POST -H "Authorization: Bearer $TOKEN" http://localhost:8080/2fa/enroll
+*/
+func TwoFactorEnroll(db data.Database, cfg *config.Config) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, ok := auth.FromContext(r.Context())
+		if !ok {
+			writeError(w, http.StatusUnauthorized, nil)
+			return
+		}
+		current, err := db.GetUserByID(user.ID)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		if current.TwoFactorEnabled {
+			writeError(w, http.StatusConflict, ErrTwoFactorEnabled)
+			return
+		}
+
+		secret, err := auth.NewTOTPSecret()
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		if err := db.SetTOTPSecret(user.ID, secret); err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		type envelope struct {
+			Secret string `json:"secret"`
+			URI    string `json:"uri"`
+		}
+		writeJSON(w, http.StatusOK, envelope{secret, auth.TOTPURI(cfg.TOTPIssuer, current.UserName, secret)})
+	})
+}
+
+/*
+Test with this curl command:
+curl -H "Content-Type: application/json" -H "Authorization: Bearer $TOKEN" -d '{"code": "123456"}' http://localhost:8080/2fa/confirm
+*/
+func TwoFactorConfirm(db data.Database) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, ok := auth.FromContext(r.Context())
+		if !ok {
+			writeError(w, http.StatusUnauthorized, nil)
+			return
+		}
+		var req twoFactorRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, http.StatusUnprocessableEntity, err)
+			return
+		}
+		defer r.Body.Close()
+
+		current, err := db.GetUserByID(user.ID)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		if current.TwoFactorEnabled {
+			writeError(w, http.StatusConflict, ErrTwoFactorEnabled)
+			return
+		}
+		secret, err := db.TOTPSecretGet(user.ID)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		if secret == "" {
+			writeError(w, http.StatusConflict, ErrNotEnrolled)
+			return
+		}
+		if err := checkTOTP(db, user.ID, secret, req.Code); err == ErrInvalidCode {
+			writeError(w, http.StatusUnprocessableEntity, err)
+			return
+		} else if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		codes, err := auth.NewRecoveryCodes(recoveryCodeCount)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		hashes := make([]string, len(codes))
+		for i, c := range codes {
+			hashes[i] = auth.HashRecoveryCode(c)
+		}
+		if err := db.EnableTOTP(user.ID, hashes); err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		// Recovery codes are only stored hashed, so this is the one time
+		// the user gets to see them.
+		type envelope struct {
+			Status        string   `json:"status"`
+			RecoveryCodes []string `json:"recovery_codes"`
+		}
+		writeJSON(w, http.StatusOK, envelope{http.StatusText(http.StatusOK), codes})
+	})
+}
+
+/*
+Test with this curl command:
+curl -H "Content-Type: application/json" -H "Authorization: Bearer $TOKEN" -d '{"code": "123456"}' http://localhost:8080/2fa/disable
+*/
+func TwoFactorDisable(db data.Database) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, ok := auth.FromContext(r.Context())
+		if !ok {
+			writeError(w, http.StatusUnauthorized, nil)
+			return
+		}
+		var req twoFactorRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, http.StatusUnprocessableEntity, err)
+			return
+		}
+		defer r.Body.Close()
+
+		current, err := db.GetUserByID(user.ID)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		if !current.TwoFactorEnabled {
+			writeError(w, http.StatusConflict, ErrTwoFactorDisabled)
+			return
+		}
+		// A stolen access token alone must not be enough to strip the
+		// second factor from an account.
+		err = checkSecondFactor(db, user.ID, req)
+		if err == ErrInvalidCode {
+			writeError(w, http.StatusUnauthorized, err)
+			return
+		}
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		if err := db.DisableTOTP(user.ID); err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		type envelope struct {
+			Status string `json:"status"`
+		}
+		writeJSON(w, http.StatusOK, envelope{http.StatusText(http.StatusOK)})
+	})
+}
@@ -0,0 +1,263 @@
+package route
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/kernkw/hhapp/internal/auth"
+	"github.com/kernkw/hhapp/internal/config"
+	"github.com/kernkw/hhapp/internal/data"
+	"github.com/kernkw/hhapp/internal/data/datamock"
+	"github.com/kernkw/hhapp/internal/schema"
+)
+
+const testTOTPSecret = "JBSWY3DPEHPK3PXP"
+
+// useTOTPStep stands in for the store's record of the last accepted step.
+func useTOTPStep(last *int64) func(int, int64) error {
+	return func(id int, step int64) error {
+		if step <= *last {
+			return data.ErrNotFound
+		}
+		*last = step
+		return nil
+	}
+}
+
+func TestUserLogin_two_factor(t *testing.T) {
+	dbUser := schema.User{ID: 42, UserName: "test", Password: "password", TwoFactorEnabled: true}
+	checkError(dbUser.HashPassword(0), t)
+	var lastStep int64
+	mockStore := &datamock.Mock{
+		GetUser_: func(user schema.User) (schema.User, error) {
+			return dbUser, nil
+		},
+		GetUserByID_: func(id int) (schema.User, error) {
+			return dbUser, nil
+		},
+		TOTPSecretGet_: func(id int) (string, error) {
+			return testTOTPSecret, nil
+		},
+		UseTOTPStep_: useTOTPStep(&lastStep),
+		CreateRefreshToken_: func(rt schema.RefreshToken) (int, error) {
+			return 1, nil
+		},
+	}
+	tokens := auth.NewSigner("secret", time.Minute, time.Hour)
+	guard := testGuard()
+
+	req, err := http.NewRequest("POST", "/authenticate", bytes.NewReader([]byte(`{"username":"test","password":"password"}`)))
+	checkError(err, t)
+	rr := httptest.NewRecorder()
+	http.HandlerFunc(UserLogin(mockStore, tokens, guard, &config.Config{})).
+		ServeHTTP(rr, req)
+
+	var challenge struct {
+		Status         string `json:"status"`
+		Token          string `json:"token"`
+		TwoFactorToken string `json:"two_factor_token"`
+	}
+	checkError(json.Unmarshal(rr.Body.Bytes(), &challenge), t)
+	if rr.Code != http.StatusOK || challenge.Status != twoFactorRequired {
+		t.Fatalf("got %v %v, want a two-factor challenge", rr.Code, rr.Body.String())
+	}
+	if challenge.Token != "" {
+		t.Errorf("access token issued before the second factor")
+	}
+	if _, err := tokens.Verify(challenge.TwoFactorToken); err == nil {
+		t.Errorf("two-factor token accepted as an access token")
+	}
+
+	code, err := auth.TOTPCode(testTOTPSecret, time.Now())
+	checkError(err, t)
+	body := fmt.Sprintf(`{"two_factor_token":%q,"code":%q}`, challenge.TwoFactorToken, code)
+	req, err = http.NewRequest("POST", "/authenticate/2fa", bytes.NewReader([]byte(body)))
+	checkError(err, t)
+	rr = httptest.NewRecorder()
+	http.HandlerFunc(UserLogin2FA(mockStore, tokens, guard, false)).
+		ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("handler returned wrong status code: got %v want %v", rr.Code, http.StatusOK)
+	}
+	var resp struct {
+		Token string `json:"token"`
+	}
+	checkError(json.Unmarshal(rr.Body.Bytes(), &resp), t)
+	claims, err := tokens.Verify(resp.Token)
+	checkError(err, t)
+	if claims.UserID != dbUser.ID {
+		t.Errorf("token issued for wrong user: got %v want %v", claims.UserID, dbUser.ID)
+	}
+
+	// The challenge token is still valid, but the code has been used.
+	req, err = http.NewRequest("POST", "/authenticate/2fa", bytes.NewReader([]byte(body)))
+	checkError(err, t)
+	rr = httptest.NewRecorder()
+	http.HandlerFunc(UserLogin2FA(mockStore, tokens, guard, false)).
+		ServeHTTP(rr, req)
+
+	expected := fmt.Sprintf(`{"status":"%s"}`, ErrInvalidCode)
+	if rr.Code != http.StatusUnauthorized || rr.Body.String() != expected {
+		t.Errorf("replayed code: got %v %v want %v %v", rr.Code, rr.Body.String(), http.StatusUnauthorized, expected)
+	}
+}
+
+func TestUserLogin2FA_recovery_code(t *testing.T) {
+	var used string
+	mockStore := &datamock.Mock{
+		UseRecoveryCode_: func(id int, hash string) error {
+			if used == hash {
+				return data.ErrNotFound
+			}
+			used = hash
+			return nil
+		},
+		GetUserByID_: func(id int) (schema.User, error) {
+			return schema.User{ID: id, UserName: "test"}, nil
+		},
+		CreateRefreshToken_: func(rt schema.RefreshToken) (int, error) {
+			return 1, nil
+		},
+	}
+	tokens := auth.NewSigner("secret", time.Minute, time.Hour)
+	token, _, err := tokens.SignPurpose(schema.User{ID: 42, UserName: "test"}, auth.PurposeTwoFactor, time.Minute)
+	checkError(err, t)
+
+	body := fmt.Sprintf(`{"two_factor_token":%q,"recovery_code":"ABCDE-fghij"}`, token)
+	for _, want := range []int{http.StatusOK, http.StatusUnauthorized} {
+		req, err := http.NewRequest("POST", "/authenticate/2fa", bytes.NewReader([]byte(body)))
+		checkError(err, t)
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(UserLogin2FA(mockStore, tokens, testGuard(), false)).
+			ServeHTTP(rr, req)
+		if rr.Code != want {
+			t.Errorf("handler returned wrong status code: got %v want %v", rr.Code, want)
+		}
+	}
+	if used != auth.HashRecoveryCode("abcdefghij") {
+		t.Errorf("recovery code was not normalised before hashing")
+	}
+}
+
+func TestUserLogin2FA_bad_code(t *testing.T) {
+	mockStore := &datamock.Mock{
+		TOTPSecretGet_: func(id int) (string, error) {
+			return testTOTPSecret, nil
+		},
+	}
+	tokens := auth.NewSigner("secret", time.Minute, time.Hour)
+	token, _, err := tokens.SignPurpose(schema.User{ID: 42, UserName: "test"}, auth.PurposeTwoFactor, time.Minute)
+	checkError(err, t)
+
+	guard := testGuard()
+	body := fmt.Sprintf(`{"two_factor_token":%q,"code":"000000"}`, token)
+	codes := []int{}
+	for i := 0; i < 4; i++ {
+		req, err := http.NewRequest("POST", "/authenticate/2fa", bytes.NewReader([]byte(body)))
+		checkError(err, t)
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(UserLogin2FA(mockStore, tokens, guard, false)).
+			ServeHTTP(rr, req)
+		codes = append(codes, rr.Code)
+	}
+	if codes[0] != http.StatusUnauthorized || codes[3] != http.StatusLocked {
+		t.Errorf("expected repeated bad codes to lock the account, got %v", codes)
+	}
+}
+
+func TestTwoFactorEnrollAndConfirm(t *testing.T) {
+	var secret string
+	var hashes []string
+	var lastStep int64
+	mockStore := &datamock.Mock{
+		GetUserByID_: func(id int) (schema.User, error) {
+			return schema.User{ID: id, UserName: "test"}, nil
+		},
+		SetTOTPSecret_: func(id int, s string) error {
+			secret = s
+			return nil
+		},
+		TOTPSecretGet_: func(id int) (string, error) {
+			return secret, nil
+		},
+		EnableTOTP_: func(id int, h []string) error {
+			hashes = h
+			return nil
+		},
+		UseTOTPStep_: useTOTPStep(&lastStep),
+	}
+
+	req, err := http.NewRequest("POST", "/2fa/enroll", nil)
+	checkError(err, t)
+	req = withUser(req, schema.User{ID: 42})
+	rr := httptest.NewRecorder()
+	http.HandlerFunc(TwoFactorEnroll(mockStore, &config.Config{TOTPIssuer: "hhapp"})).
+		ServeHTTP(rr, req)
+
+	var enroll struct {
+		Secret string `json:"secret"`
+		URI    string `json:"uri"`
+	}
+	checkError(json.Unmarshal(rr.Body.Bytes(), &enroll), t)
+	if rr.Code != http.StatusOK || enroll.Secret != secret {
+		t.Fatalf("got %v %v, want the stored secret", rr.Code, rr.Body.String())
+	}
+	if want := "otpauth://totp/hhapp:test?"; enroll.URI[:len(want)] != want {
+		t.Errorf("unexpected provisioning uri %v", enroll.URI)
+	}
+
+	code, err := auth.TOTPCode(secret, time.Now())
+	checkError(err, t)
+	req, err = http.NewRequest("POST", "/2fa/confirm", bytes.NewReader([]byte(fmt.Sprintf(`{"code":%q}`, code))))
+	checkError(err, t)
+	req = withUser(req, schema.User{ID: 42})
+	rr = httptest.NewRecorder()
+	http.HandlerFunc(TwoFactorConfirm(mockStore)).
+		ServeHTTP(rr, req)
+
+	var confirm struct {
+		RecoveryCodes []string `json:"recovery_codes"`
+	}
+	checkError(json.Unmarshal(rr.Body.Bytes(), &confirm), t)
+	if rr.Code != http.StatusOK || len(confirm.RecoveryCodes) != recoveryCodeCount {
+		t.Fatalf("got %v %v, want recovery codes", rr.Code, rr.Body.String())
+	}
+	for i, c := range confirm.RecoveryCodes {
+		if hashes[i] != auth.HashRecoveryCode(c) {
+			t.Errorf("recovery code %v was not stored hashed", i)
+		}
+	}
+}
+
+func TestTwoFactorDisable_requires_code(t *testing.T) {
+	disabled := false
+	mockStore := &datamock.Mock{
+		GetUserByID_: func(id int) (schema.User, error) {
+			return schema.User{ID: id, TwoFactorEnabled: true}, nil
+		},
+		TOTPSecretGet_: func(id int) (string, error) {
+			return testTOTPSecret, nil
+		},
+		DisableTOTP_: func(id int) error {
+			disabled = true
+			return nil
+		},
+	}
+
+	req, err := http.NewRequest("POST", "/2fa/disable", bytes.NewReader([]byte(`{"code":"000000"}`)))
+	checkError(err, t)
+	req = withUser(req, schema.User{ID: 42})
+	rr := httptest.NewRecorder()
+	http.HandlerFunc(TwoFactorDisable(mockStore)).
+		ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusUnauthorized || disabled {
+		t.Errorf("got %v disabled=%v, want %v and 2fa left enabled", rr.Code, disabled, http.StatusUnauthorized)
+	}
+}
@@ -0,0 +1,258 @@
+package route
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/kernkw/hhapp/internal/auth"
+	"github.com/kernkw/hhapp/internal/config"
+	"github.com/kernkw/hhapp/internal/data"
+	"github.com/kernkw/hhapp/internal/mail"
+	"github.com/kernkw/hhapp/internal/schema"
+)
+
+var ErrWrongPassword = errors.New("current password is incorrect")
+
+/*
+Test with this curl command:
+curl -H "Authorization: Bearer $TOKEN" http://localhost:8080/users/me
+*/
+func UserMeGet(db data.Database) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		u, ok := auth.FromContext(r.Context())
+		if !ok {
+			writeError(w, http.StatusUnauthorized, nil)
+			return
+		}
+		user, err := db.GetUserByID(u.ID)
+		if err == data.ErrNotFound {
+			writeError(w, http.StatusNotFound, err)
+			return
+		}
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		type envelope struct {
+			Data schema.User `json:"data"`
+		}
+		writeJSON(w, http.StatusOK, envelope{user})
+	})
+}
+
+/*
+Test with this curl command:
+curl -X PUT -H "Content-Type: application/json" -H "Authorization: Bearer $TOKEN" -d '{"first_name":"Kyle", "last_name": "Kern"}' http://localhost:8080/users/me
+*/
+func UserMeUpdate(db data.Database, tokens *auth.Signer, m mail.Mailer, cfg *config.Config) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		u, ok := auth.FromContext(r.Context())
+		if !ok {
+			writeError(w, http.StatusUnauthorized, nil)
+			return
+		}
+		// Fields left out of the request are unchanged.
+		var req struct {
+			Email     *string `json:"email"`
+			FirstName *string `json:"first_name"`
+			LastName  *string `json:"last_name"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, http.StatusUnprocessableEntity, err)
+			return
+		}
+		defer r.Body.Close()
+
+		user, err := db.GetUserByID(u.ID)
+		if err == data.ErrNotFound {
+			writeError(w, http.StatusNotFound, err)
+			return
+		}
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		emailChanged := false
+		if req.Email != nil {
+			if *req.Email == "" {
+				writeError(w, http.StatusUnprocessableEntity, schema.RequiredFieldError("email"))
+				return
+			}
+			emailChanged = *req.Email != user.Email
+			user.Email = *req.Email
+		}
+		if req.FirstName != nil {
+			user.FirstName = *req.FirstName
+		}
+		if req.LastName != nil {
+			user.LastName = *req.LastName
+		}
+
+		if err := db.UpdateUser(user); err != nil {
+			writeError(w, http.StatusConflict, err)
+			return
+		}
+		if emailChanged {
+			user.EmailVerified = false
+			sendVerificationEmail(m, tokens, cfg, user)
+		}
+
+		type envelope struct {
+			Data schema.User `json:"data"`
+		}
+		writeJSON(w, http.StatusOK, envelope{user})
+	})
+}
+
+/*
+Test with this curl command:
+curl -H "Content-Type: application/json" -H "Authorization: Bearer $TOKEN" -d '{"old_password":"correct-horse-battery", "new_password": "new-horse-battery"}' http://localhost:8080/users/me/password
+*/
+func UserMePassword(db data.Database, tokens *auth.Signer, cfg *config.Config) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		u, ok := auth.FromContext(r.Context())
+		if !ok {
+			writeError(w, http.StatusUnauthorized, nil)
+			return
+		}
+		var req struct {
+			OldPassword string `json:"old_password"`
+			NewPassword string `json:"new_password"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, http.StatusUnprocessableEntity, err)
+			return
+		}
+		defer r.Body.Close()
+		if req.NewPassword == "" {
+			writeError(w, http.StatusUnprocessableEntity, schema.RequiredFieldError("new_password"))
+			return
+		}
+
+		dbuser, err := db.GetUser(schema.User{UserName: u.UserName})
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		old := schema.User{Password: req.OldPassword}
+		if dbuser.ID != u.ID || !old.Authorized(dbuser) {
+			writeError(w, http.StatusUnauthorized, ErrWrongPassword)
+			return
+		}
+
+		policy := passwordPolicy(cfg)
+		if err := policy.CheckField("new_password", req.NewPassword, dbuser); err != nil {
+			writeError(w, http.StatusUnprocessableEntity, err)
+			return
+		}
+		user := schema.User{Password: req.NewPassword}
+		if err := user.HashPassword(policy.Cost); err != nil {
+			writeError(w, http.StatusUnprocessableEntity, err)
+			return
+		}
+		if err := db.UpdateUserPassword(dbuser.ID, user.Password); err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		// Every other session is ended; the caller gets a fresh one.
+		if err := db.RevokeUserRefreshTokens(dbuser.ID); err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		// Both updates bumped the token generation, so the fresh session
+		// has to be issued under the new one.
+		gen, err := db.UserTokenGeneration(dbuser.ID)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		dbuser.Password = ""
+		dbuser.TokenGeneration = gen
+		writeSession(w, db, tokens, dbuser)
+	})
+}
+
+/*
+Test with this curl command:
+curl -X DELETE -H "Authorization: Bearer $TOKEN" http://localhost:8080/users/me
+*/
+func UserMeDelete(db data.Database) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		u, ok := auth.FromContext(r.Context())
+		if !ok {
+			writeError(w, http.StatusUnauthorized, nil)
+			return
+		}
+		err := db.DeleteUser(u.ID)
+		if err == data.ErrNotFound {
+			writeError(w, http.StatusNotFound, err)
+			return
+		}
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		type envelope struct {
+			Status string `json:"status"`
+		}
+		writeJSON(w, http.StatusOK, envelope{http.StatusText(http.StatusOK)})
+	})
+}
+
+/*
+Test with this curl command:
+curl -X PUT -H "Content-Type: application/json" -H "Authorization: Bearer $TOKEN" -d '{"roles":["venue_owner"]}' http://localhost:8080/users/1/roles
+*/
+func UserRolesUpdate(db data.Database) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(mux.Vars(r)["id"])
+		if err != nil {
+			writeError(w, http.StatusUnprocessableEntity, err)
+			return
+		}
+		var req struct {
+			Roles []string `json:"roles"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, http.StatusUnprocessableEntity, err)
+			return
+		}
+		defer r.Body.Close()
+
+		roles := []schema.Role{}
+		for _, name := range req.Roles {
+			role, err := schema.ParseRole(name)
+			if err != nil {
+				writeError(w, http.StatusUnprocessableEntity, err)
+				return
+			}
+			roles = append(roles, role)
+		}
+		if len(roles) == 0 {
+			roles = append(roles, schema.RolePatron)
+		}
+
+		err = db.UpdateUserRoles(id, roles)
+		if err == data.ErrNotFound {
+			writeError(w, http.StatusNotFound, err)
+			return
+		}
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		type envelope struct {
+			Status string        `json:"status"`
+			Roles  []schema.Role `json:"roles"`
+		}
+		writeJSON(w, http.StatusOK, envelope{http.StatusText(http.StatusOK), roles})
+	})
+}
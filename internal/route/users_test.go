@@ -0,0 +1,247 @@
+package route
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/kernkw/hhapp/internal/auth"
+	"github.com/kernkw/hhapp/internal/config"
+	"github.com/kernkw/hhapp/internal/data/datamock"
+	"github.com/kernkw/hhapp/internal/schema"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestUserRolesUpdate(t *testing.T) {
+	var gotID int
+	var gotRoles []schema.Role
+	mockStore := &datamock.Mock{
+		UpdateUserRoles_: func(id int, roles []schema.Role) error {
+			gotID, gotRoles = id, roles
+			return nil
+		},
+	}
+
+	req, err := http.NewRequest("PUT", "/users/7/roles", bytes.NewReader([]byte(`{"roles":["Venue_Owner","patron"]}`)))
+	checkError(err, t)
+
+	rr := httptest.NewRecorder()
+
+	router := mux.NewRouter()
+	router.Handle("/users/{id:[0-9]+}/roles", UserRolesUpdate(mockStore))
+	router.ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusOK {
+		t.Errorf("handler returned wrong status code: got %v want %v",
+			status, http.StatusOK)
+	}
+	want := []schema.Role{schema.RoleVenueOwner, schema.RolePatron}
+	if gotID != 7 || !reflect.DeepEqual(gotRoles, want) {
+		t.Errorf("wrong roles stored: got %v %v want %v %v", gotID, gotRoles, 7, want)
+	}
+}
+
+func TestUserRolesUpdate_unknown_role(t *testing.T) {
+	req, err := http.NewRequest("PUT", "/users/7/roles", bytes.NewReader([]byte(`{"roles":["superuser"]}`)))
+	checkError(err, t)
+
+	rr := httptest.NewRecorder()
+
+	router := mux.NewRouter()
+	router.Handle("/users/{id:[0-9]+}/roles", UserRolesUpdate(&datamock.Mock{}))
+	router.ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusUnprocessableEntity {
+		t.Errorf("handler returned wrong status code: got %v want %v",
+			status, http.StatusUnprocessableEntity)
+	}
+}
+
+func TestUserMeGet(t *testing.T) {
+	mockStore := &datamock.Mock{
+		GetUserByID_: func(id int) (schema.User, error) {
+			return schema.User{ID: id, UserName: "test", Email: "test@domain.com", FirstName: "Test"}, nil
+		},
+	}
+
+	req, err := http.NewRequest("GET", "/users/me", nil)
+	checkError(err, t)
+	req = withUser(req, schema.User{ID: 42})
+	rr := httptest.NewRecorder()
+
+	http.HandlerFunc(UserMeGet(mockStore)).
+		ServeHTTP(rr, req)
+
+	expected := `{"data":{"id":42,"username":"test","email":"test@domain.com","email_verified":false,"two_factor_enabled":false,"first_name":"Test"}}`
+	if rr.Code != http.StatusOK || rr.Body.String() != expected {
+		t.Errorf("got %v %v want %v %v", rr.Code, rr.Body.String(), http.StatusOK, expected)
+	}
+}
+
+func TestUserMeUpdate(t *testing.T) {
+	outbox, cleanup := newOutbox(t)
+	defer cleanup()
+
+	var got schema.User
+	mockStore := &datamock.Mock{
+		GetUserByID_: func(id int) (schema.User, error) {
+			return schema.User{ID: id, UserName: "test", Email: "test@domain.com", EmailVerified: true, FirstName: "Old", LastName: "Name"}, nil
+		},
+		UpdateUser_: func(user schema.User) error {
+			got = user
+			return nil
+		},
+	}
+
+	req, err := http.NewRequest("PUT", "/users/me", bytes.NewReader([]byte(`{"first_name":"New"}`)))
+	checkError(err, t)
+	req = withUser(req, schema.User{ID: 42})
+	rr := httptest.NewRecorder()
+
+	http.HandlerFunc(UserMeUpdate(mockStore, auth.NewSigner("secret", time.Minute, time.Hour), outbox, &config.Config{})).
+		ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusOK {
+		t.Errorf("handler returned wrong status code: got %v want %v",
+			status, http.StatusOK)
+	}
+	if got.FirstName != "New" || got.LastName != "Name" || got.Email != "test@domain.com" {
+		t.Errorf("unexpected update: %+v", got)
+	}
+	msgs, err := outbox.Messages()
+	checkError(err, t)
+	if len(msgs) != 0 {
+		t.Errorf("verification sent although email did not change")
+	}
+}
+
+func TestUserMeUpdate_email(t *testing.T) {
+	outbox, cleanup := newOutbox(t)
+	defer cleanup()
+
+	mockStore := &datamock.Mock{
+		GetUserByID_: func(id int) (schema.User, error) {
+			return schema.User{ID: id, UserName: "test", Email: "test@domain.com", EmailVerified: true}, nil
+		},
+		UpdateUser_: func(user schema.User) error {
+			return nil
+		},
+	}
+
+	req, err := http.NewRequest("PUT", "/users/me", bytes.NewReader([]byte(`{"email":"new@domain.com"}`)))
+	checkError(err, t)
+	req = withUser(req, schema.User{ID: 42})
+	rr := httptest.NewRecorder()
+
+	http.HandlerFunc(UserMeUpdate(mockStore, auth.NewSigner("secret", time.Minute, time.Hour), outbox, &config.Config{})).
+		ServeHTTP(rr, req)
+
+	expected := `{"data":{"id":42,"username":"test","email":"new@domain.com","email_verified":false,"two_factor_enabled":false}}`
+	if rr.Code != http.StatusOK || rr.Body.String() != expected {
+		t.Errorf("got %v %v want %v %v", rr.Code, rr.Body.String(), http.StatusOK, expected)
+	}
+	msgs, err := outbox.Messages()
+	checkError(err, t)
+	if len(msgs) != 1 || msgs[0].To != "new@domain.com" {
+		t.Errorf("expected a verification email to the new address, got %+v", msgs)
+	}
+}
+
+func TestUserMePassword(t *testing.T) {
+	dbUser := schema.User{ID: 42, UserName: "test", Password: "password"}
+	checkError(dbUser.HashPassword(0), t)
+
+	var stored string
+	revoked := 0
+	mockStore := &datamock.Mock{
+		GetUser_: func(user schema.User) (schema.User, error) {
+			return dbUser, nil
+		},
+		UpdateUserPassword_: func(userID int, password string) error {
+			stored = password
+			return nil
+		},
+		RevokeUserRefreshTokens_: func(userID int) error {
+			revoked = userID
+			return nil
+		},
+		UserTokenGeneration_: func(userID int) (int, error) {
+			return 5, nil
+		},
+		CreateRefreshToken_: func(rt schema.RefreshToken) (int, error) {
+			return 1, nil
+		},
+	}
+	tokens := auth.NewSigner("secret", time.Minute, time.Hour)
+	var issued string
+
+	tests := []struct {
+		body string
+		want int
+	}{
+		{`{"old_password":"wrong","new_password":"new-password"}`, http.StatusUnauthorized},
+		{`{"old_password":"password","new_password":""}`, http.StatusUnprocessableEntity},
+		{`{"old_password":"password","new_password":"new-password"}`, http.StatusOK},
+	}
+	for _, tt := range tests {
+		req, err := http.NewRequest("POST", "/users/me/password", bytes.NewReader([]byte(tt.body)))
+		checkError(err, t)
+		req = withUser(req, schema.User{ID: 42, UserName: "test"})
+		rr := httptest.NewRecorder()
+
+		http.HandlerFunc(UserMePassword(mockStore, tokens, &config.Config{})).
+			ServeHTTP(rr, req)
+
+		if rr.Code != tt.want {
+			t.Errorf("%s: got status %v want %v", tt.body, rr.Code, tt.want)
+		}
+		if rr.Code == http.StatusOK {
+			var resp struct {
+				Token string `json:"token"`
+			}
+			checkError(json.Unmarshal(rr.Body.Bytes(), &resp), t)
+			issued = resp.Token
+		}
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(stored), []byte("new-password")); err != nil {
+		t.Errorf("new password not stored: %v", err)
+	}
+	if revoked != 42 {
+		t.Errorf("other sessions not revoked")
+	}
+	// The caller's new token must survive the revocation it triggered.
+	if claims, err := tokens.Verify(issued); err != nil || claims.Generation != 5 {
+		t.Errorf("new session issued under the old token generation: %+v %v", claims, err)
+	}
+}
+
+func TestUserMeDelete(t *testing.T) {
+	deleted := 0
+	mockStore := &datamock.Mock{
+		DeleteUser_: func(id int) error {
+			deleted = id
+			return nil
+		},
+	}
+
+	req, err := http.NewRequest("DELETE", "/users/me", nil)
+	checkError(err, t)
+	req = withUser(req, schema.User{ID: 42})
+	rr := httptest.NewRecorder()
+
+	http.HandlerFunc(UserMeDelete(mockStore)).
+		ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusOK {
+		t.Errorf("handler returned wrong status code: got %v want %v",
+			status, http.StatusOK)
+	}
+	if deleted != 42 {
+		t.Errorf("wrong user deleted: got %v want %v", deleted, 42)
+	}
+}
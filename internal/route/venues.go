@@ -0,0 +1,299 @@
+package route
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/kernkw/hhapp/internal/auth"
+	"github.com/kernkw/hhapp/internal/data"
+	"github.com/kernkw/hhapp/internal/geo"
+	"github.com/kernkw/hhapp/internal/schema"
+)
+
+// venuePatch holds the venue fields a client sent. Fields left out of the
+// request are unchanged.
+type venuePatch struct {
+	Name     *string       `json:"name"`
+	Address  *string       `json:"address"`
+	Address2 *string       `json:"address2"`
+	City     *string       `json:"city"`
+	State    *string       `json:"state"`
+	Zip      *string       `json:"zip"`
+	Country  *string       `json:"country"`
+	Image    *string       `json:"image"`
+	OwnerID  *int          `json:"owner_id"`
+	Location *schema.Point `json:"location"`
+	TimeZone *string       `json:"time_zone"`
+}
+
+const (
+	defaultNearbyRadiusKM = 5
+	maxNearbyRadiusKM     = 100
+)
+
+var (
+	ErrInvalidRadius   = errors.New("radius must be greater than 0 and at most 100 km")
+	ErrInvalidTimeZone = errors.New("time_zone must be an IANA time zone such as America/Denver")
+)
+
+// apply copies the fields set in p onto v.
+func (p venuePatch) apply(v *schema.Venue) {
+	for _, f := range []struct {
+		src *string
+		dst *string
+	}{
+		{p.Name, &v.Name},
+		{p.Address, &v.Address},
+		{p.Address2, &v.Address2},
+		{p.City, &v.City},
+		{p.State, &v.State},
+		{p.Zip, &v.Zip},
+		{p.Country, &v.Country},
+		{p.Image, &v.Image},
+	} {
+		if f.src != nil {
+			*f.dst = *f.src
+		}
+	}
+	if p.OwnerID != nil {
+		v.OwnerID = *p.OwnerID
+	}
+	if p.Location != nil {
+		v.Location = p.Location
+	}
+	if p.TimeZone != nil {
+		v.TimeZone = *p.TimeZone
+	}
+}
+
+// moves reports whether applying p changes where v is.
+func (p venuePatch) moves(v schema.Venue) bool {
+	for _, f := range []struct {
+		src *string
+		cur string
+	}{
+		{p.Address, v.Address},
+		{p.Address2, v.Address2},
+		{p.City, v.City},
+		{p.State, v.State},
+		{p.Zip, v.Zip},
+		{p.Country, v.Country},
+	} {
+		if f.src != nil && *f.src != f.cur {
+			return true
+		}
+	}
+	return false
+}
+
+// checkTimeZone rejects time zones the server doesn't know. An empty zone
+// is defaulted from the venue's address.
+func checkTimeZone(name string) error {
+	if name == "" {
+		return nil
+	}
+	if _, err := geo.LoadLocation(name); err != nil || name == "Local" {
+		return ErrInvalidTimeZone
+	}
+	return nil
+}
+
+// locate sets venue.Location from its address. A venue the geocoder can't
+// place is saved without coordinates rather than refused.
+func locate(g geo.Geocoder, venue *schema.Venue) {
+	p, err := g.Geocode(*venue)
+	if err != nil {
+		if err != geo.ErrNoMatch {
+			log.Println("failed to geocode venue:", err)
+		}
+		venue.Location = nil
+		return
+	}
+	venue.Location = &p
+}
+
+/*
+Test with this curl command:
+curl -X PATCH -H "Content-Type: application/json" -H "Authorization: Bearer $TOKEN" -d '{"address": "909 17th St"}' http://localhost:8080/venues/1
+*/
+func VenueUpdate(db data.Database, g geo.Geocoder, zones *geo.TimeZones) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(mux.Vars(r)["id"])
+		if err != nil {
+			writeError(w, http.StatusUnprocessableEntity, err)
+			return
+		}
+		var patch venuePatch
+		if err := json.NewDecoder(r.Body).Decode(&patch); err != nil {
+			writeError(w, http.StatusUnprocessableEntity, err)
+			return
+		}
+		defer r.Body.Close()
+
+		venue, ok := authorizeVenue(w, r, db, id)
+		if !ok {
+			return
+		}
+		// Only admins hand venues between owners.
+		user, _ := auth.FromContext(r.Context())
+		if patch.OwnerID != nil && *patch.OwnerID != venue.OwnerID && !user.HasRole(schema.RoleAdmin) {
+			writeError(w, http.StatusForbidden, ErrForbidden)
+			return
+		}
+		moved := patch.moves(venue)
+		patch.apply(&venue)
+		if venue.Name == "" {
+			writeError(w, http.StatusUnprocessableEntity, schema.RequiredFieldError("name"))
+			return
+		}
+		// Coordinates sent by the client win; otherwise the venue is
+		// relocated when its address changes or it was never placed.
+		if patch.Location != nil {
+			if err := patch.Location.Validate(); err != nil {
+				writeError(w, http.StatusUnprocessableEntity, err)
+				return
+			}
+		} else if moved || venue.Location == nil {
+			locate(g, &venue)
+		}
+		if patch.TimeZone != nil {
+			if err := checkTimeZone(*patch.TimeZone); err != nil {
+				writeError(w, http.StatusUnprocessableEntity, err)
+				return
+			}
+		} else if moved || patch.Location != nil {
+			venue.TimeZone = ""
+		}
+		if venue.TimeZone == "" {
+			venue.TimeZone = zones.Zone(venue)
+		}
+
+		err = db.UpdateVenue(venue)
+		if err == data.ErrNotFound {
+			writeError(w, http.StatusNotFound, err)
+			return
+		}
+		if err != nil {
+			writeError(w, http.StatusConflict, err)
+			return
+		}
+
+		type envelope struct {
+			Data schema.Venue `json:"data"`
+		}
+		writeJSON(w, http.StatusOK, envelope{venue})
+	})
+}
+
+/*
+Test with this curl command:
+curl -X DELETE -H "Authorization: Bearer $TOKEN" http://localhost:8080/venues/1
+*/
+func VenueDelete(db data.Database) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(mux.Vars(r)["id"])
+		if err != nil {
+			writeError(w, http.StatusUnprocessableEntity, err)
+			return
+		}
+		if _, ok := authorizeVenue(w, r, db, id); !ok {
+			return
+		}
+		user, _ := auth.FromContext(r.Context())
+
+		err = db.DeleteVenue(id, user.ID)
+		if err == data.ErrNotFound {
+			writeError(w, http.StatusNotFound, err)
+			return
+		}
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		type envelope struct {
+			Status string `json:"status"`
+		}
+		writeJSON(w, http.StatusOK, envelope{http.StatusText(http.StatusOK)})
+	})
+}
+
+// nearbyQuery reads the lat, lng and optional radius parameters of a
+// nearby search.
+func nearbyQuery(q url.Values) (schema.Point, float64, error) {
+	lat, err := strconv.ParseFloat(q.Get("lat"), 64)
+	if err != nil {
+		return schema.Point{}, 0, schema.RequiredFieldError("lat")
+	}
+	lng, err := strconv.ParseFloat(q.Get("lng"), 64)
+	if err != nil {
+		return schema.Point{}, 0, schema.RequiredFieldError("lng")
+	}
+	center := schema.Point{Lat: lat, Lng: lng}
+	if err := center.Validate(); err != nil {
+		return schema.Point{}, 0, err
+	}
+	radius := float64(defaultNearbyRadiusKM)
+	if v := q.Get("radius"); v != "" {
+		radius, err = strconv.ParseFloat(v, 64)
+		if err != nil || radius <= 0 || radius > maxNearbyRadiusKM {
+			return schema.Point{}, 0, ErrInvalidRadius
+		}
+	}
+	return center, radius, nil
+}
+
+/*
+Test with this curl command:
+curl "http://localhost:8080/venues/nearby?lat=39.7508&lng=-104.9966&radius=2&category=food"
+*/
+func VenuesNearby(db data.Database) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		center, radius, err := nearbyQuery(q)
+		if err != nil {
+			writeError(w, http.StatusUnprocessableEntity, err)
+			return
+		}
+		category, err := categoryQuery(q)
+		if err != nil {
+			writeError(w, http.StatusUnprocessableEntity, err)
+			return
+		}
+
+		min, max := geo.Bounds(center, radius)
+		venues, err := db.Venues(schema.VenueFilter{Min: &min, Max: &max, Category: category})
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		type nearbyVenue struct {
+			schema.Venue
+			DistanceKM float64 `json:"distance_km"`
+		}
+		nearby := []nearbyVenue{}
+		for _, v := range venues {
+			if v.Location == nil {
+				continue
+			}
+			if d := geo.Distance(center, *v.Location); d <= radius {
+				nearby = append(nearby, nearbyVenue{v, d})
+			}
+		}
+		sort.Slice(nearby, func(i, j int) bool {
+			return nearby[i].DistanceKM < nearby[j].DistanceKM
+		})
+
+		type envelope struct {
+			Data []nearbyVenue `json:"data"`
+		}
+		writeJSON(w, http.StatusOK, envelope{nearby})
+	})
+}
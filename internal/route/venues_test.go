@@ -0,0 +1,410 @@
+package route
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/kernkw/hhapp/internal/data"
+	"github.com/kernkw/hhapp/internal/data/datamock"
+	"github.com/kernkw/hhapp/internal/geo"
+	"github.com/kernkw/hhapp/internal/schema"
+)
+
+var (
+	testOwner = schema.User{ID: 42, Roles: []schema.Role{schema.RoleVenueOwner}}
+	testAdmin = schema.User{ID: 1, Roles: []schema.Role{schema.RoleAdmin}}
+)
+
+const testGazetteer = `country,state,city,zip,lat,lng
+us,co,denver,80202,39.7528,-104.9997
+us,co,denver,,39.7392,-104.9903
+us,co,boulder,,40.0150,-105.2705
+`
+
+func testGeocoder(t *testing.T) geo.Geocoder {
+	g, err := geo.ReadGazetteer(strings.NewReader(testGazetteer))
+	checkError(err, t)
+	return g
+}
+
+// noGeocoder places nothing, leaving venues without coordinates.
+type noGeocoder struct{}
+
+func (noGeocoder) Geocode(v schema.Venue) (schema.Point, error) {
+	return schema.Point{}, geo.ErrNoMatch
+}
+
+func testVenue(id int) schema.Venue {
+	return schema.Venue{ID: id, Name: "Panzano", Address: "909 17th St", City: "Denver", State: "CO", Country: "USA", OwnerID: 42}
+}
+
+func TestVenueUpdate_patch(t *testing.T) {
+	var stored schema.Venue
+	mockStore := &datamock.Mock{
+		VenueGet_: func(v schema.Venue) (schema.Venue, error) {
+			return testVenue(v.ID), nil
+		},
+		UpdateVenue_: func(v schema.Venue) error {
+			stored = v
+			return nil
+		},
+	}
+
+	req, err := http.NewRequest("PATCH", "/venues/5", bytes.NewReader([]byte(`{"address":"1 Larimer Sq","address2":""}`)))
+	checkError(err, t)
+	req = withUser(req, testOwner)
+	rr := httptest.NewRecorder()
+
+	router := mux.NewRouter()
+	router.Handle("/venues/{id:[0-9]+}", VenueUpdate(mockStore, noGeocoder{}, nil))
+	router.ServeHTTP(rr, req)
+
+	expected := `{"data":{"id":5,"name":"Panzano","address":"1 Larimer Sq","address2":"","city":"Denver","state":"CO","zip":"","country":"USA","image":"","owner_id":42}}`
+	if rr.Code != http.StatusOK || rr.Body.String() != expected {
+		t.Errorf("got %v %v want %v %v", rr.Code, rr.Body.String(), http.StatusOK, expected)
+	}
+	want := testVenue(5)
+	want.Address = "1 Larimer Sq"
+	if stored != want {
+		t.Errorf("stored %+v want %+v", stored, want)
+	}
+}
+
+func TestVenueUpdate_forbidden(t *testing.T) {
+	tests := []struct {
+		user schema.User
+		body string
+		want int
+	}{
+		{schema.User{ID: 43, Roles: []schema.Role{schema.RoleVenueOwner}}, `{"name":"Mine now"}`, http.StatusForbidden},
+		{testOwner, `{"owner_id":43}`, http.StatusForbidden},
+		{testOwner, `{"name":""}`, http.StatusUnprocessableEntity},
+		{testAdmin, `{"owner_id":43}`, http.StatusOK},
+	}
+	for _, tt := range tests {
+		mockStore := &datamock.Mock{
+			VenueGet_: func(v schema.Venue) (schema.Venue, error) {
+				return testVenue(v.ID), nil
+			},
+			UpdateVenue_: func(v schema.Venue) error {
+				return nil
+			},
+		}
+
+		req, err := http.NewRequest("PUT", "/venues/5", bytes.NewReader([]byte(tt.body)))
+		checkError(err, t)
+		req = withUser(req, tt.user)
+		rr := httptest.NewRecorder()
+
+		router := mux.NewRouter()
+		router.Handle("/venues/{id:[0-9]+}", VenueUpdate(mockStore, noGeocoder{}, nil))
+		router.ServeHTTP(rr, req)
+
+		if rr.Code != tt.want {
+			t.Errorf("user %v %s: got status %v want %v", tt.user.ID, tt.body, rr.Code, tt.want)
+		}
+	}
+}
+
+func TestVenueDelete(t *testing.T) {
+	deleted, by := 0, 0
+	mockStore := &datamock.Mock{
+		VenueGet_: func(v schema.Venue) (schema.Venue, error) {
+			if v.ID != 5 {
+				return schema.Venue{}, data.ErrNotFound
+			}
+			return testVenue(v.ID), nil
+		},
+		DeleteVenue_: func(id, userID int) error {
+			deleted, by = id, userID
+			return nil
+		},
+	}
+
+	tests := []struct {
+		path string
+		want int
+	}{
+		{"/venues/6", http.StatusNotFound},
+		{"/venues/5", http.StatusOK},
+	}
+	for _, tt := range tests {
+		req, err := http.NewRequest("DELETE", tt.path, nil)
+		checkError(err, t)
+		req = withUser(req, testOwner)
+		rr := httptest.NewRecorder()
+
+		router := mux.NewRouter()
+		router.Handle("/venues/{id:[0-9]+}", VenueDelete(mockStore))
+		router.ServeHTTP(rr, req)
+
+		if rr.Code != tt.want {
+			t.Errorf("%v: got status %v want %v", tt.path, rr.Code, tt.want)
+		}
+	}
+	if deleted != 5 || by != testOwner.ID {
+		t.Errorf("venue was not deleted by its owner: got venue %v user %v", deleted, by)
+	}
+}
+
+func TestVenueCreate_geocodes(t *testing.T) {
+	tests := []struct {
+		body string
+		want *schema.Point
+	}{
+		{`{"name":"Panzano","city":"Denver","state":"CO","zip":"80202","country":"USA"}`, &schema.Point{Lat: 39.7528, Lng: -104.9997}},
+		{`{"name":"Panzano","city":"Denver","state":"CO","zip":"80299","country":"USA"}`, &schema.Point{Lat: 39.7392, Lng: -104.9903}},
+		{`{"name":"Panzano","city":"Denver","state":"CO","location":{"lat":39.5,"lng":-105}}`, &schema.Point{Lat: 39.5, Lng: -105}},
+		{`{"name":"Panzano","city":"Nowhere","state":"CO"}`, nil},
+	}
+	for _, tt := range tests {
+		var got schema.Venue
+		mockStore := &datamock.Mock{
+			CreateVenue_: func(v schema.Venue) (int, error) {
+				got = v
+				return 1, nil
+			},
+			CreateMenu_: func(m schema.Menu, userID int) (int, error) {
+				return 1, nil
+			},
+		}
+
+		req, err := http.NewRequest("POST", "/create_venue", bytes.NewReader([]byte(tt.body)))
+		checkError(err, t)
+		req = withUser(req, testOwner)
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(VenueCreate(mockStore, testGeocoder(t), nil)).
+			ServeHTTP(rr, req)
+
+		if rr.Code != http.StatusCreated {
+			t.Errorf("%s: got status %v want %v", tt.body, rr.Code, http.StatusCreated)
+		}
+		if !reflect.DeepEqual(got.Location, tt.want) {
+			t.Errorf("%s: got location %v want %v", tt.body, got.Location, tt.want)
+		}
+	}
+}
+
+func TestVenueUpdate_geocodes(t *testing.T) {
+	located := testVenue(5)
+	located.Location = &schema.Point{Lat: 1, Lng: 1}
+	tests := []struct {
+		stored schema.Venue
+		body   string
+		want   *schema.Point
+	}{
+		// Moving the venue relocates it.
+		{located, `{"city":"Boulder"}`, &schema.Point{Lat: 40.0150, Lng: -105.2705}},
+		// An address the geocoder can't place clears stale coordinates.
+		{located, `{"city":"Nowhere"}`, nil},
+		// Other edits leave the coordinates alone.
+		{located, `{"name":"Panzano Denver"}`, located.Location},
+		// A venue that was never placed is geocoded on any edit.
+		{testVenue(5), `{"name":"Panzano Denver"}`, &schema.Point{Lat: 39.7392, Lng: -104.9903}},
+		// Explicit coordinates win over the address.
+		{located, `{"city":"Boulder","location":{"lat":2,"lng":2}}`, &schema.Point{Lat: 2, Lng: 2}},
+	}
+	for _, tt := range tests {
+		var stored schema.Venue
+		mockStore := &datamock.Mock{
+			VenueGet_: func(v schema.Venue) (schema.Venue, error) {
+				return tt.stored, nil
+			},
+			UpdateVenue_: func(v schema.Venue) error {
+				stored = v
+				return nil
+			},
+		}
+
+		req, err := http.NewRequest("PATCH", "/venues/5", bytes.NewReader([]byte(tt.body)))
+		checkError(err, t)
+		req = withUser(req, testOwner)
+		rr := httptest.NewRecorder()
+
+		router := mux.NewRouter()
+		router.Handle("/venues/{id:[0-9]+}", VenueUpdate(mockStore, testGeocoder(t), nil))
+		router.ServeHTTP(rr, req)
+
+		if rr.Code != http.StatusOK {
+			t.Errorf("%s: got status %v want %v", tt.body, rr.Code, http.StatusOK)
+		}
+		if !reflect.DeepEqual(stored.Location, tt.want) {
+			t.Errorf("%s: got location %v want %v", tt.body, stored.Location, tt.want)
+		}
+	}
+}
+
+func TestVenueUpdate_invalid_location(t *testing.T) {
+	mockStore := &datamock.Mock{
+		VenueGet_: func(v schema.Venue) (schema.Venue, error) {
+			return testVenue(v.ID), nil
+		},
+	}
+
+	req, err := http.NewRequest("PATCH", "/venues/5", bytes.NewReader([]byte(`{"location":{"lat":91,"lng":0}}`)))
+	checkError(err, t)
+	req = withUser(req, testOwner)
+	rr := httptest.NewRecorder()
+
+	router := mux.NewRouter()
+	router.Handle("/venues/{id:[0-9]+}", VenueUpdate(mockStore, testGeocoder(t), nil))
+	router.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusUnprocessableEntity {
+		t.Errorf("got status %v want %v", rr.Code, http.StatusUnprocessableEntity)
+	}
+}
+
+func TestVenuesNearby(t *testing.T) {
+	union := schema.Point{Lat: 39.7528, Lng: -104.9997}
+	var filter schema.VenueFilter
+	mockStore := &datamock.Mock{
+		Venues_: func(f schema.VenueFilter) ([]schema.Venue, error) {
+			filter = f
+			return []schema.Venue{
+				{ID: 1, Name: "Far", Location: &schema.Point{Lat: 39.7392, Lng: -104.9903}},
+				{ID: 2, Name: "Corner", Location: &schema.Point{Lat: 39.7780, Lng: -105.0330}},
+				{ID: 3, Name: "Close", Location: &schema.Point{Lat: 39.7530, Lng: -105.0000}},
+			}, nil
+		},
+	}
+
+	path := fmt.Sprintf("/venues/nearby?lat=%v&lng=%v&radius=3&category=Drink", union.Lat, union.Lng)
+	req, err := http.NewRequest("GET", path, nil)
+	checkError(err, t)
+	rr := httptest.NewRecorder()
+	http.HandlerFunc(VenuesNearby(mockStore)).
+		ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("handler returned wrong status code: got %v want %v", rr.Code, http.StatusOK)
+	}
+	if min, max := geo.Bounds(union, 3); filter.Min == nil || filter.Max == nil || *filter.Min != min || *filter.Max != max {
+		t.Errorf("queried bounds %v %v want %v %v", filter.Min, filter.Max, min, max)
+	}
+	if filter.Category != schema.CategoryDrink {
+		t.Errorf("queried category %q want %q", filter.Category, schema.CategoryDrink)
+	}
+	var resp struct {
+		Data []struct {
+			ID         int     `json:"id"`
+			DistanceKM float64 `json:"distance_km"`
+		} `json:"data"`
+	}
+	checkError(json.Unmarshal(rr.Body.Bytes(), &resp), t)
+	// Corner is inside the bounding box but more than 3km away.
+	if len(resp.Data) != 2 || resp.Data[0].ID != 3 || resp.Data[1].ID != 1 {
+		t.Fatalf("got %v, want Close then Far", rr.Body.String())
+	}
+	if resp.Data[0].DistanceKM > resp.Data[1].DistanceKM {
+		t.Errorf("venues not sorted by distance: %v", rr.Body.String())
+	}
+}
+
+func TestVenuesNearby_bad_query(t *testing.T) {
+	for _, q := range []string{"", "lat=39.7&lng=", "lat=91&lng=0", "lat=39.7&lng=-105&radius=0", "lat=39.7&lng=-105&radius=500", "lat=39.7&lng=-105&category=beer"} {
+		req, err := http.NewRequest("GET", "/venues/nearby?"+q, nil)
+		checkError(err, t)
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(VenuesNearby(&datamock.Mock{})).
+			ServeHTTP(rr, req)
+
+		if rr.Code != http.StatusUnprocessableEntity {
+			t.Errorf("%q: got status %v want %v", q, rr.Code, http.StatusUnprocessableEntity)
+		}
+	}
+}
+
+const testTimeZones = `country,state,zone,lat,lng
+us,co,America/Denver,39.7392,-104.9903
+us,tx,America/Chicago,29.7604,-95.3698
+us,tx,America/Denver,31.7619,-106.4850
+`
+
+func TestVenueCreate_time_zone(t *testing.T) {
+	zones, err := geo.ReadTimeZones(strings.NewReader(testTimeZones))
+	checkError(err, t)
+	tests := []struct {
+		body string
+		code int
+		want string
+	}{
+		{`{"name":"Panzano","city":"Denver","state":"CO","country":"USA"}`, http.StatusCreated, "America/Denver"},
+		{`{"name":"Panzano","city":"El Paso","state":"TX","country":"USA","location":{"lat":31.8,"lng":-106.4}}`, http.StatusCreated, "America/Denver"},
+		{`{"name":"Panzano","city":"Austin","state":"TX","country":"USA"}`, http.StatusCreated, "America/Chicago"},
+		{`{"name":"Panzano","city":"Denver","state":"CO","time_zone":"America/New_York"}`, http.StatusCreated, "America/New_York"},
+		{`{"name":"Panzano","city":"Denver","state":"CO","time_zone":"Mountain"}`, http.StatusUnprocessableEntity, ""},
+		{`{"name":"Panzano","city":"Paris","country":"FR"}`, http.StatusCreated, ""},
+	}
+	for _, tt := range tests {
+		var got schema.Venue
+		mockStore := &datamock.Mock{
+			CreateVenue_: func(v schema.Venue) (int, error) {
+				got = v
+				return 1, nil
+			},
+			CreateMenu_: func(m schema.Menu, userID int) (int, error) {
+				return 1, nil
+			},
+		}
+
+		req, err := http.NewRequest("POST", "/create_venue", bytes.NewReader([]byte(tt.body)))
+		checkError(err, t)
+		req = withUser(req, testOwner)
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(VenueCreate(mockStore, noGeocoder{}, zones)).
+			ServeHTTP(rr, req)
+
+		if rr.Code != tt.code || got.TimeZone != tt.want {
+			t.Errorf("%s: got %v %q want %v %q", tt.body, rr.Code, got.TimeZone, tt.code, tt.want)
+		}
+	}
+}
+
+func TestVenueUpdate_time_zone(t *testing.T) {
+	zones, err := geo.ReadTimeZones(strings.NewReader(testTimeZones))
+	checkError(err, t)
+	denver := testVenue(5)
+	denver.TimeZone = "America/Denver"
+	tests := []struct {
+		body string
+		want string
+	}{
+		{`{"name":"Panzano Denver"}`, "America/Denver"},
+		{`{"city":"Houston","state":"TX"}`, "America/Chicago"},
+		{`{"time_zone":"America/Phoenix"}`, "America/Phoenix"},
+		{`{"time_zone":""}`, "America/Denver"},
+	}
+	for _, tt := range tests {
+		var stored schema.Venue
+		mockStore := &datamock.Mock{
+			VenueGet_: func(v schema.Venue) (schema.Venue, error) {
+				return denver, nil
+			},
+			UpdateVenue_: func(v schema.Venue) error {
+				stored = v
+				return nil
+			},
+		}
+
+		req, err := http.NewRequest("PATCH", "/venues/5", bytes.NewReader([]byte(tt.body)))
+		checkError(err, t)
+		req = withUser(req, testOwner)
+		rr := httptest.NewRecorder()
+
+		router := mux.NewRouter()
+		router.Handle("/venues/{id:[0-9]+}", VenueUpdate(mockStore, noGeocoder{}, zones))
+		router.ServeHTTP(rr, req)
+
+		if rr.Code != http.StatusOK || stored.TimeZone != tt.want {
+			t.Errorf("%s: got %v %q want %v %q", tt.body, rr.Code, stored.TimeZone, http.StatusOK, tt.want)
+		}
+	}
+}
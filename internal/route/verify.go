@@ -0,0 +1,95 @@
+package route
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+
+	"github.com/kernkw/hhapp/internal/auth"
+	"github.com/kernkw/hhapp/internal/config"
+	"github.com/kernkw/hhapp/internal/data"
+	"github.com/kernkw/hhapp/internal/mail"
+	"github.com/kernkw/hhapp/internal/schema"
+)
+
+/*
+Test with this curl command:
+curl -H "Content-Type: application/json" http://localhost:8080/verify_email?token=...
+*/
+func VerifyEmail(db data.Database, tokens *auth.Signer) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		claims, err := tokens.VerifyPurpose(r.URL.Query().Get("token"), auth.PurposeVerifyEmail)
+		if err != nil {
+			writeError(w, http.StatusUnprocessableEntity, err)
+			return
+		}
+
+		err = db.VerifyUserEmail(claims.UserID, claims.Email)
+		if err == data.ErrNotFound {
+			// The account is gone or its email changed since the link was sent.
+			writeError(w, http.StatusUnprocessableEntity, auth.ErrInvalidToken)
+			return
+		}
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		type envelope struct {
+			Status string `json:"status"`
+		}
+		writeJSON(w, http.StatusOK, envelope{http.StatusText(http.StatusOK)})
+	})
+}
+
+/*
+Test with this curl command:
+curl -X POST -H "Authorization: Bearer $TOKEN" http://localhost:8080/verify_email/resend
+*/
+func VerifyEmailResend(db data.Database, tokens *auth.Signer, m mail.Mailer, cfg *config.Config) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		u, ok := auth.FromContext(r.Context())
+		if !ok {
+			writeError(w, http.StatusUnauthorized, nil)
+			return
+		}
+		user, err := db.GetUserByID(u.ID)
+		if err != nil {
+			writeError(w, http.StatusUnauthorized, err)
+			return
+		}
+
+		type envelope struct {
+			Status string `json:"status"`
+		}
+		if user.EmailVerified {
+			writeJSON(w, http.StatusOK, envelope{"email already verified"})
+			return
+		}
+		if err := sendVerificationEmail(m, tokens, cfg, user); err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		writeJSON(w, http.StatusAccepted, envelope{http.StatusText(http.StatusAccepted)})
+	})
+}
+
+func sendVerificationEmail(m mail.Mailer, tokens *auth.Signer, cfg *config.Config, user schema.User) error {
+	token, _, err := tokens.SignPurpose(user, auth.PurposeVerifyEmail, cfg.EmailVerificationTTL)
+	if err != nil {
+		return err
+	}
+	link := fmt.Sprintf("%s/verify_email?token=%s", cfg.PublicURL, url.QueryEscape(token))
+	msg := mail.Message{
+		To:      user.Email,
+		Subject: "Verify your email address",
+		Body: fmt.Sprintf("Hi %s,\n\nPlease confirm this is your email address by opening the link below. It expires in %s.\n\n%s\n",
+			user.UserName, cfg.EmailVerificationTTL, link),
+	}
+	if err := m.Send(msg); err != nil {
+		log.Println("failed to send verification email:", err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,174 @@
+package route
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kernkw/hhapp/internal/auth"
+	"github.com/kernkw/hhapp/internal/config"
+	"github.com/kernkw/hhapp/internal/data"
+	"github.com/kernkw/hhapp/internal/data/datamock"
+	"github.com/kernkw/hhapp/internal/schema"
+)
+
+func TestUserCreate_sends_verification(t *testing.T) {
+	outbox, cleanup := newOutbox(t)
+	defer cleanup()
+
+	var verifiedID int
+	var verifiedEmail string
+	mockStore := &datamock.Mock{
+		CreateUser_: func(user schema.User) (int, error) {
+			return 42, nil
+		},
+		VerifyUserEmail_: func(userID int, email string) error {
+			verifiedID, verifiedEmail = userID, email
+			return nil
+		},
+	}
+	tokens := auth.NewSigner("secret", time.Minute, time.Hour)
+	cfg := &config.Config{PublicURL: "http://hhapp.test", EmailVerificationTTL: time.Hour}
+
+	req, err := http.NewRequest("POST", "/create_account", bytes.NewReader([]byte(`{"username":"test","password":"password","email":"test@domain.com"}`)))
+	checkError(err, t)
+
+	rr := httptest.NewRecorder()
+
+	http.HandlerFunc(UserCreate(mockStore, tokens, outbox, cfg)).
+		ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusCreated {
+		t.Fatalf("handler returned wrong status code: got %v want %v",
+			status, http.StatusCreated)
+	}
+
+	msgs, err := outbox.Messages()
+	checkError(err, t)
+	if len(msgs) != 1 || msgs[0].To != "test@domain.com" {
+		t.Fatalf("expected one verification email to test@domain.com, got %+v", msgs)
+	}
+	prefix := "http://hhapp.test/verify_email?token="
+	i := strings.Index(msgs[0].Body, prefix)
+	if i < 0 {
+		t.Fatalf("message has no verification link: %q", msgs[0].Body)
+	}
+	token, err := url.QueryUnescape(strings.Fields(msgs[0].Body[i+len(prefix):])[0])
+	checkError(err, t)
+
+	// An access token must not work as a verification link, nor vice versa.
+	if _, err := tokens.Verify(token); err != auth.ErrInvalidToken {
+		t.Errorf("verification token accepted as access token: %v", err)
+	}
+
+	req, err = http.NewRequest("GET", "/verify_email?token="+url.QueryEscape(token), nil)
+	checkError(err, t)
+	rr = httptest.NewRecorder()
+
+	http.HandlerFunc(VerifyEmail(mockStore, tokens)).
+		ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusOK {
+		t.Errorf("handler returned wrong status code: got %v want %v",
+			status, http.StatusOK)
+	}
+	if verifiedID != 42 || verifiedEmail != "test@domain.com" {
+		t.Errorf("wrong account verified: %v %v", verifiedID, verifiedEmail)
+	}
+}
+
+func TestVerifyEmail_access_token(t *testing.T) {
+	tokens := auth.NewSigner("secret", time.Minute, time.Hour)
+	token, _, err := tokens.Sign(schema.User{ID: 42, Email: "test@domain.com"})
+	checkError(err, t)
+
+	req, err := http.NewRequest("GET", "/verify_email?token="+url.QueryEscape(token), nil)
+	checkError(err, t)
+	rr := httptest.NewRecorder()
+
+	http.HandlerFunc(VerifyEmail(&datamock.Mock{}, tokens)).
+		ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusUnprocessableEntity {
+		t.Errorf("handler returned wrong status code: got %v want %v",
+			status, http.StatusUnprocessableEntity)
+	}
+}
+
+func TestUserNotificationCreate_unverified(t *testing.T) {
+	mockStore := &datamock.Mock{
+		GetUserByID_: func(id int) (schema.User, error) {
+			return schema.User{ID: id, Email: "test@domain.com"}, nil
+		},
+	}
+
+	req, err := http.NewRequest("POST", "/user_notifications", bytes.NewReader([]byte(`{"notification_id":1}`)))
+	checkError(err, t)
+	req = withUser(req, schema.User{ID: 42})
+	rr := httptest.NewRecorder()
+
+	http.HandlerFunc(UserNotificationCreate(mockStore)).
+		ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusForbidden {
+		t.Errorf("handler returned wrong status code: got %v want %v",
+			status, http.StatusForbidden)
+	}
+}
+
+func TestUserNotificationCreate(t *testing.T) {
+	var got schema.UserNotifications
+	mockStore := &datamock.Mock{
+		GetUserByID_: func(id int) (schema.User, error) {
+			return schema.User{ID: id, Email: "test@domain.com", EmailVerified: true}, nil
+		},
+		CreateUserNotification_: func(un schema.UserNotifications) (int, error) {
+			got = un
+			return 7, nil
+		},
+	}
+
+	req, err := http.NewRequest("POST", "/user_notifications", bytes.NewReader([]byte(`{"notification_id":1,"email":"other@domain.com"}`)))
+	checkError(err, t)
+	req = withUser(req, schema.User{ID: 42})
+	rr := httptest.NewRecorder()
+
+	http.HandlerFunc(UserNotificationCreate(mockStore)).
+		ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusCreated {
+		t.Errorf("handler returned wrong status code: got %v want %v",
+			status, http.StatusCreated)
+	}
+	want := schema.UserNotifications{UserID: 42, NotificationID: 1, Email: "test@domain.com"}
+	if got != want {
+		t.Errorf("wrong subscription stored: got %+v want %+v", got, want)
+	}
+}
+
+func TestVerifyEmail_changed_email(t *testing.T) {
+	tokens := auth.NewSigner("secret", time.Minute, time.Hour)
+	token, _, err := tokens.SignPurpose(schema.User{ID: 42, Email: "old@domain.com"}, auth.PurposeVerifyEmail, time.Hour)
+	checkError(err, t)
+	mockStore := &datamock.Mock{
+		VerifyUserEmail_: func(userID int, email string) error {
+			return data.ErrNotFound
+		},
+	}
+
+	req, err := http.NewRequest("GET", "/verify_email?token="+url.QueryEscape(token), nil)
+	checkError(err, t)
+	rr := httptest.NewRecorder()
+
+	http.HandlerFunc(VerifyEmail(mockStore, tokens)).
+		ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusUnprocessableEntity {
+		t.Errorf("handler returned wrong status code: got %v want %v",
+			status, http.StatusUnprocessableEntity)
+	}
+}
@@ -0,0 +1,40 @@
+package schema
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// APIKeyScope limits what an API key may do.
+type APIKeyScope string
+
+const (
+	ScopeRead  APIKeyScope = "read"
+	ScopeWrite APIKeyScope = "write"
+)
+
+// ParseAPIKeyScope returns the scope named by s.
+func ParseAPIKeyScope(s string) (APIKeyScope, error) {
+	switch sc := APIKeyScope(strings.ToLower(strings.TrimSpace(s))); sc {
+	case ScopeRead, ScopeWrite:
+		return sc, nil
+	}
+	return "", fmt.Errorf("unknown api key scope %q", s)
+}
+
+// APIKey lets a partner act as UserID without an interactive login. Only the
+// SHA-256 hash of the key is stored; Prefix is kept so admins can tell keys
+// apart.
+type APIKey struct {
+	ID         int         `json:"id"`
+	UserID     int         `json:"user_id"`
+	Name       string      `json:"name"`
+	Prefix     string      `json:"prefix"`
+	KeyHash    string      `json:"-"`
+	Scope      APIKeyScope `json:"scope"`
+	CreatedBy  int         `json:"created_by"`
+	LastUsedAt *time.Time  `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time  `json:"revoked_at,omitempty"`
+	CreatedAt  time.Time   `json:"created_at"`
+}
@@ -0,0 +1,20 @@
+package schema
+
+import "strings"
+
+//go:generate go run gen_common_passwords.go
+
+// commonPasswords are the passwords in data/common_passwords.txt, compiled
+// in as commonPasswordList. PasswordPolicy.Check rejects them when
+// RejectCommon is set.
+var commonPasswords = readCommonPasswords(commonPasswordList)
+
+func readCommonPasswords(list string) map[string]bool {
+	m := map[string]bool{}
+	for _, p := range strings.Split(list, "\n") {
+		if p != "" {
+			m[p] = true
+		}
+	}
+	return m
+}
@@ -0,0 +1,59 @@
+//go:build ignore
+// +build ignore
+
+// This program generates common_passwords_list.go from
+// data/common_passwords.txt. Run it with go generate.
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"log"
+	"os"
+	"strings"
+)
+
+func main() {
+	f, err := os.Open("../../data/common_passwords.txt")
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer f.Close()
+
+	var buf bytes.Buffer
+	fmt.Fprintln(&buf, "// Code generated by gen_common_passwords.go; DO NOT EDIT.")
+	fmt.Fprintln(&buf)
+	fmt.Fprintln(&buf, "package schema")
+	fmt.Fprintln(&buf)
+	fmt.Fprintln(&buf, "// commonPasswordList holds data/common_passwords.txt, one password per line.")
+	fmt.Fprint(&buf, "const commonPasswordList = `")
+
+	seen := map[string]bool{}
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		p := strings.ToLower(strings.TrimSpace(s.Text()))
+		if p == "" || strings.HasPrefix(p, "#") || seen[p] {
+			continue
+		}
+		if strings.Contains(p, "`") {
+			log.Fatalf("password %q can't be held in a raw string", p)
+		}
+		seen[p] = true
+		fmt.Fprintf(&buf, "\n%s", p)
+	}
+	if err := s.Err(); err != nil {
+		log.Fatal(err)
+	}
+	fmt.Fprintln(&buf, "\n`")
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := ioutil.WriteFile("common_passwords_list.go", src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
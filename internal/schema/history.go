@@ -0,0 +1,138 @@
+package schema
+
+import (
+	"encoding/json"
+	"sort"
+	"time"
+)
+
+// ChangeEntity names the kind of record a MenuChange changed.
+type ChangeEntity string
+
+const (
+	ChangeMenu      ChangeEntity = "menu"
+	ChangeMenuItem  ChangeEntity = "menu_item"
+	ChangeSchedule  ChangeEntity = "schedule"
+	ChangeException ChangeEntity = "exception"
+)
+
+// ChangeAction says whether a MenuChange created, updated or deleted its
+// record.
+type ChangeAction string
+
+const (
+	ChangeCreate ChangeAction = "create"
+	ChangeUpdate ChangeAction = "update"
+	ChangeDelete ChangeAction = "delete"
+)
+
+// MenuChange is one entry in the history of a venue's menus: the values of
+// a menu, menu item, schedule window or exception before and after a user
+// changed it. Before is null for a create and After for a delete.
+type MenuChange struct {
+	ID       int             `json:"id"`
+	VenueID  int             `json:"venue_id"`
+	MenuID   int             `json:"menu_id"`
+	Entity   ChangeEntity    `json:"entity"`
+	EntityID int             `json:"entity_id"`
+	Action   ChangeAction    `json:"action"`
+	UserID   int             `json:"user_id"`
+	Before   json.RawMessage `json:"before"`
+	After    json.RawMessage `json:"after"`
+	At       time.Time       `json:"at"`
+}
+
+// MenuChangeFilter selects entries of a venue's menu history. Zero fields
+// don't restrict it.
+type MenuChangeFilter struct {
+	VenueID int
+	MenuID  int
+	// After and Until bound the time of the change: after After, up to and
+	// including Until.
+	After time.Time
+	Until time.Time
+	Limit int
+}
+
+// MenuState is a menu with its schedule, exceptions and items at one time.
+type MenuState struct {
+	Menu
+	Schedule   []MenuDateTime  `json:"schedule"`
+	Exceptions []MenuException `json:"exceptions"`
+	Items      []MenuItem      `json:"items"`
+}
+
+// Undo reverts c, a change made to the menu after s, so that s shows the
+// menu as it was before it. Undoing the menu's creation leaves s with a
+// zero Menu, as the menu didn't exist yet.
+func (s *MenuState) Undo(c MenuChange) error {
+	switch c.Entity {
+	case ChangeMenu:
+		var menu Menu
+		if c.Before != nil {
+			if err := json.Unmarshal(c.Before, &menu); err != nil {
+				return err
+			}
+		}
+		s.Menu = menu
+	case ChangeMenuItem:
+		items := []MenuItem{}
+		for _, item := range s.Items {
+			if item.ID != c.EntityID {
+				items = append(items, item)
+			}
+		}
+		if c.Before != nil {
+			var item MenuItem
+			if err := json.Unmarshal(c.Before, &item); err != nil {
+				return err
+			}
+			items = append(items, item)
+		}
+		sort.SliceStable(items, func(i, j int) bool {
+			if items[i].Position != items[j].Position {
+				return items[i].Position < items[j].Position
+			}
+			return items[i].ID < items[j].ID
+		})
+		s.Items = items
+	case ChangeSchedule:
+		times := []MenuDateTime{}
+		for _, md := range s.Schedule {
+			if md.ID != c.EntityID {
+				times = append(times, md)
+			}
+		}
+		if c.Before != nil {
+			var md MenuDateTime
+			if err := json.Unmarshal(c.Before, &md); err != nil {
+				return err
+			}
+			times = append(times, md)
+		}
+		sort.SliceStable(times, func(i, j int) bool { return times[i].ID < times[j].ID })
+		s.Schedule = times
+	case ChangeException:
+		exceptions := []MenuException{}
+		for _, e := range s.Exceptions {
+			if e.ID != c.EntityID {
+				exceptions = append(exceptions, e)
+			}
+		}
+		if c.Before != nil {
+			var e MenuException
+			if err := json.Unmarshal(c.Before, &e); err != nil {
+				return err
+			}
+			exceptions = append(exceptions, e)
+		}
+		sort.SliceStable(exceptions, func(i, j int) bool {
+			if exceptions[i].StartDate != exceptions[j].StartDate {
+				return exceptions[i].StartDate.Before(exceptions[j].StartDate)
+			}
+			return exceptions[i].ID < exceptions[j].ID
+		})
+		s.Exceptions = exceptions
+	}
+	return nil
+}
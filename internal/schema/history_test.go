@@ -0,0 +1,55 @@
+package schema
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestMenuState_Undo(t *testing.T) {
+	christmas := MenuException{ID: 1, MenuID: 7, Kind: ExceptionBlackout, StartDate: Date{2018, time.December, 25}, EndDate: Date{2018, time.December, 25}}
+	newYear := MenuException{ID: 2, MenuID: 7, Kind: ExceptionBlackout, StartDate: Date{2019, time.January, 1}, EndDate: Date{2019, time.January, 1}}
+	superBowl := MenuException{ID: 3, MenuID: 7, Kind: ExceptionSpecial, StartDate: Date{2018, time.February, 4}, EndDate: Date{2018, time.February, 4}, StartAt: 16 * 60 * 60, EndAt: 23 * 60 * 60}
+	moved := superBowl
+	moved.StartDate, moved.EndDate = Date{2019, time.February, 3}, Date{2019, time.February, 3}
+	value := func(v interface{}) json.RawMessage {
+		b, err := json.Marshal(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return b
+	}
+
+	s := MenuState{Exceptions: []MenuException{christmas, newYear, moved}}
+	changes := []MenuChange{
+		{Entity: ChangeException, EntityID: 2, Action: ChangeCreate, After: value(newYear)},
+		{Entity: ChangeException, EntityID: 3, Action: ChangeUpdate, Before: value(superBowl), After: value(moved)},
+	}
+	for _, c := range changes {
+		if err := s.Undo(c); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(s.Exceptions) != 2 || s.Exceptions[0] != superBowl || s.Exceptions[1] != christmas {
+		t.Errorf("got %+v want the Super Bowl special then Christmas", s.Exceptions)
+	}
+
+	renamed := Menu{ID: 7, VenueID: 5, Name: "Night Owl", Type: MenuLateNight}
+	s.Menu = Menu{ID: 7, VenueID: 5, Name: "Late Night", Type: MenuLateNight}
+	if err := s.Undo(MenuChange{Entity: ChangeMenu, EntityID: 7, Action: ChangeUpdate, Before: value(renamed), After: value(s.Menu)}); err != nil {
+		t.Fatal(err)
+	}
+	if s.Menu != renamed {
+		t.Errorf("got %+v want %+v", s.Menu, renamed)
+	}
+	if err := s.Undo(MenuChange{Entity: ChangeMenu, EntityID: 7, Action: ChangeCreate, After: value(renamed)}); err != nil {
+		t.Fatal(err)
+	}
+	if s.Menu != (Menu{}) {
+		t.Errorf("got %+v after undoing the menu's creation want a zero Menu", s.Menu)
+	}
+
+	if err := s.Undo(MenuChange{Entity: ChangeMenuItem, EntityID: 4, Action: ChangeDelete, Before: json.RawMessage(`{"id":"four"}`)}); err == nil {
+		t.Error("undid a change with an unreadable before value")
+	}
+}
@@ -0,0 +1,14 @@
+package schema
+
+import "time"
+
+// Identity links an account at an external identity provider to a user.
+// Subject is the provider's stable identifier for the account.
+type Identity struct {
+	ID        int       `json:"id"`
+	UserID    int       `json:"user_id"`
+	Provider  string    `json:"provider"`
+	Subject   string    `json:"subject"`
+	Email     string    `json:"email,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -1,28 +1,246 @@
 package schema
 
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+var ErrInvalidMenuType = errors.New("type must be one of happy_hour, late_night, brunch or other")
+
+// MenuType says when a menu's specials are served.
+type MenuType string
+
+const (
+	MenuHappyHour MenuType = "happy_hour"
+	MenuLateNight MenuType = "late_night"
+	MenuBrunch    MenuType = "brunch"
+	MenuOther     MenuType = "other"
+)
+
+// ParseMenuType reads a menu type, ignoring case and surrounding space.
+func ParseMenuType(s string) (MenuType, error) {
+	t := MenuType(strings.ToLower(strings.TrimSpace(s)))
+	if !t.Valid() {
+		return "", ErrInvalidMenuType
+	}
+	return t, nil
+}
+
+func (t MenuType) Valid() bool {
+	switch t {
+	case MenuHappyHour, MenuLateNight, MenuBrunch, MenuOther:
+		return true
+	}
+	return false
+}
+
+// DefaultMenuName names the menu every new venue starts with.
+const DefaultMenuName = "Happy Hour"
+
+// maxMenuNameLength is the width of the menu.name column.
+const maxMenuNameLength = 100
+
 type Menu struct {
-	ID      int `json:"id"`
-	VenueID int `json:"venue_id"`
+	ID      int      `json:"id"`
+	VenueID int      `json:"venue_id"`
+	Name    string   `json:"name"`
+	Type    MenuType `json:"type"`
 }
 
+// Validate checks the fields a venue owner sets.
+func (m Menu) Validate() error {
+	errs := FieldErrors{}
+	if strings.TrimSpace(m.Name) == "" {
+		errs["name"] = strings.TrimSuffix(requiredFieldMessage("name"), " ")
+	} else if len(m.Name) > maxMenuNameLength {
+		errs["name"] = "name must be at most 100 characters."
+	}
+	if !m.Type.Valid() {
+		errs["type"] = ErrInvalidMenuType.Error()
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+var ErrInvalidCategory = errors.New("category must be one of drink, food or all")
+
+// Category says what kind of special a menu item is. CategoryAll items,
+// such as half off everything, apply to both food and drink.
+type Category string
+
+const (
+	CategoryDrink Category = "drink"
+	CategoryFood  Category = "food"
+	CategoryAll   Category = "all"
+)
+
+// ParseCategory reads a category name, ignoring case and surrounding space.
+func ParseCategory(s string) (Category, error) {
+	c := Category(strings.ToLower(strings.TrimSpace(s)))
+	if !c.Valid() {
+		return "", ErrInvalidCategory
+	}
+	return c, nil
+}
+
+func (c Category) Valid() bool {
+	switch c {
+	case CategoryDrink, CategoryFood, CategoryAll:
+		return true
+	}
+	return false
+}
+
+// Matches reports whether an item of category item belongs in a search for
+// c. The empty category matches every item and CategoryAll items match
+// every search.
+func (c Category) Matches(item Category) bool {
+	return c == "" || item == c || item == CategoryAll
+}
+
+// UnmarshalJSON accepts a category in any case. Unknown names are kept so
+// that Validate can report them.
+func (c *Category) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	*c = Category(strings.ToLower(strings.TrimSpace(s)))
+	return nil
+}
+
+// Scan reads a category column. NULL, and the empty string MySQL stores
+// for values outside the enum, read as the empty category.
+func (c *Category) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*c = ""
+	case []byte:
+		*c = Category(v)
+	case string:
+		*c = Category(v)
+	default:
+		return fmt.Errorf("schema: cannot scan %T into a Category", src)
+	}
+	return nil
+}
+
+// MenuItem is a special on a menu. Price is what it costs on the menu and
+// the optional RegularPrice what it costs the rest of the time, in the same
+// currency.
 type MenuItem struct {
-	ID          int     `json:"id"`
-	MenuID      int     `json:"menu_id"`
-	Category    string  `json:"category"`
-	Price       float64 `json:"price"`
-	Description string  `json:"description"`
-}
-
-type MenuDateTime struct {
-	ID        int  `json:"id"`
-	MenuID    int  `json:"menu_id"`
-	Monday    bool `json:"monday"`
-	Tuesday   bool `json:"tuesday"`
-	Wednesday bool `json:"wednesday"`
-	Thursday  bool `json:"thursday"`
-	Friday    bool `json:"friday"`
-	Saturday  bool `json:"saturday"`
-	Sunday    bool `json:"sunday"`
-	StartAt   bool `json:"start_at"`
-	EndAt     bool `json:"end_at"`
+	ID           int      `json:"id"`
+	MenuID       int      `json:"menu_id"`
+	Category     Category `json:"category"`
+	Price        Money    `json:"price"`
+	RegularPrice *Money   `json:"regular_price,omitempty"`
+	Description  string   `json:"description"`
+	Position     int      `json:"position"`
+}
+
+// Savings is how much cheaper an item is than its regular price.
+type Savings struct {
+	Amount  Money
+	Percent float64
+}
+
+// MarshalJSON writes the amount saved alongside the percentage, as
+// {"amount":"1.50","currency":"USD","percent":25}.
+func (s Savings) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Amount   string  `json:"amount"`
+		Currency string  `json:"currency"`
+		Percent  float64 `json:"percent"`
+	}{s.Amount.String(), s.Amount.Currency, s.Percent})
+}
+
+// Savings returns the item's savings, or nil when it has no regular price.
+// The percentage is rounded to one decimal.
+func (m MenuItem) Savings() *Savings {
+	if m.RegularPrice == nil || m.RegularPrice.Amount <= 0 || m.RegularPrice.Currency != m.Price.Currency {
+		return nil
+	}
+	amount := m.RegularPrice.Amount - m.Price.Amount
+	return &Savings{
+		Amount:  Money{Amount: amount, Currency: m.Price.Currency},
+		Percent: math.Floor(float64(amount)*1000/float64(m.RegularPrice.Amount)+0.5) / 10,
+	}
+}
+
+// MarshalJSON adds the item's savings to its fields.
+func (m MenuItem) MarshalJSON() ([]byte, error) {
+	type item MenuItem
+	return json.Marshal(struct {
+		item
+		Savings *Savings `json:"savings,omitempty"`
+	}{item(m), m.Savings()})
+}
+
+// InCurrency returns the item with its prices in their own currency, or in
+// def when the client gave none. An item without a price is an error.
+func (m MenuItem) InCurrency(def string) (MenuItem, error) {
+	if !m.Price.set() {
+		return m, FieldErrors{"price": strings.TrimSuffix(requiredFieldMessage("price"), " ")}
+	}
+	currency := m.Price.Currency
+	if currency == "" {
+		currency = def
+	}
+	price, err := m.Price.In(currency)
+	if err != nil {
+		return m, FieldErrors{"price": err.Error()}
+	}
+	m.Price = price
+	if m.RegularPrice != nil {
+		regular, err := m.RegularPrice.In(currency)
+		if err != nil {
+			return m, FieldErrors{"regular_price": err.Error()}
+		}
+		m.RegularPrice = &regular
+	}
+	return m, nil
+}
+
+// Validate checks the fields a venue owner sets. Prices are expected to be
+// in their currency already; see InCurrency.
+func (m MenuItem) Validate() error {
+	errs := FieldErrors{}
+	if !m.Category.Valid() {
+		errs["category"] = ErrInvalidCategory.Error()
+	}
+	if !ValidCurrency(m.Price.Currency) {
+		errs["price"] = ErrUnknownCurrency.Error()
+	} else if m.Price.Amount < 0 {
+		errs["price"] = "price must not be negative."
+	}
+	if m.RegularPrice != nil {
+		if m.RegularPrice.Currency != m.Price.Currency {
+			errs["regular_price"] = ErrCurrencyMismatch.Error()
+		} else if m.RegularPrice.Amount < m.Price.Amount {
+			errs["regular_price"] = "regular_price must not be less than price."
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// FilterItems returns the items that belong in a search for c.
+func FilterItems(items []MenuItem, c Category) []MenuItem {
+	if c == "" {
+		return items
+	}
+	filtered := []MenuItem{}
+	for _, item := range items {
+		if c.Matches(item.Category) {
+			filtered = append(filtered, item)
+		}
+	}
+	return filtered
 }
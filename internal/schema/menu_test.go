@@ -0,0 +1,107 @@
+package schema
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseCategory(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Category
+		err  error
+	}{
+		{"drink", CategoryDrink, nil},
+		{" Food ", CategoryFood, nil},
+		{"ALL", CategoryAll, nil},
+		{"beer", "", ErrInvalidCategory},
+		{"", "", ErrInvalidCategory},
+	}
+	for _, tt := range tests {
+		if got, err := ParseCategory(tt.in); got != tt.want || err != tt.err {
+			t.Errorf("ParseCategory(%q): got %q %v want %q %v", tt.in, got, err, tt.want, tt.err)
+		}
+	}
+}
+
+func TestCategory_Matches(t *testing.T) {
+	tests := []struct {
+		search, item Category
+		want         bool
+	}{
+		{"", CategoryFood, true},
+		{CategoryDrink, CategoryDrink, true},
+		{CategoryDrink, CategoryFood, false},
+		{CategoryDrink, CategoryAll, true},
+		{CategoryAll, CategoryFood, false},
+	}
+	for _, tt := range tests {
+		if got := tt.search.Matches(tt.item); got != tt.want {
+			t.Errorf("%q.Matches(%q): got %v want %v", tt.search, tt.item, got, tt.want)
+		}
+	}
+}
+
+func TestMenuItem_Validate(t *testing.T) {
+	var item MenuItem
+	if err := json.Unmarshal([]byte(`{"category":"Drink","price":{"amount":"5","currency":"USD"}}`), &item); err != nil {
+		t.Fatal(err)
+	}
+	if item.Category != CategoryDrink || item.Validate() != nil {
+		t.Errorf("got category %q, %v", item.Category, item.Validate())
+	}
+
+	if err := json.Unmarshal([]byte(`{"category":"beer"}`), &item); err != nil {
+		t.Fatal(err)
+	}
+	errs, ok := item.Validate().(FieldErrors)
+	if !ok || errs["category"] == "" {
+		t.Errorf("category beer: got %v", item.Validate())
+	}
+}
+
+func TestCategory_Scan(t *testing.T) {
+	var c Category
+	for _, src := range []interface{}{[]byte("food"), "food"} {
+		if err := c.Scan(src); err != nil || c != CategoryFood {
+			t.Errorf("Scan(%v): got %q %v", src, c, err)
+		}
+	}
+	if err := c.Scan(nil); err != nil || c != "" {
+		t.Errorf("Scan(nil): got %q %v", c, err)
+	}
+	if err := c.Scan(1); err == nil {
+		t.Error("Scan(1): expected an error")
+	}
+}
+
+func TestMenu_Validate(t *testing.T) {
+	tests := []struct {
+		menu   Menu
+		fields []string
+	}{
+		{Menu{Name: "Late Night", Type: MenuLateNight}, nil},
+		{Menu{Name: " ", Type: MenuBrunch}, []string{"name"}},
+		{Menu{Name: "Dinner", Type: "dinner"}, []string{"type"}},
+		{Menu{}, []string{"name", "type"}},
+	}
+	for _, tt := range tests {
+		err := tt.menu.Validate()
+		if tt.fields == nil {
+			if err != nil {
+				t.Errorf("Validate(%+v): %v", tt.menu, err)
+			}
+			continue
+		}
+		errs, ok := err.(FieldErrors)
+		if !ok || len(errs) != len(tt.fields) {
+			t.Errorf("Validate(%+v): got %v want errors for %v", tt.menu, err, tt.fields)
+			continue
+		}
+		for _, f := range tt.fields {
+			if errs[f] == "" {
+				t.Errorf("Validate(%+v): no error for %s", tt.menu, f)
+			}
+		}
+	}
+}
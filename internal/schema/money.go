@@ -0,0 +1,193 @@
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrInvalidAmount    = errors.New(`amount must be a decimal such as "4.50"`)
+	ErrAmountPrecision  = errors.New("amount has more decimal places than its currency")
+	ErrUnknownCurrency  = errors.New("currency must be an ISO 4217 code")
+	ErrCurrencyMismatch = errors.New("amounts must be in the same currency")
+)
+
+// DefaultCurrency is used for venues whose country has no known currency.
+const DefaultCurrency = "USD"
+
+// currencyDigits is the number of minor unit digits of each currency
+// accepted.
+var currencyDigits = map[string]int{
+	"AED": 2, "ARS": 2, "AUD": 2, "BHD": 3, "BRL": 2, "CAD": 2, "CHF": 2,
+	"CLP": 0, "CNY": 2, "COP": 2, "CZK": 2, "DKK": 2, "EUR": 2, "GBP": 2,
+	"HKD": 2, "HUF": 2, "IDR": 2, "ILS": 2, "INR": 2, "ISK": 0, "JPY": 0,
+	"KRW": 0, "KWD": 3, "MXN": 2, "MYR": 2, "NOK": 2, "NZD": 2, "PEN": 2,
+	"PHP": 2, "PLN": 2, "SEK": 2, "SGD": 2, "THB": 2, "TRY": 2, "TWD": 2,
+	"USD": 2, "VND": 0, "ZAR": 2,
+}
+
+// ValidCurrency reports whether code is a currency Money can hold.
+func ValidCurrency(code string) bool {
+	_, ok := currencyDigits[code]
+	return ok
+}
+
+// Money is an exact amount in a currency's minor units, such as cents. It
+// is written to JSON as {"amount":"4.50","currency":"USD"}.
+type Money struct {
+	Amount   int64
+	Currency string
+	// text holds an amount read from JSON without a currency until In
+	// says how many minor units it has.
+	text string
+}
+
+// ParseMoney reads a decimal amount such as "4.50" in currency.
+func ParseMoney(s, currency string) (Money, error) {
+	digits, ok := currencyDigits[currency]
+	if !ok {
+		return Money{}, ErrUnknownCurrency
+	}
+	s = strings.TrimSpace(s)
+	neg := strings.HasPrefix(s, "-")
+	if neg {
+		s = s[1:]
+	}
+	whole, frac := s, ""
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		whole, frac = s[:i], s[i+1:]
+		if frac == "" {
+			return Money{}, ErrInvalidAmount
+		}
+	}
+	if whole == "" || !isDigits(whole) || !isDigits(frac) {
+		return Money{}, ErrInvalidAmount
+	}
+	if len(frac) > digits {
+		// Trailing zeros, as in "4.500", say nothing more.
+		if strings.Trim(frac[digits:], "0") != "" {
+			return Money{}, ErrAmountPrecision
+		}
+		frac = frac[:digits]
+	}
+	frac += strings.Repeat("0", digits-len(frac))
+	amount, err := strconv.ParseInt(whole+frac, 10, 64)
+	if err != nil {
+		return Money{}, ErrInvalidAmount
+	}
+	if neg {
+		amount = -amount
+	}
+	return Money{Amount: amount, Currency: currency}, nil
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// String formats the amount as a decimal with the currency's minor digits,
+// without the currency.
+func (m Money) String() string {
+	digits, ok := currencyDigits[m.Currency]
+	if !ok {
+		digits = 2
+	}
+	amount, sign := m.Amount, ""
+	if amount < 0 {
+		amount, sign = -amount, "-"
+	}
+	s := strconv.FormatInt(amount, 10)
+	if digits == 0 {
+		return sign + s
+	}
+	if len(s) <= digits {
+		s = strings.Repeat("0", digits-len(s)+1) + s
+	}
+	return sign + s[:len(s)-digits] + "." + s[len(s)-digits:]
+}
+
+// set reports whether m holds an amount, as opposed to a price left out
+// of a JSON object.
+func (m Money) set() bool {
+	return m.Currency != "" || m.text != ""
+}
+
+// In returns m in currency. An amount read without a currency is parsed in
+// it; an amount already in another currency is an error.
+func (m Money) In(currency string) (Money, error) {
+	if m.text != "" {
+		if m.Currency != "" && m.Currency != currency {
+			return Money{}, ErrCurrencyMismatch
+		}
+		return ParseMoney(m.text, currency)
+	}
+	if m.Currency == "" {
+		if !ValidCurrency(currency) {
+			return Money{}, ErrUnknownCurrency
+		}
+		m.Currency = currency
+		return m, nil
+	}
+	if m.Currency != currency {
+		return Money{}, ErrCurrencyMismatch
+	}
+	return m, nil
+}
+
+type moneyJSON struct {
+	Amount   json.RawMessage `json:"amount"`
+	Currency string          `json:"currency"`
+}
+
+func (m Money) MarshalJSON() ([]byte, error) {
+	amount, _ := json.Marshal(m.String())
+	return json.Marshal(moneyJSON{amount, m.Currency})
+}
+
+// UnmarshalJSON reads {"amount":"4.50","currency":"USD"}. The amount may
+// also be a JSON number, and a bare amount stands for the object without a
+// currency. Amounts without a currency are parsed once In gives them one.
+func (m *Money) UnmarshalJSON(b []byte) error {
+	var v moneyJSON
+	if b = bytes.TrimSpace(b); len(b) > 0 && b[0] == '{' {
+		if err := json.Unmarshal(b, &v); err != nil {
+			return err
+		}
+	} else {
+		v.Amount = b
+	}
+	text, err := amountText(v.Amount)
+	if err != nil {
+		return err
+	}
+	currency := strings.ToUpper(strings.TrimSpace(v.Currency))
+	*m = Money{Currency: currency, text: text}
+	if currency != "" {
+		*m, err = ParseMoney(text, currency)
+	}
+	return err
+}
+
+// amountText returns the decimal in a JSON string or number without going
+// through a float.
+func amountText(b json.RawMessage) (string, error) {
+	if len(b) == 0 || string(b) == "null" {
+		return "", ErrInvalidAmount
+	}
+	if b[0] == '"' {
+		var s string
+		if err := json.Unmarshal(b, &s); err != nil {
+			return "", ErrInvalidAmount
+		}
+		return s, nil
+	}
+	return string(b), nil
+}
@@ -0,0 +1,99 @@
+package schema
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		in, currency string
+		want         Money
+		err          error
+	}{
+		{"4.50", "USD", Money{Amount: 450, Currency: "USD"}, nil},
+		{"4.5", "USD", Money{Amount: 450, Currency: "USD"}, nil},
+		{"4", "USD", Money{Amount: 400, Currency: "USD"}, nil},
+		{"4.500", "USD", Money{Amount: 450, Currency: "USD"}, nil},
+		{"0.07", "USD", Money{Amount: 7, Currency: "USD"}, nil},
+		{"-1.25", "EUR", Money{Amount: -125, Currency: "EUR"}, nil},
+		{"1500", "JPY", Money{Amount: 1500, Currency: "JPY"}, nil},
+		{"1.234", "KWD", Money{Amount: 1234, Currency: "KWD"}, nil},
+		{"4.999", "USD", Money{}, ErrAmountPrecision},
+		{"1500.5", "JPY", Money{}, ErrAmountPrecision},
+		{"4.", "USD", Money{}, ErrInvalidAmount},
+		{".5", "USD", Money{}, ErrInvalidAmount},
+		{"4,50", "USD", Money{}, ErrInvalidAmount},
+		{"1e2", "USD", Money{}, ErrInvalidAmount},
+		{"", "USD", Money{}, ErrInvalidAmount},
+		{"99999999999999999999", "USD", Money{}, ErrInvalidAmount},
+		{"4.50", "XXX", Money{}, ErrUnknownCurrency},
+	}
+	for _, tt := range tests {
+		if got, err := ParseMoney(tt.in, tt.currency); got != tt.want || err != tt.err {
+			t.Errorf("ParseMoney(%q, %q): got %+v %v want %+v %v", tt.in, tt.currency, got, err, tt.want, tt.err)
+		}
+	}
+}
+
+func TestMoney_String(t *testing.T) {
+	tests := []struct {
+		m    Money
+		want string
+	}{
+		{Money{Amount: 450, Currency: "USD"}, "4.50"},
+		{Money{Amount: 7, Currency: "USD"}, "0.07"},
+		{Money{Amount: 0, Currency: "USD"}, "0.00"},
+		{Money{Amount: -125, Currency: "EUR"}, "-1.25"},
+		{Money{Amount: 1500, Currency: "JPY"}, "1500"},
+		{Money{Amount: 1234, Currency: "KWD"}, "1.234"},
+	}
+	for _, tt := range tests {
+		if got := tt.m.String(); got != tt.want {
+			t.Errorf("%+v.String(): got %q want %q", tt.m, got, tt.want)
+		}
+	}
+}
+
+func TestMoney_JSON(t *testing.T) {
+	b, err := json.Marshal(Money{Amount: 499, Currency: "USD"})
+	if err != nil || string(b) != `{"amount":"4.99","currency":"USD"}` {
+		t.Errorf("got %s %v", b, err)
+	}
+
+	tests := []struct {
+		in   string
+		want Money
+	}{
+		{`{"amount":"4.99","currency":"USD"}`, Money{Amount: 499, Currency: "USD"}},
+		{`{"amount":4.99,"currency":"usd"}`, Money{Amount: 499, Currency: "USD"}},
+		{`"4.99"`, Money{Amount: 499, Currency: "USD"}},
+		{`4.99`, Money{Amount: 499, Currency: "USD"}},
+		{`{"amount":"4.99"}`, Money{Amount: 499, Currency: "USD"}},
+	}
+	for _, tt := range tests {
+		var m Money
+		if err := json.Unmarshal([]byte(tt.in), &m); err != nil {
+			t.Errorf("Unmarshal(%s): %v", tt.in, err)
+			continue
+		}
+		if got, err := m.In("USD"); got != tt.want || err != nil {
+			t.Errorf("Unmarshal(%s): got %+v %v want %+v", tt.in, got, err, tt.want)
+		}
+	}
+
+	for _, in := range []string{`{"amount":"4.999","currency":"USD"}`, `{"amount":"4.99","currency":"DOGE"}`, `null`, `{}`} {
+		var m Money
+		if err := json.Unmarshal([]byte(in), &m); err == nil {
+			t.Errorf("Unmarshal(%s): expected an error", in)
+		}
+	}
+
+	var m Money
+	if err := json.Unmarshal([]byte(`{"amount":"4.99","currency":"EUR"}`), &m); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.In("USD"); err != ErrCurrencyMismatch {
+		t.Errorf("In(USD) of EUR: got %v want %v", err, ErrCurrencyMismatch)
+	}
+}
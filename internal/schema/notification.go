@@ -10,8 +10,8 @@ const (
 
 type Notification struct {
 	ID          int       `json:"id"`
-	ListID      int       `json:"list_id, omitempty"`
-	FavoritesID int       `json:"favorites_id, omitempty"`
+	ListID      int       `json:"list_id,omitempty"`
+	FavoritesID int       `json:"favorites_id,omitempty"`
 	Name        string    `json:"name"`
 	Frequency   Frequency `json:"frequency"`
 }
@@ -0,0 +1,63 @@
+package schema
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// FieldErrors maps a field name to the reason its value was rejected.
+type FieldErrors map[string]string
+
+func (e FieldErrors) Error() string {
+	fields := make([]string, 0, len(e))
+	for f := range e {
+		fields = append(fields, f)
+	}
+	sort.Strings(fields)
+	msgs := make([]string, len(fields))
+	for i, f := range fields {
+		msgs[i] = e[f]
+	}
+	return strings.Join(msgs, " ")
+}
+
+// PasswordPolicy is the set of rules a new password must satisfy and the
+// bcrypt cost it is hashed with.
+type PasswordPolicy struct {
+	MinLength    int
+	RejectCommon bool
+	Cost         int
+}
+
+// Check returns the reason password is not acceptable for u, or "" if it
+// is. u's UserName and Email are compared against the password.
+func (p PasswordPolicy) Check(password string, u User) string {
+	if password == "" {
+		return strings.TrimSuffix(requiredFieldMessage("password"), " ")
+	}
+	if utf8.RuneCountInString(password) < p.MinLength {
+		return fmt.Sprintf("password must be at least %d characters.", p.MinLength)
+	}
+	lower := strings.ToLower(password)
+	if (u.UserName != "" && lower == strings.ToLower(u.UserName)) ||
+		(u.Email != "" && lower == strings.ToLower(u.Email)) {
+		return "password must not match your username or email."
+	}
+	// Common passwords shorter than MinLength were turned away above, so
+	// in effect the list is filtered to the configured minimum.
+	if p.RejectCommon && commonPasswords[lower] {
+		return "password is too common."
+	}
+	return ""
+}
+
+// CheckField is Check reported against field, suitable for returning from
+// a handler.
+func (p PasswordPolicy) CheckField(field, password string, u User) error {
+	if msg := p.Check(password, u); msg != "" {
+		return FieldErrors{field: strings.Replace(msg, "password", field, 1)}
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package schema
+
+import (
+	"testing"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestPasswordPolicyCheck(t *testing.T) {
+	p := PasswordPolicy{MinLength: 10, RejectCommon: true}
+	u := User{UserName: "happyhour-fan", Email: "fan@domain.com"}
+	tests := []struct {
+		password string
+		ok       bool
+	}{
+		{"", false},
+		{"short", false},
+		{"Password123", false},
+		{"HAPPYHOUR-FAN", false},
+		{"fan@domain.com", false},
+		{"Basketball", false},
+		{"1q2w3e4r5t", false},
+		{"playstation", false},
+		{"correct horse battery", true},
+		{"ünïcödé-ok!", true},
+	}
+	for _, tt := range tests {
+		if msg := p.Check(tt.password, u); (msg == "") != tt.ok {
+			t.Errorf("Check(%q): got %q, want ok=%v", tt.password, msg, tt.ok)
+		}
+	}
+}
+
+func TestCommonPasswords_long(t *testing.T) {
+	// The list is only consulted for passwords that pass the length rule,
+	// so it must hold plenty at the default minimum of 10 characters.
+	n := 0
+	for p := range commonPasswords {
+		if len(p) >= 10 {
+			n++
+		}
+	}
+	if n < 50 {
+		t.Errorf("only %d common passwords of 10 or more characters", n)
+	}
+}
+
+func TestUserValidate_fields(t *testing.T) {
+	err := User{Password: "short"}.Validate(PasswordPolicy{MinLength: 10})
+	errs, ok := err.(FieldErrors)
+	if !ok {
+		t.Fatalf("expected FieldErrors, got %T", err)
+	}
+	for _, f := range []string{"username", "email", "password"} {
+		if errs[f] == "" {
+			t.Errorf("no error reported for %v", f)
+		}
+	}
+	want := "email is a required field. password must be at least 10 characters. username is a required field."
+	if err.Error() != want {
+		t.Errorf("got %q want %q", err.Error(), want)
+	}
+}
+
+func TestUserNeedsRehash(t *testing.T) {
+	u := User{Password: "correct horse battery"}
+	if err := u.HashPassword(bcrypt.MinCost); err != nil {
+		t.Fatal(err)
+	}
+	if u.NeedsRehash(bcrypt.MinCost) {
+		t.Errorf("hash at the configured cost flagged for rehash")
+	}
+	if !u.NeedsRehash(bcrypt.MinCost + 1) {
+		t.Errorf("hash at an old cost not flagged for rehash")
+	}
+}
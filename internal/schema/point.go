@@ -0,0 +1,18 @@
+package schema
+
+import "errors"
+
+var ErrInvalidPoint = errors.New("lat must be within ±90 and lng within ±180")
+
+// Point is a WGS84 coordinate in decimal degrees.
+type Point struct {
+	Lat float64 `json:"lat"`
+	Lng float64 `json:"lng"`
+}
+
+func (p Point) Validate() error {
+	if p.Lat < -90 || p.Lat > 90 || p.Lng < -180 || p.Lng > 180 {
+		return ErrInvalidPoint
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package schema_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/kernkw/hhapp/internal/schema"
+	"github.com/kernkw/hhapp/internal/schema/schematest"
+)
+
+func TestMenuItem_Savings(t *testing.T) {
+	tests := []struct {
+		item schema.MenuItem
+		want *schema.Savings
+	}{
+		{schema.MenuItem{Price: schematest.USD(500)}, nil},
+		{schema.MenuItem{Price: schematest.USD(500), RegularPrice: schematest.USDPtr(0)}, nil},
+		{schema.MenuItem{Price: schematest.USD(500), RegularPrice: schematest.USDPtr(800)}, &schema.Savings{Amount: schematest.USD(300), Percent: 37.5}},
+		{schema.MenuItem{Price: schematest.USD(410), RegularPrice: schematest.USDPtr(650)}, &schema.Savings{Amount: schematest.USD(240), Percent: 36.9}},
+		{schema.MenuItem{Price: schematest.USD(600), RegularPrice: schematest.USDPtr(900)}, &schema.Savings{Amount: schematest.USD(300), Percent: 33.3}},
+		{schema.MenuItem{Price: schematest.USD(500), RegularPrice: &schema.Money{Amount: 800, Currency: "CAD"}}, nil},
+	}
+	for _, tt := range tests {
+		got := tt.item.Savings()
+		if (got == nil) != (tt.want == nil) || got != nil && *got != *tt.want {
+			t.Errorf("Savings(%v, %v): got %+v want %+v", tt.item.Price, tt.item.RegularPrice, got, tt.want)
+		}
+	}
+
+	b, err := json.Marshal(schema.MenuItem{ID: 1, Category: schema.CategoryDrink, Price: schematest.USD(500), RegularPrice: schematest.USDPtr(800)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"id":1,"menu_id":0,"category":"drink","price":{"amount":"5.00","currency":"USD"},"regular_price":{"amount":"8.00","currency":"USD"},"description":"","position":0,"savings":{"amount":"3.00","currency":"USD","percent":37.5}}`
+	if string(b) != expected {
+		t.Errorf("got %s want %s", b, expected)
+	}
+}
+
+func TestMenuItem_Validate_prices(t *testing.T) {
+	tests := []struct {
+		item  schema.MenuItem
+		field string
+	}{
+		{schema.MenuItem{Category: schema.CategoryFood, Price: schematest.USD(500), RegularPrice: schematest.USDPtr(500)}, ""},
+		{schema.MenuItem{Category: schema.CategoryFood, Price: schematest.USD(-100)}, "price"},
+		{schema.MenuItem{Category: schema.CategoryFood, Price: schema.Money{Amount: 500}}, "price"},
+		{schema.MenuItem{Category: schema.CategoryFood, Price: schematest.USD(500), RegularPrice: schematest.USDPtr(400)}, "regular_price"},
+		{schema.MenuItem{Category: schema.CategoryFood, Price: schematest.USD(500), RegularPrice: &schema.Money{Amount: 800, Currency: "EUR"}}, "regular_price"},
+	}
+	for _, tt := range tests {
+		err := tt.item.Validate()
+		if tt.field == "" {
+			if err != nil {
+				t.Errorf("Validate(%+v): %v", tt.item, err)
+			}
+			continue
+		}
+		if errs, ok := err.(schema.FieldErrors); !ok || errs[tt.field] == "" {
+			t.Errorf("Validate(%+v): got %v want an error for %s", tt.item, err, tt.field)
+		}
+	}
+}
+
+func TestMenuItem_InCurrency(t *testing.T) {
+	var item schema.MenuItem
+	if err := json.Unmarshal([]byte(`{"price":"1500","regular_price":2000}`), &item); err != nil {
+		t.Fatal(err)
+	}
+	got, err := item.InCurrency("JPY")
+	if err != nil || got.Price != (schema.Money{Amount: 1500, Currency: "JPY"}) || *got.RegularPrice != (schema.Money{Amount: 2000, Currency: "JPY"}) {
+		t.Errorf("InCurrency(JPY): got %+v %+v %v", got.Price, got.RegularPrice, err)
+	}
+
+	if err := json.Unmarshal([]byte(`{"price":{"amount":"4.50","currency":"gbp"},"regular_price":"6"}`), &item); err != nil {
+		t.Fatal(err)
+	}
+	got, err = item.InCurrency("USD")
+	if err != nil || got.Price != (schema.Money{Amount: 450, Currency: "GBP"}) || *got.RegularPrice != (schema.Money{Amount: 600, Currency: "GBP"}) {
+		t.Errorf("InCurrency(USD) with GBP prices: got %+v %+v %v", got.Price, got.RegularPrice, err)
+	}
+
+	if err := json.Unmarshal([]byte(`{"price":"4.505"}`), &item); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := item.InCurrency("USD"); err == nil {
+		t.Error("InCurrency accepted a fraction of a cent")
+	} else if errs, ok := err.(schema.FieldErrors); !ok || errs["price"] != schema.ErrAmountPrecision.Error() {
+		t.Errorf("got %v want a price error", err)
+	}
+}
@@ -0,0 +1,61 @@
+package schema
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Role grants a user access to a class of endpoints.
+type Role string
+
+const (
+	RoleAdmin      Role = "admin"
+	RoleVenueOwner Role = "venue_owner"
+	RolePatron     Role = "patron"
+)
+
+// ParseRole returns the Role named by s.
+func ParseRole(s string) (Role, error) {
+	switch r := Role(strings.ToLower(strings.TrimSpace(s))); r {
+	case RoleAdmin, RoleVenueOwner, RolePatron:
+		return r, nil
+	}
+	return "", fmt.Errorf("unknown role %q", s)
+}
+
+// ParseRoles parses the comma separated form roles are stored in.
+func ParseRoles(s string) ([]Role, error) {
+	var roles []Role
+	for _, name := range strings.Split(s, ",") {
+		if strings.TrimSpace(name) == "" {
+			continue
+		}
+		r, err := ParseRole(name)
+		if err != nil {
+			return nil, err
+		}
+		roles = append(roles, r)
+	}
+	return roles, nil
+}
+
+// JoinRoles returns roles in the comma separated form they are stored in.
+func JoinRoles(roles []Role) string {
+	names := make([]string, len(roles))
+	for i, r := range roles {
+		names[i] = string(r)
+	}
+	return strings.Join(names, ",")
+}
+
+// HasRole reports whether u has any of roles.
+func (u User) HasRole(roles ...Role) bool {
+	for _, have := range u.Roles {
+		for _, want := range roles {
+			if have == want {
+				return true
+			}
+		}
+	}
+	return false
+}
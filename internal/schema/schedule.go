@@ -0,0 +1,278 @@
+package schema
+
+import (
+	"errors"
+	"sort"
+	"time"
+)
+
+var (
+	ErrNoWeekdays         = errors.New("a schedule window needs at least one weekday")
+	ErrInvertedWindow     = errors.New("end_at must be after start_at; set overnight for a window that ends the next day")
+	ErrOverlappingWindows = errors.New("schedule windows overlap")
+	ErrExceptionKind      = errors.New("kind must be special or blackout")
+	ErrInvertedDates      = errors.New("end_date must not be before start_date")
+	ErrNoteTooLong        = errors.New("note must be at most 255 characters")
+)
+
+const (
+	day  = 24 * 60 * 60
+	week = 7 * day
+)
+
+// MenuDateTime is one window of a menu's weekly schedule: the menu is served
+// from StartAt to EndAt on each of the selected weekdays. An overnight
+// window starts on the selected day and ends at EndAt the following day.
+type MenuDateTime struct {
+	ID        int       `json:"id"`
+	MenuID    int       `json:"menu_id"`
+	Monday    bool      `json:"monday"`
+	Tuesday   bool      `json:"tuesday"`
+	Wednesday bool      `json:"wednesday"`
+	Thursday  bool      `json:"thursday"`
+	Friday    bool      `json:"friday"`
+	Saturday  bool      `json:"saturday"`
+	Sunday    bool      `json:"sunday"`
+	StartAt   TimeOfDay `json:"start_at"`
+	EndAt     TimeOfDay `json:"end_at"`
+	Overnight bool      `json:"overnight"`
+}
+
+// On reports whether the window opens on day.
+func (m MenuDateTime) On(day time.Weekday) bool {
+	return [...]bool{m.Sunday, m.Monday, m.Tuesday, m.Wednesday, m.Thursday, m.Friday, m.Saturday}[day]
+}
+
+// Validate checks the window on its own. ValidateSchedule also checks it
+// against the menu's other windows.
+func (m MenuDateTime) Validate() error {
+	if err := validateWindow(m.StartAt, m.EndAt, m.Overnight); err != nil {
+		return err
+	}
+	if len(m.spans()) == 0 {
+		return ErrNoWeekdays
+	}
+	return nil
+}
+
+// validateWindow checks the times of day a menu is served from and to.
+func validateWindow(start, end TimeOfDay, overnight bool) error {
+	if start < 0 || start >= EndOfDay || end < 0 || end > EndOfDay {
+		return ErrInvalidTimeOfDay
+	}
+	if (overnight && end >= start) || (!overnight && end <= start) {
+		return ErrInvertedWindow
+	}
+	return nil
+}
+
+// ActiveAt reports whether the window covers t. t should be in the venue's
+// local time.
+func (m MenuDateTime) ActiveAt(t time.Time) bool {
+	at := int(t.Weekday())*day + int(TimeOfDayOf(t))
+	for _, s := range m.spans() {
+		if s.contains(at) || s.contains(at+week) {
+			return true
+		}
+	}
+	return false
+}
+
+// span is a half-open interval in seconds after the start of Sunday.
+type span struct{ start, end int }
+
+func (s span) contains(at int) bool { return s.start <= at && at < s.end }
+
+// spans returns the times the window covers in a week. A span that runs
+// past the end of Saturday ends beyond week.
+func (m MenuDateTime) spans() []span {
+	var spans []span
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		if !m.On(d) {
+			continue
+		}
+		s := span{int(d)*day + int(m.StartAt), int(d)*day + int(m.EndAt)}
+		if m.Overnight {
+			s.end += day
+		}
+		spans = append(spans, s)
+	}
+	return spans
+}
+
+// ValidateSchedule checks each window of a menu's schedule and that no two
+// windows cover the same time.
+func ValidateSchedule(times []MenuDateTime) error {
+	var spans []span
+	for _, m := range times {
+		if err := m.Validate(); err != nil {
+			return err
+		}
+		spans = append(spans, m.spans()...)
+	}
+	for i, a := range spans {
+		for _, b := range spans[i+1:] {
+			if overlaps(a, b) || overlaps(a, span{b.start + week, b.end + week}) || overlaps(span{a.start + week, a.end + week}, b) {
+				return ErrOverlappingWindows
+			}
+		}
+	}
+	return nil
+}
+
+func overlaps(a, b span) bool { return a.start < b.end && b.start < a.end }
+
+// Occurrence is one concrete serving of a menu.
+type Occurrence struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+// Occurrences returns the servings of the window in loc that overlap the
+// interval from to to, in order.
+func (m MenuDateTime) Occurrences(from, to time.Time, loc *time.Location) []Occurrence {
+	return occurrences(from, to, loc, m.StartAt, m.EndAt, m.Overnight, func(day time.Time) bool {
+		return m.On(day.Weekday())
+	})
+}
+
+// occurrences returns the servings from start to end on each day in loc
+// that on accepts and that overlap the interval from to to, in order.
+func occurrences(from, to time.Time, loc *time.Location, start, end TimeOfDay, overnight bool, on func(day time.Time) bool) []Occurrence {
+	var occs []Occurrence
+	// Start a day early for an overnight window still open at from.
+	y, mo, d := from.In(loc).AddDate(0, 0, -1).Date()
+	for day := time.Date(y, mo, d, 0, 0, 0, 0, loc); day.Before(to); day = day.AddDate(0, 0, 1) {
+		if !on(day) {
+			continue
+		}
+		y, mo, d := day.Date()
+		s := time.Date(y, mo, d, 0, 0, int(start), 0, loc)
+		if overnight {
+			d++
+		}
+		e := time.Date(y, mo, d, 0, 0, int(end), 0, loc)
+		if e.After(from) && s.Before(to) {
+			occs = append(occs, Occurrence{s, e})
+		}
+	}
+	return occs
+}
+
+// ExceptionKind says how a MenuException changes a menu's weekly schedule.
+type ExceptionKind string
+
+const (
+	// ExceptionSpecial serves the menu at extra times on its dates.
+	ExceptionSpecial ExceptionKind = "special"
+	// ExceptionBlackout suppresses the weekly schedule on its dates.
+	ExceptionBlackout ExceptionKind = "blackout"
+)
+
+// MenuException changes a menu's schedule from StartDate to EndDate
+// inclusive. A special serves the menu from StartAt to EndAt on each of its
+// days, in addition to the weekly schedule; a blackout cancels the weekly
+// windows that open on its days and has no times. Specials are served even
+// on blackout days, so a venue can replace its usual hours on a holiday.
+type MenuException struct {
+	ID        int           `json:"id"`
+	MenuID    int           `json:"menu_id"`
+	Kind      ExceptionKind `json:"kind"`
+	StartDate Date          `json:"start_date"`
+	EndDate   Date          `json:"end_date"`
+	StartAt   TimeOfDay     `json:"start_at"`
+	EndAt     TimeOfDay     `json:"end_at"`
+	Overnight bool          `json:"overnight"`
+	Note      string        `json:"note"`
+}
+
+// Validate checks the exception's kind, dates and, for a special, times.
+func (e MenuException) Validate() error {
+	if e.StartDate.IsZero() || e.EndDate.IsZero() {
+		return ErrInvalidDate
+	}
+	if e.EndDate.Before(e.StartDate) {
+		return ErrInvertedDates
+	}
+	if len(e.Note) > 255 {
+		return ErrNoteTooLong
+	}
+	switch e.Kind {
+	case ExceptionSpecial:
+		return validateWindow(e.StartAt, e.EndAt, e.Overnight)
+	case ExceptionBlackout:
+		return nil
+	}
+	return ErrExceptionKind
+}
+
+// Covers reports whether day is one of the exception's dates.
+func (e MenuException) Covers(day Date) bool {
+	return !day.Before(e.StartDate) && !e.EndDate.Before(day)
+}
+
+// Occurrences returns the servings of a special in loc that overlap the
+// interval from to to, in order. A blackout has none.
+func (e MenuException) Occurrences(from, to time.Time, loc *time.Location) []Occurrence {
+	if e.Kind != ExceptionSpecial {
+		return nil
+	}
+	return occurrences(from, to, loc, e.StartAt, e.EndAt, e.Overnight, func(day time.Time) bool {
+		return e.Covers(DateOf(day))
+	})
+}
+
+// MenuSchedule is a menu together with the venue serving it, the times it
+// is served each week and the dated exceptions to them.
+type MenuSchedule struct {
+	Venue      Venue
+	MenuID     int
+	Times      []MenuDateTime
+	Exceptions []MenuException
+}
+
+// blackedOut reports whether the weekly schedule is suppressed on day.
+func (s MenuSchedule) blackedOut(day Date) bool {
+	for _, e := range s.Exceptions {
+		if e.Kind == ExceptionBlackout && e.Covers(day) {
+			return true
+		}
+	}
+	return false
+}
+
+// Occurrences returns the servings of the menu in loc that overlap the
+// interval from to to, in order. Weekly windows opening on a blackout day
+// are left out, specials are added, and servings that overlap are merged.
+func (s MenuSchedule) Occurrences(from, to time.Time, loc *time.Location) []Occurrence {
+	var occs []Occurrence
+	for _, dt := range s.Times {
+		for _, o := range dt.Occurrences(from, to, loc) {
+			if !s.blackedOut(DateOf(o.Start)) {
+				occs = append(occs, o)
+			}
+		}
+	}
+	for _, e := range s.Exceptions {
+		occs = append(occs, e.Occurrences(from, to, loc)...)
+	}
+	sort.Slice(occs, func(i, j int) bool { return occs[i].Start.Before(occs[j].Start) })
+
+	var merged []Occurrence
+	for _, o := range occs {
+		if n := len(merged); n > 0 && o.Start.Before(merged[n-1].End) {
+			if o.End.After(merged[n-1].End) {
+				merged[n-1].End = o.End
+			}
+			continue
+		}
+		merged = append(merged, o)
+	}
+	return merged
+}
+
+// ActiveAt reports whether the menu is being served at t. t should be in
+// the venue's local time.
+func (s MenuSchedule) ActiveAt(t time.Time) bool {
+	return len(s.Occurrences(t, t.Add(time.Nanosecond), t.Location())) > 0
+}
@@ -0,0 +1,292 @@
+package schema
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestMenuDateTime_ActiveAt(t *testing.T) {
+	weekdays := MenuDateTime{
+		Monday: true, Tuesday: true, Wednesday: true, Thursday: true, Friday: true,
+		StartAt: 15 * 60 * 60, EndAt: 18 * 60 * 60,
+	}
+	// 2018-03-02 was a Friday.
+	tests := []struct {
+		at   time.Time
+		want bool
+	}{
+		{time.Date(2018, 3, 2, 14, 59, 59, 0, time.UTC), false},
+		{time.Date(2018, 3, 2, 15, 0, 0, 0, time.UTC), true},
+		{time.Date(2018, 3, 2, 17, 59, 59, 0, time.UTC), true},
+		{time.Date(2018, 3, 2, 18, 0, 0, 0, time.UTC), false},
+		{time.Date(2018, 3, 3, 16, 0, 0, 0, time.UTC), false},
+	}
+	for _, tt := range tests {
+		if got := weekdays.ActiveAt(tt.at); got != tt.want {
+			t.Errorf("ActiveAt(%v): got %v want %v", tt.at, got, tt.want)
+		}
+	}
+
+	// The weekday and time of day are read in t's location.
+	denver, err := time.LoadLocation("America/Denver")
+	if err != nil {
+		t.Skip(err)
+	}
+	at := time.Date(2018, 3, 3, 0, 30, 0, 0, time.UTC) // Friday 17:30 in Denver
+	if !weekdays.ActiveAt(at.In(denver)) || weekdays.ActiveAt(at) {
+		t.Errorf("ActiveAt ignored the location of %v", at)
+	}
+}
+
+func TestMenuDateTime_ActiveAt_overnight(t *testing.T) {
+	// Friday and Saturday nights, 22:00 to 02:00.
+	late := MenuDateTime{Friday: true, Saturday: true, StartAt: 22 * 60 * 60, EndAt: 2 * 60 * 60, Overnight: true}
+	tests := []struct {
+		at   time.Time
+		want bool
+	}{
+		{time.Date(2018, 3, 2, 21, 59, 0, 0, time.UTC), false}, // Friday
+		{time.Date(2018, 3, 2, 23, 0, 0, 0, time.UTC), true},
+		{time.Date(2018, 3, 3, 1, 59, 0, 0, time.UTC), true}, // Saturday
+		{time.Date(2018, 3, 3, 2, 0, 0, 0, time.UTC), false},
+		{time.Date(2018, 3, 4, 1, 0, 0, 0, time.UTC), true}, // Sunday, wrapping the week
+		{time.Date(2018, 3, 5, 1, 0, 0, 0, time.UTC), false},
+	}
+	for _, tt := range tests {
+		if got := late.ActiveAt(tt.at); got != tt.want {
+			t.Errorf("ActiveAt(%v): got %v want %v", tt.at, got, tt.want)
+		}
+	}
+}
+
+func TestValidateSchedule(t *testing.T) {
+	hours := func(h int) TimeOfDay { return TimeOfDay(h * 60 * 60) }
+	weekdays := MenuDateTime{Monday: true, Tuesday: true, Wednesday: true, Thursday: true, Friday: true, StartAt: hours(15), EndAt: hours(18)}
+	sunday := MenuDateTime{Sunday: true, StartAt: 0, EndAt: EndOfDay}
+	tests := []struct {
+		name  string
+		times []MenuDateTime
+		want  error
+	}{
+		{"weekdays and all day sunday", []MenuDateTime{weekdays, sunday}, nil},
+		{"back to back", []MenuDateTime{weekdays, {Monday: true, StartAt: hours(18), EndAt: hours(20)}}, nil},
+		{"no weekdays", []MenuDateTime{{StartAt: hours(15), EndAt: hours(18)}}, ErrNoWeekdays},
+		{"inverted", []MenuDateTime{{Monday: true, StartAt: hours(18), EndAt: hours(15)}}, ErrInvertedWindow},
+		{"empty", []MenuDateTime{{Monday: true, StartAt: hours(18), EndAt: hours(18)}}, ErrInvertedWindow},
+		{"overnight that isn't", []MenuDateTime{{Monday: true, StartAt: hours(15), EndAt: hours(18), Overnight: true}}, ErrInvertedWindow},
+		{"out of range", []MenuDateTime{{Monday: true, StartAt: hours(15), EndAt: hours(25)}}, ErrInvalidTimeOfDay},
+		{"same day overlap", []MenuDateTime{weekdays, {Friday: true, StartAt: hours(17), EndAt: hours(19)}}, ErrOverlappingWindows},
+		{"overnight into the next day", []MenuDateTime{weekdays, {Sunday: true, StartAt: hours(22), EndAt: hours(16), Overnight: true}}, ErrOverlappingWindows},
+		{"saturday night into sunday", []MenuDateTime{sunday, {Saturday: true, StartAt: hours(22), EndAt: hours(1), Overnight: true}}, ErrOverlappingWindows},
+		{"saturday night until sunday", []MenuDateTime{{Sunday: true, StartAt: hours(1), EndAt: hours(3)}, {Saturday: true, StartAt: hours(22), EndAt: hours(1), Overnight: true}}, nil},
+	}
+	for _, tt := range tests {
+		if got := ValidateSchedule(tt.times); got != tt.want {
+			t.Errorf("%s: got %v want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestTimeOfDay_JSON(t *testing.T) {
+	tests := []struct {
+		in   string
+		want TimeOfDay
+		err  bool
+	}{
+		{`"15:30"`, 15*60*60 + 30*60, false},
+		{`"00:00"`, 0, false},
+		{`"24:00"`, EndOfDay, false},
+		{`"24:01"`, 0, true},
+		{`"15:60"`, 0, true},
+		{`"3pm"`, 0, true},
+		{`900`, 0, true},
+	}
+	for _, tt := range tests {
+		var got TimeOfDay
+		err := json.Unmarshal([]byte(tt.in), &got)
+		if (err != nil) != tt.err || got != tt.want {
+			t.Errorf("Unmarshal(%s): got %v, %v want %v", tt.in, got, err, tt.want)
+			continue
+		}
+		if tt.err {
+			continue
+		}
+		b, err := json.Marshal(got)
+		if err != nil || string(b) != tt.in {
+			t.Errorf("Marshal(%v): got %s, %v want %s", got, b, err, tt.in)
+		}
+	}
+}
+
+func TestParseTimeOfDay(t *testing.T) {
+	for in, want := range map[string]TimeOfDay{
+		"15:30":    15*60*60 + 30*60,
+		"15:30:20": 15*60*60 + 30*60 + 20,
+		"24:00:00": EndOfDay,
+	} {
+		if got, err := ParseTimeOfDay(in); err != nil || got != want {
+			t.Errorf("ParseTimeOfDay(%q): got %v, %v want %v", in, got, err, want)
+		}
+	}
+}
+
+func TestMenuDateTime_Occurrences(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip(err)
+	}
+	hours := func(h int) TimeOfDay { return TimeOfDay(h * 60 * 60) }
+	at := func(s string) time.Time {
+		v, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return v
+	}
+	weekdays := MenuDateTime{Monday: true, Tuesday: true, Wednesday: true, Thursday: true, Friday: true, StartAt: hours(16), EndAt: hours(18)}
+	saturdayNight := MenuDateTime{Saturday: true, StartAt: hours(22), EndAt: hours(3), Overnight: true}
+
+	tests := []struct {
+		name     string
+		m        MenuDateTime
+		from, to string
+		want     []string
+	}{
+		{"across spring forward", weekdays, "2018-03-09T00:00:00-05:00", "2018-03-13T00:00:00-04:00", []string{
+			"2018-03-09T16:00:00-05:00", "2018-03-12T16:00:00-04:00",
+		}},
+		{"already started", weekdays, "2018-03-09T17:00:00-05:00", "2018-03-10T00:00:00-05:00", []string{
+			"2018-03-09T16:00:00-05:00",
+		}},
+		{"already ended", weekdays, "2018-03-09T18:00:00-05:00", "2018-03-10T00:00:00-05:00", nil},
+		{"overnight still open at from", saturdayNight, "2018-03-11T01:00:00-05:00", "2018-03-12T00:00:00-04:00", []string{
+			"2018-03-10T22:00:00-05:00",
+		}},
+	}
+	for _, tt := range tests {
+		got := tt.m.Occurrences(at(tt.from), at(tt.to), ny)
+		if len(got) != len(tt.want) {
+			t.Errorf("%s: got %v want %v", tt.name, got, tt.want)
+			continue
+		}
+		for i, o := range got {
+			if !o.Start.Equal(at(tt.want[i])) {
+				t.Errorf("%s: occurrence %v starts %v want %v", tt.name, i, o.Start, tt.want[i])
+			}
+		}
+	}
+
+	// The overnight window over the skipped hour is an hour short.
+	occs := saturdayNight.Occurrences(at("2018-03-10T00:00:00Z"), at("2018-03-12T00:00:00Z"), ny)
+	if len(occs) != 1 || occs[0].End.Sub(occs[0].Start) != 4*time.Hour {
+		t.Errorf("got %v, want one four hour occurrence", occs)
+	}
+}
+
+func TestMenuException_Validate(t *testing.T) {
+	hours := func(h int) TimeOfDay { return TimeOfDay(h * 60 * 60) }
+	day := Date{2018, time.February, 4}
+	tests := []struct {
+		name string
+		e    MenuException
+		want error
+	}{
+		{"special", MenuException{Kind: ExceptionSpecial, StartDate: day, EndDate: day, StartAt: hours(16), EndAt: hours(23)}, nil},
+		{"overnight special", MenuException{Kind: ExceptionSpecial, StartDate: day, EndDate: day, StartAt: hours(22), EndAt: hours(2), Overnight: true}, nil},
+		{"blackout", MenuException{Kind: ExceptionBlackout, StartDate: day, EndDate: Date{2018, time.February, 5}}, nil},
+		{"no kind", MenuException{StartDate: day, EndDate: day}, ErrExceptionKind},
+		{"no dates", MenuException{Kind: ExceptionBlackout}, ErrInvalidDate},
+		{"inverted dates", MenuException{Kind: ExceptionBlackout, StartDate: day, EndDate: Date{2018, time.January, 31}}, ErrInvertedDates},
+		{"inverted special", MenuException{Kind: ExceptionSpecial, StartDate: day, EndDate: day, StartAt: hours(23), EndAt: hours(16)}, ErrInvertedWindow},
+	}
+	for _, tt := range tests {
+		if got := tt.e.Validate(); got != tt.want {
+			t.Errorf("%s: got %v want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestMenuSchedule_exceptions(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip(err)
+	}
+	hours := func(h int) TimeOfDay { return TimeOfDay(h * 60 * 60) }
+	date := func(s string) Date {
+		d, err := ParseDate(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return d
+	}
+	local := func(s string) time.Time {
+		v, err := time.ParseInLocation("2006-01-02 15:04", s, ny)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return v
+	}
+	daily := MenuDateTime{
+		Monday: true, Tuesday: true, Wednesday: true, Thursday: true, Friday: true, Saturday: true, Sunday: true,
+		StartAt: hours(16), EndAt: hours(18),
+	}
+	s := MenuSchedule{
+		Times: []MenuDateTime{daily},
+		Exceptions: []MenuException{
+			{Kind: ExceptionBlackout, StartDate: date("2018-12-24"), EndDate: date("2018-12-25")},
+			// A special on a blackout day is still served.
+			{Kind: ExceptionSpecial, StartDate: date("2018-12-24"), EndDate: date("2018-12-24"), StartAt: hours(12), EndAt: hours(14)},
+			// Overlapping the weekly window, it extends that day's serving.
+			{Kind: ExceptionSpecial, StartDate: date("2018-12-27"), EndDate: date("2018-12-27"), StartAt: hours(17), EndAt: hours(22)},
+		},
+	}
+
+	got := s.Occurrences(local("2018-12-23 00:00"), local("2018-12-28 00:00"), ny)
+	want := []Occurrence{
+		{local("2018-12-23 16:00"), local("2018-12-23 18:00")},
+		{local("2018-12-24 12:00"), local("2018-12-24 14:00")},
+		{local("2018-12-26 16:00"), local("2018-12-26 18:00")},
+		{local("2018-12-27 16:00"), local("2018-12-27 22:00")},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %v want %v", got, want)
+	}
+	for i := range got {
+		if !got[i].Start.Equal(want[i].Start) || !got[i].End.Equal(want[i].End) {
+			t.Errorf("occurrence %d: got %v want %v", i, got[i], want[i])
+		}
+	}
+
+	tests := []struct {
+		at   string
+		want bool
+	}{
+		{"2018-12-23 17:00", true},
+		{"2018-12-24 13:00", true},
+		{"2018-12-24 17:00", false},
+		{"2018-12-25 17:00", false},
+		{"2018-12-27 21:00", true},
+		{"2018-12-28 21:00", false},
+	}
+	for _, tt := range tests {
+		if got := s.ActiveAt(local(tt.at)); got != tt.want {
+			t.Errorf("ActiveAt(%s): got %v want %v", tt.at, got, tt.want)
+		}
+	}
+}
+
+func TestDate_JSON(t *testing.T) {
+	var d Date
+	if err := json.Unmarshal([]byte(`"2018-02-04"`), &d); err != nil || d != (Date{2018, time.February, 4}) {
+		t.Errorf("got %v %v", d, err)
+	}
+	if b, err := json.Marshal(d); err != nil || string(b) != `"2018-02-04"` {
+		t.Errorf("got %s %v", b, err)
+	}
+	for _, in := range []string{`"2018-02-30"`, `"02/04/2018"`, `20180204`} {
+		if err := json.Unmarshal([]byte(in), &d); err != ErrInvalidDate {
+			t.Errorf("Unmarshal(%s): got %v want %v", in, err, ErrInvalidDate)
+		}
+	}
+}
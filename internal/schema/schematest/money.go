@@ -0,0 +1,15 @@
+// Package schematest provides shorthand for building schema values in tests.
+package schematest
+
+import "github.com/kernkw/hhapp/internal/schema"
+
+// USD returns an amount of US cents.
+func USD(cents int64) schema.Money {
+	return schema.Money{Amount: cents, Currency: "USD"}
+}
+
+// USDPtr returns USD(cents) as an optional price.
+func USDPtr(cents int64) *schema.Money {
+	m := USD(cents)
+	return &m
+}
@@ -0,0 +1,60 @@
+package schema
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var ErrInvalidTimeOfDay = errors.New(`time of day must be "HH:MM" between 00:00 and 24:00`)
+
+// TimeOfDay is a wall-clock time in seconds after midnight. It is written
+// as "HH:MM" in JSON; 24:00 is the end of the day.
+type TimeOfDay int
+
+const EndOfDay = TimeOfDay(24 * 60 * 60)
+
+// TimeOfDayOf returns the wall-clock time of t in t's location.
+func TimeOfDayOf(t time.Time) TimeOfDay {
+	h, m, s := t.Clock()
+	return TimeOfDay(h*3600 + m*60 + s)
+}
+
+func (d TimeOfDay) String() string {
+	return fmt.Sprintf("%02d:%02d", int(d)/3600, int(d)%3600/60)
+}
+
+func (d TimeOfDay) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+func (d *TimeOfDay) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return ErrInvalidTimeOfDay
+	}
+	v, err := ParseTimeOfDay(s)
+	if err != nil {
+		return err
+	}
+	*d = v
+	return nil
+}
+
+// ParseTimeOfDay parses "HH:MM" or the "HH:MM:SS" form MySQL returns for
+// TIME columns.
+func ParseTimeOfDay(s string) (TimeOfDay, error) {
+	if s == "24:00" || s == "24:00:00" {
+		return EndOfDay, nil
+	}
+	layout := "15:04"
+	if len(s) > 5 {
+		layout = "15:04:05"
+	}
+	t, err := time.Parse(layout, s)
+	if err != nil {
+		return 0, ErrInvalidTimeOfDay
+	}
+	return TimeOfDayOf(t), nil
+}
@@ -9,30 +9,41 @@ import (
 )
 
 type User struct {
-	ID        int    `json:"id"`
-	UserName  string `json:"username"`
-	Password  string `json:"password,omitempty"`
-	Email     string `json:"email"`
-	FirstName string `json:"first_name,omitempty"`
-	LastName  string `json:"last_name,omitempty"`
+	ID               int    `json:"id"`
+	UserName         string `json:"username"`
+	Password         string `json:"password,omitempty"`
+	Email            string `json:"email"`
+	EmailVerified    bool   `json:"email_verified"`
+	TwoFactorEnabled bool   `json:"two_factor_enabled"`
+	FirstName        string `json:"first_name,omitempty"`
+	LastName         string `json:"last_name,omitempty"`
+	Roles            []Role `json:"roles,omitempty"`
+	// TokenGeneration is bumped whenever the user's outstanding access
+	// tokens should stop working.
+	TokenGeneration int `json:"-"`
 }
 
 type UserNotifications struct {
 	ID             int    `json:"id"`
-	UserID         string `json:"user_id"`
-	NotificationID string `json:"notification_id"`
+	UserID         int    `json:"user_id"`
+	NotificationID int    `json:"notification_id"`
 	Email          string `json:"email"`
 }
 
 type UserFavorite struct {
-	ID      int    `json:"id"`
-	UserID  string `json:"user_id"`
-	VenueID int    `json:"venue_id"`
+	ID      int `json:"id"`
+	UserID  int `json:"user_id"`
+	VenueID int `json:"venue_id"`
 }
 
-func (u *User) HashPassword() error {
+// HashPassword replaces u.Password with its bcrypt hash. A cost below
+// bcrypt.MinCost means bcrypt.DefaultCost.
+func (u *User) HashPassword(cost int) error {
+	if cost < bcrypt.MinCost {
+		cost = bcrypt.DefaultCost
+	}
 	// Generate "hash" to store from user password
-	hash, err := bcrypt.GenerateFromPassword([]byte(u.Password), bcrypt.DefaultCost)
+	hash, err := bcrypt.GenerateFromPassword([]byte(u.Password), cost)
 	if err != nil {
 		fmt.Println(err)
 		return err
@@ -51,23 +62,38 @@ func (u *User) Authorized(user User) bool {
 	return true
 }
 
-func (u User) Validate() error {
-	var errStr string
+// NeedsRehash reports whether u.Password is a hash made with a cost other
+// than cost, so it should be replaced the next time the plain password is
+// known.
+func (u User) NeedsRehash(cost int) bool {
+	if cost < bcrypt.MinCost {
+		cost = bcrypt.DefaultCost
+	}
+	c, err := bcrypt.Cost([]byte(u.Password))
+	return err == nil && c != cost
+}
+
+// Validate checks a new user, returning FieldErrors describing every field
+// that is missing or breaks p.
+func (u User) Validate(p PasswordPolicy) error {
+	errs := FieldErrors{}
 
 	reqStrFields := make(map[string]string)
 	reqStrFields["username"] = u.UserName
-	reqStrFields["password"] = u.Password
 	reqStrFields["email"] = u.Email
 
 	for k, v := range reqStrFields {
 		nes := nonEmptyString(k, v)
 		if nes != "" {
-			errStr += nes
+			errs[k] = strings.TrimSuffix(nes, " ")
 		}
 	}
+	if msg := p.Check(u.Password, u); msg != "" {
+		errs["password"] = msg
+	}
 
-	if errStr != "" {
-		return errors.New(strings.TrimSuffix(errStr, " "))
+	if len(errs) > 0 {
+		return errs
 	}
 	return nil
 }
@@ -79,6 +105,11 @@ func nonEmptyString(key, value string) string {
 	return ""
 }
 
+// RequiredFieldError returns the error reported when field is missing.
+func RequiredFieldError(field string) error {
+	return errors.New(strings.TrimSuffix(requiredFieldMessage(field), " "))
+}
+
 func requiredFieldMessage(field string) string {
 	return fmt.Sprintf("%s is a required field. ", field)
 }
@@ -10,6 +10,25 @@ type Venue struct {
 	Zip      string `json:"zip"`
 	Country  string `json:"country"`
 	Image    string `json:"image"`
+	OwnerID  int    `json:"owner_id,omitempty"`
+	Location *Point `json:"location,omitempty"`
+	// TimeZone is the IANA zone the venue's schedules are kept in.
+	TimeZone string `json:"time_zone,omitempty"`
+}
+
+// VenueFilter narrows a venue search. Zero fields match every venue; a
+// non-nil IDs limits the search to those venues. Min and Max are the
+// corners of a bounding box and are set together. Category keeps the
+// venues, and in schedule searches the menus, serving an item that
+// matches it.
+type VenueFilter struct {
+	IDs      []int
+	City     string
+	Min      *Point
+	Max      *Point
+	Category Category
+	// MenuType restricts a search of menus to those of the type.
+	MenuType MenuType
 }
 
 type VenueList struct {
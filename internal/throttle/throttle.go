@@ -0,0 +1,261 @@
+// Package throttle slows down and locks out repeated failed login attempts.
+package throttle
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kernkw/hhapp/internal/config"
+)
+
+var ErrThrottled = errors.New("too many failed login attempts, try again later")
+var ErrLocked = errors.New("login temporarily locked after too many failed attempts")
+
+// Policy controls when a key is delayed and when it is locked out.
+type Policy struct {
+	// MaxAttempts failures within Window lock the key for Lockout.
+	MaxAttempts int
+	Window      time.Duration
+	Lockout     time.Duration
+	// Each failure below MaxAttempts blocks the key for BaseDelay doubled
+	// per previous failure, up to MaxDelay.
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+}
+
+type entry struct {
+	failures int
+	// pending counts attempts Allow let through whose outcome isn't known
+	// yet.
+	pending     int
+	first       time.Time
+	nextAllowed time.Time
+	lockedUntil time.Time
+}
+
+// Limiter tracks failures per key in memory.
+type Limiter struct {
+	mu      sync.Mutex
+	policy  Policy
+	entries map[string]*entry
+	now     func() time.Time
+	checks  int
+}
+
+func NewLimiter(p Policy) *Limiter {
+	return &Limiter{policy: p, entries: make(map[string]*entry), now: time.Now}
+}
+
+// Allow reports whether key may attempt a login now and, when it may,
+// reserves the attempt until Fail or Release settles it. Reserved attempts
+// count as failures towards MaxAttempts, and while one is outstanding a
+// key with a delay is not let through again, so a burst of concurrent
+// attempts gets no further than the same attempts made one at a time. When
+// key may not attempt a login, the returned duration is how long the
+// caller should wait and the error is ErrLocked or ErrThrottled.
+func (l *Limiter) Allow(key string) (time.Duration, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	l.sweep(now)
+
+	e := l.current(key, now)
+	if e == nil {
+		e = &entry{first: now}
+		l.entries[key] = e
+	}
+	if now.Before(e.lockedUntil) {
+		return e.lockedUntil.Sub(now), ErrLocked
+	}
+	if now.Before(e.nextAllowed) {
+		return e.nextAllowed.Sub(now), ErrThrottled
+	}
+	if e.pending > 0 && (l.policy.BaseDelay > 0 ||
+		l.policy.MaxAttempts > 0 && e.failures+e.pending >= l.policy.MaxAttempts) {
+		return l.delay(e.failures + 1), ErrThrottled
+	}
+	e.pending++
+	return 0, nil
+}
+
+// Fail records a failed attempt for key, settling one it reserved.
+func (l *Limiter) Fail(key string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+
+	e := l.current(key, now)
+	if e == nil {
+		e = &entry{first: now}
+		l.entries[key] = e
+	}
+	if e.pending > 0 {
+		e.pending--
+	}
+	e.failures++
+	if l.policy.MaxAttempts > 0 && e.failures >= l.policy.MaxAttempts {
+		e.lockedUntil = now.Add(l.policy.Lockout)
+		// Start counting afresh once the lockout ends.
+		e.failures = 0
+		e.first = e.lockedUntil
+		return
+	}
+	e.nextAllowed = now.Add(l.delay(e.failures))
+}
+
+// Release settles an attempt reserved for key without counting it as a
+// failure.
+func (l *Limiter) Release(key string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if e, ok := l.entries[key]; ok && e.pending > 0 {
+		e.pending--
+	}
+}
+
+// Reset forgets all failures recorded for key.
+func (l *Limiter) Reset(key string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.entries, key)
+}
+
+func (l *Limiter) delay(failures int) time.Duration {
+	d := l.policy.BaseDelay
+	for i := 1; i < failures && d < l.policy.MaxDelay; i++ {
+		d *= 2
+	}
+	if d > l.policy.MaxDelay {
+		d = l.policy.MaxDelay
+	}
+	return d
+}
+
+// current returns key's entry, discarding it if it has gone stale.
+func (l *Limiter) current(key string, now time.Time) *entry {
+	e, ok := l.entries[key]
+	if !ok {
+		return nil
+	}
+	if l.stale(e, now) {
+		delete(l.entries, key)
+		return nil
+	}
+	return e
+}
+
+func (l *Limiter) stale(e *entry, now time.Time) bool {
+	return e.pending == 0 && !now.Before(e.lockedUntil) && !now.Before(e.nextAllowed) && now.Sub(e.first) >= l.policy.Window
+}
+
+// sweep occasionally drops stale entries so the map doesn't grow without
+// bound under a distributed attack.
+func (l *Limiter) sweep(now time.Time) {
+	l.checks++
+	if l.checks%1000 != 0 {
+		return
+	}
+	for k, e := range l.entries {
+		if l.stale(e, now) {
+			delete(l.entries, k)
+		}
+	}
+}
+
+// Guard applies separate limits per username, or email address, and per
+// client IP.
+type Guard struct {
+	users *Limiter
+	ips   *Limiter
+}
+
+func NewGuard(cfg *config.Config) *Guard {
+	p := Policy{
+		MaxAttempts: cfg.LoginMaxAttempts,
+		Window:      cfg.LoginWindow,
+		Lockout:     cfg.LoginLockout,
+		BaseDelay:   cfg.LoginBaseDelay,
+		MaxDelay:    cfg.LoginMaxDelay,
+	}
+	// Many users can share an address, so IPs are only ever locked out,
+	// never delayed after a single failure.
+	ip := p
+	ip.MaxAttempts = cfg.LoginMaxAttemptsPerIP
+	ip.BaseDelay = 0
+	return &Guard{users: NewLimiter(p), ips: NewLimiter(ip)}
+}
+
+// NewPasswordForgotGuard returns a Guard limiting password reset requests
+// per email address and per client IP. Each request may send an email, so
+// the caller counts every one with Fail.
+func NewPasswordForgotGuard(cfg *config.Config) *Guard {
+	p := Policy{
+		MaxAttempts: cfg.PasswordForgotMaxAttempts,
+		Window:      cfg.PasswordForgotWindow,
+		Lockout:     cfg.PasswordForgotWindow,
+	}
+	ip := p
+	ip.MaxAttempts = cfg.PasswordForgotMaxAttemptsPerIP
+	return &Guard{users: NewLimiter(p), ips: NewLimiter(ip)}
+}
+
+// Allow reports whether a login for username from ip may be attempted,
+// returning the longer wait when both are blocked. A lockout takes
+// precedence over a delay. An allowed attempt is reserved against both and
+// must be settled with Fail, Succeed or Release.
+func (g *Guard) Allow(username, ip string) (time.Duration, error) {
+	ud, uerr := g.users.Allow(userKey(username))
+	id, ierr := g.ips.Allow(ip)
+	if uerr == nil && ierr != nil {
+		g.users.Release(userKey(username))
+	}
+	if ierr == nil && uerr != nil {
+		g.ips.Release(ip)
+	}
+	switch {
+	case uerr == ErrLocked || ierr == ErrLocked:
+		return maxDuration(lockedWait(ud, uerr), lockedWait(id, ierr)), ErrLocked
+	case uerr != nil || ierr != nil:
+		return maxDuration(ud, id), ErrThrottled
+	}
+	return 0, nil
+}
+
+func (g *Guard) Fail(username, ip string) {
+	g.users.Fail(userKey(username))
+	g.ips.Fail(ip)
+}
+
+// Succeed clears the username's failures. The IP's failures are left to
+// expire on their own so one valid account can't be used to reset them.
+func (g *Guard) Succeed(username, ip string) {
+	g.users.Reset(userKey(username))
+	g.ips.Release(ip)
+}
+
+// Release settles an attempt that neither failed nor completed a login,
+// such as one that could not be checked or still needs a second factor.
+func (g *Guard) Release(username, ip string) {
+	g.users.Release(userKey(username))
+	g.ips.Release(ip)
+}
+
+func userKey(username string) string {
+	return strings.ToLower(strings.TrimSpace(username))
+}
+
+func lockedWait(d time.Duration, err error) time.Duration {
+	if err == ErrLocked {
+		return d
+	}
+	return 0
+}
+
+func maxDuration(a, b time.Duration) time.Duration {
+	if a > b {
+		return a
+	}
+	return b
+}
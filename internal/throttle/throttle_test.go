@@ -0,0 +1,148 @@
+package throttle
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kernkw/hhapp/internal/config"
+)
+
+type clock struct{ t time.Time }
+
+func (c *clock) now() time.Time          { return c.t }
+func (c *clock) advance(d time.Duration) { c.t = c.t.Add(d) }
+
+func TestLimiter_progressive_delay(t *testing.T) {
+	c := &clock{time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)}
+	l := NewLimiter(Policy{
+		MaxAttempts: 5,
+		Window:      time.Hour,
+		Lockout:     time.Hour,
+		BaseDelay:   time.Second,
+		MaxDelay:    3 * time.Second,
+	})
+	l.now = c.now
+
+	for _, want := range []time.Duration{time.Second, 2 * time.Second, 3 * time.Second} {
+		l.Fail("k")
+		wait, err := l.Allow("k")
+		if err != ErrThrottled || wait != want {
+			t.Errorf("got %v %v want %v %v", wait, err, want, ErrThrottled)
+		}
+		c.advance(wait)
+		if _, err := l.Allow("k"); err != nil {
+			t.Errorf("still blocked after waiting %v: %v", wait, err)
+		}
+	}
+}
+
+func TestLimiter_lockout(t *testing.T) {
+	c := &clock{time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)}
+	l := NewLimiter(Policy{MaxAttempts: 3, Window: time.Hour, Lockout: 10 * time.Minute})
+	l.now = c.now
+
+	for i := 0; i < 3; i++ {
+		l.Fail("k")
+	}
+	wait, err := l.Allow("k")
+	if err != ErrLocked || wait != 10*time.Minute {
+		t.Errorf("got %v %v want %v %v", wait, err, 10*time.Minute, ErrLocked)
+	}
+	if _, err := l.Allow("other"); err != nil {
+		t.Errorf("unrelated key blocked: %v", err)
+	}
+
+	c.advance(10 * time.Minute)
+	if _, err := l.Allow("k"); err != nil {
+		t.Errorf("still locked after lockout expired: %v", err)
+	}
+}
+
+func TestLimiter_window(t *testing.T) {
+	c := &clock{time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)}
+	l := NewLimiter(Policy{MaxAttempts: 3, Window: time.Minute, Lockout: time.Hour})
+	l.now = c.now
+
+	l.Fail("k")
+	l.Fail("k")
+	c.advance(time.Minute)
+	l.Fail("k")
+	if _, err := l.Allow("k"); err != nil {
+		t.Errorf("failures outside the window counted towards lockout: %v", err)
+	}
+}
+
+func TestLimiter_reset(t *testing.T) {
+	l := NewLimiter(Policy{MaxAttempts: 1, Window: time.Minute, Lockout: time.Hour})
+	l.Fail("k")
+	l.Reset("k")
+	if _, err := l.Allow("k"); err != nil {
+		t.Errorf("reset key still blocked: %v", err)
+	}
+}
+
+func TestLimiter_release(t *testing.T) {
+	l := NewLimiter(Policy{MaxAttempts: 3, Window: time.Minute, Lockout: time.Hour, BaseDelay: time.Second, MaxDelay: time.Second})
+	if _, err := l.Allow("k"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := l.Allow("k"); err != ErrThrottled {
+		t.Errorf("second attempt while the first is outstanding: got %v want %v", err, ErrThrottled)
+	}
+	l.Release("k")
+	if _, err := l.Allow("k"); err != nil {
+		t.Errorf("released attempt still blocks the key: %v", err)
+	}
+}
+
+func TestGuard_concurrent(t *testing.T) {
+	g := NewGuard(&config.Config{
+		LoginMaxAttempts:      3,
+		LoginMaxAttemptsPerIP: 5,
+		LoginWindow:           time.Minute,
+		LoginLockout:          time.Minute,
+	})
+
+	// Every attempt is checked before any has failed, as when a client
+	// fires a burst of logins at once.
+	const n = 20
+	var mu sync.Mutex
+	var wg, checked sync.WaitGroup
+	allowed := 0
+	wg.Add(n)
+	checked.Add(n)
+	for i := 0; i < n; i++ {
+		go func() {
+			defer wg.Done()
+			_, err := g.Allow("Test", "10.0.0.1")
+			checked.Done()
+			checked.Wait()
+			if err != nil {
+				return
+			}
+			mu.Lock()
+			allowed++
+			mu.Unlock()
+			g.Fail("test", "10.0.0.1")
+		}()
+	}
+	wg.Wait()
+
+	if allowed != 3 {
+		t.Errorf("%d of %d concurrent attempts allowed, want 3", allowed, n)
+	}
+	if _, err := g.Allow("test", "10.0.0.1"); err != ErrLocked {
+		t.Errorf("after the burst: got %v want %v", err, ErrLocked)
+	}
+}
+
+func TestGuard_succeed(t *testing.T) {
+	g := NewGuard(&config.Config{LoginMaxAttempts: 3, LoginMaxAttemptsPerIP: 1, LoginWindow: time.Minute, LoginLockout: time.Minute})
+	for i := 0; i < 3; i++ {
+		if _, err := g.Allow("test", "10.0.0.1"); err != nil {
+			t.Fatalf("attempt %d: %v", i, err)
+		}
+		g.Succeed("test", "10.0.0.1")
+	}
+}
@@ -0,0 +1,7 @@
+USE `happy_hour`;
+
+ALTER TABLE `user`
+  ADD COLUMN `email_verified_at` datetime DEFAULT NULL AFTER `email`;
+
+ALTER TABLE `user_notifications`
+  ADD UNIQUE INDEX `user_notification_unique` (`user_id`, `notification_id`);
@@ -0,0 +1,10 @@
+USE `happy_hour`;
+
+ALTER TABLE `user`
+  ADD COLUMN `roles` set('admin','venue_owner','patron') NOT NULL DEFAULT 'patron' AFTER `email_verified_at`;
+
+ALTER TABLE `venue`
+  ADD COLUMN `owner_id` int(11) DEFAULT NULL AFTER `image`,
+  ADD FOREIGN KEY (owner_id)
+        REFERENCES user(id)
+        ON DELETE SET NULL;
@@ -0,0 +1,15 @@
+USE `happy_hour`;
+
+-- user_favorites.user_id was a free-form varchar. Drop rows that can't
+-- reference a user before converting it to an int foreign key.
+DELETE FROM `user_favorites` WHERE `user_id` NOT REGEXP '^[0-9]+$';
+
+DELETE uf FROM `user_favorites` AS uf
+  LEFT JOIN `user` AS u ON u.id = CAST(uf.user_id AS UNSIGNED)
+  WHERE u.id IS NULL;
+
+ALTER TABLE `user_favorites`
+  MODIFY `user_id` int(11) NOT NULL,
+  ADD FOREIGN KEY (user_id)
+        REFERENCES user(id)
+        ON DELETE CASCADE;
@@ -0,0 +1,19 @@
+USE `happy_hour`;
+
+ALTER TABLE `user`
+  ADD COLUMN `totp_secret` varchar(64) DEFAULT NULL AFTER `roles`,
+  ADD COLUMN `totp_enabled_at` datetime DEFAULT NULL AFTER `totp_secret`;
+
+CREATE TABLE `recovery_code` (
+  `id` int(11) NOT NULL AUTO_INCREMENT,
+  `user_id` int(11) NOT NULL,
+  `code_hash` char(64) NOT NULL,
+  `used_at` datetime DEFAULT NULL,
+  `updated_at` datetime DEFAULT NULL,
+  `created_at` datetime DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE INDEX `recovery_code_unique` (`user_id`, `code_hash`),
+  FOREIGN KEY (user_id)
+        REFERENCES user(id)
+        ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8 COLLATE=utf8_unicode_ci;
@@ -0,0 +1,12 @@
+USE `happy_hour`;
+
+-- Deleting a venue cascades to user_favorites, which notification.favorites_id
+-- referenced without an ON DELETE rule, so the delete was refused for any
+-- favorite with a notification. Notifications for a favorite go with it.
+ALTER TABLE `notification`
+  DROP FOREIGN KEY `notification_ibfk_2`;
+
+ALTER TABLE `notification`
+  ADD FOREIGN KEY (favorites_id)
+        REFERENCES user_favorites(id)
+        ON DELETE CASCADE;
@@ -0,0 +1,8 @@
+USE `happy_hour`;
+
+-- Coordinates are filled in by the geocoder on create and update and are
+-- NULL for venues it couldn't place.
+ALTER TABLE `venue`
+  ADD COLUMN `latitude` decimal(9,6) DEFAULT NULL AFTER `country`,
+  ADD COLUMN `longitude` decimal(9,6) DEFAULT NULL AFTER `latitude`,
+  ADD KEY `venue_location_index` (`latitude`, `longitude`);
@@ -0,0 +1,19 @@
+USE `happy_hour`;
+
+-- A menu_datetime row is one window of a menu's weekly schedule. Times of
+-- day are stored as TIME so that 24:00 can close a window at midnight, and
+-- an overnight window ends at end_time on the following day.
+ALTER TABLE `menu_datetime`
+  CHANGE `sunday` `sun` tinyint(1) DEFAULT '0',
+  ADD COLUMN `start_time` time NOT NULL DEFAULT '00:00:00' AFTER `sun`,
+  ADD COLUMN `end_time` time NOT NULL DEFAULT '00:00:00' AFTER `start_time`,
+  ADD COLUMN `overnight` tinyint(1) NOT NULL DEFAULT '0' AFTER `end_time`;
+
+UPDATE `menu_datetime`
+  SET start_time = TIME(start_at),
+      end_time = TIME(end_at),
+      overnight = TIME(end_at) < TIME(start_at);
+
+ALTER TABLE `menu_datetime`
+  DROP COLUMN `start_at`,
+  DROP COLUMN `end_at`;
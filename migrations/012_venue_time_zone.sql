@@ -0,0 +1,6 @@
+USE `happy_hour`;
+
+-- The IANA time zone a venue's schedules are kept in. NULL venues use the
+-- app's HHAPP_VENUE_TIME_ZONE.
+ALTER TABLE `venue`
+  ADD COLUMN `time_zone` varchar(64) DEFAULT NULL AFTER `longitude`;
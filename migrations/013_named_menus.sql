@@ -0,0 +1,8 @@
+USE `happy_hour`;
+
+-- A venue may serve several menus, each with its own name, type and
+-- schedule. Existing menus become the venue's happy hour menu.
+ALTER TABLE `menu`
+  ADD COLUMN `name` varchar(100) COLLATE utf8_unicode_ci NOT NULL DEFAULT 'Happy Hour' AFTER `venue_id`,
+  ADD COLUMN `type` enum('happy_hour','late_night','brunch','other') COLLATE utf8_unicode_ci NOT NULL DEFAULT 'happy_hour' AFTER `name`,
+  ADD UNIQUE KEY `menu_venue_name_unique` (`venue_id`, `name`);
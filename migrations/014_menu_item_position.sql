@@ -0,0 +1,14 @@
+USE `happy_hour`;
+
+-- Items are listed in the order their venue sets, lowest position first.
+-- Existing items keep the order they were added in.
+ALTER TABLE `menu_item`
+  ADD COLUMN `position` int(11) NOT NULL DEFAULT '0' AFTER `description`,
+  ADD KEY `menu_item_menu_position` (`menu_id`, `position`);
+
+UPDATE `menu_item` AS mi
+  JOIN (SELECT a.id, COUNT(b.id) AS position
+          FROM menu_item AS a
+          JOIN menu_item AS b ON b.menu_id = a.menu_id AND b.id < a.id
+         GROUP BY a.id) AS earlier ON earlier.id = mi.id
+  SET mi.position = earlier.position;
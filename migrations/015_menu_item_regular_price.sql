@@ -0,0 +1,6 @@
+USE `happy_hour`;
+
+-- What an item costs outside happy hour, so that savings can be shown.
+-- NULL when the venue hasn't said.
+ALTER TABLE `menu_item`
+  ADD COLUMN `regular_price` double DEFAULT NULL AFTER `price`;
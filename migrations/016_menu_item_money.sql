@@ -0,0 +1,74 @@
+USE `happy_hour`;
+
+-- Prices become whole minor units (cents for USD) with the currency they are
+-- in, so that amounts like 4.10 are stored exactly. Existing prices are taken
+-- to be in the currency of their venue's country, as geo.Currency picks for
+-- new items, or in US dollars where the country isn't one it knows.
+ALTER TABLE `menu_item`
+  ADD COLUMN `price_minor` bigint NOT NULL DEFAULT '0' AFTER `regular_price`,
+  ADD COLUMN `regular_price_minor` bigint DEFAULT NULL AFTER `price_minor`,
+  ADD COLUMN `currency` char(3) COLLATE utf8_unicode_ci NOT NULL DEFAULT 'USD' AFTER `regular_price_minor`;
+
+UPDATE `menu_item`
+  JOIN `menu` ON `menu`.`id` = `menu_item`.`menu_id`
+  JOIN `venue` ON `venue`.`id` = `menu`.`venue_id`
+  SET `menu_item`.`currency` = CASE
+      WHEN LOWER(TRIM(`venue`.`country`)) IN ('ae') THEN 'AED'
+      WHEN LOWER(TRIM(`venue`.`country`)) IN ('ar') THEN 'ARS'
+      WHEN LOWER(TRIM(`venue`.`country`)) IN ('au') THEN 'AUD'
+      WHEN LOWER(TRIM(`venue`.`country`)) IN ('bh') THEN 'BHD'
+      WHEN LOWER(TRIM(`venue`.`country`)) IN ('br') THEN 'BRL'
+      WHEN LOWER(TRIM(`venue`.`country`)) IN ('ca', 'can', 'canada') THEN 'CAD'
+      WHEN LOWER(TRIM(`venue`.`country`)) IN ('ch') THEN 'CHF'
+      WHEN LOWER(TRIM(`venue`.`country`)) IN ('cl') THEN 'CLP'
+      WHEN LOWER(TRIM(`venue`.`country`)) IN ('cn') THEN 'CNY'
+      WHEN LOWER(TRIM(`venue`.`country`)) IN ('co') THEN 'COP'
+      WHEN LOWER(TRIM(`venue`.`country`)) IN ('cz') THEN 'CZK'
+      WHEN LOWER(TRIM(`venue`.`country`)) IN ('dk') THEN 'DKK'
+      WHEN LOWER(TRIM(`venue`.`country`)) IN ('at', 'be', 'cy', 'de', 'ee', 'es', 'fi', 'fr', 'gr', 'hr', 'ie', 'it', 'lt', 'lu', 'lv', 'mt', 'nl', 'pt', 'si', 'sk') THEN 'EUR'
+      WHEN LOWER(TRIM(`venue`.`country`)) IN ('gb', 'gbr', 'uk', 'united kingdom') THEN 'GBP'
+      WHEN LOWER(TRIM(`venue`.`country`)) IN ('hk') THEN 'HKD'
+      WHEN LOWER(TRIM(`venue`.`country`)) IN ('hu') THEN 'HUF'
+      WHEN LOWER(TRIM(`venue`.`country`)) IN ('id') THEN 'IDR'
+      WHEN LOWER(TRIM(`venue`.`country`)) IN ('il') THEN 'ILS'
+      WHEN LOWER(TRIM(`venue`.`country`)) IN ('in') THEN 'INR'
+      WHEN LOWER(TRIM(`venue`.`country`)) IN ('is') THEN 'ISK'
+      WHEN LOWER(TRIM(`venue`.`country`)) IN ('jp') THEN 'JPY'
+      WHEN LOWER(TRIM(`venue`.`country`)) IN ('kr') THEN 'KRW'
+      WHEN LOWER(TRIM(`venue`.`country`)) IN ('kw') THEN 'KWD'
+      WHEN LOWER(TRIM(`venue`.`country`)) IN ('mx', 'mex', 'mexico') THEN 'MXN'
+      WHEN LOWER(TRIM(`venue`.`country`)) IN ('my') THEN 'MYR'
+      WHEN LOWER(TRIM(`venue`.`country`)) IN ('no') THEN 'NOK'
+      WHEN LOWER(TRIM(`venue`.`country`)) IN ('nz') THEN 'NZD'
+      WHEN LOWER(TRIM(`venue`.`country`)) IN ('pe') THEN 'PEN'
+      WHEN LOWER(TRIM(`venue`.`country`)) IN ('ph') THEN 'PHP'
+      WHEN LOWER(TRIM(`venue`.`country`)) IN ('pl') THEN 'PLN'
+      WHEN LOWER(TRIM(`venue`.`country`)) IN ('se') THEN 'SEK'
+      WHEN LOWER(TRIM(`venue`.`country`)) IN ('sg') THEN 'SGD'
+      WHEN LOWER(TRIM(`venue`.`country`)) IN ('th') THEN 'THB'
+      WHEN LOWER(TRIM(`venue`.`country`)) IN ('tr') THEN 'TRY'
+      WHEN LOWER(TRIM(`venue`.`country`)) IN ('tw') THEN 'TWD'
+      WHEN LOWER(TRIM(`venue`.`country`)) IN ('us', 'united states', 'usa') THEN 'USD'
+      WHEN LOWER(TRIM(`venue`.`country`)) IN ('vn') THEN 'VND'
+      WHEN LOWER(TRIM(`venue`.`country`)) IN ('za') THEN 'ZAR'
+      ELSE 'USD'
+    END;
+
+-- Each currency has as many minor unit digits as schema.Money gives it.
+UPDATE `menu_item`
+  SET `price_minor` = ROUND(IFNULL(`price`, 0) * CASE
+        WHEN `currency` IN ('BHD', 'KWD') THEN 1000
+        WHEN `currency` IN ('CLP', 'ISK', 'JPY', 'KRW', 'VND') THEN 1
+        ELSE 100
+      END),
+      `regular_price_minor` = ROUND(`regular_price` * CASE
+        WHEN `currency` IN ('BHD', 'KWD') THEN 1000
+        WHEN `currency` IN ('CLP', 'ISK', 'JPY', 'KRW', 'VND') THEN 1
+        ELSE 100
+      END);
+
+ALTER TABLE `menu_item`
+  DROP COLUMN `price`,
+  DROP COLUMN `regular_price`,
+  CHANGE COLUMN `price_minor` `price` bigint NOT NULL DEFAULT '0',
+  CHANGE COLUMN `regular_price_minor` `regular_price` bigint DEFAULT NULL;
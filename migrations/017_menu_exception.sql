@@ -0,0 +1,23 @@
+USE `happy_hour`;
+
+-- Dated exceptions to a menu's weekly schedule, from start_date to end_date
+-- inclusive. A special serves the menu from start_time to end_time on each
+-- of its days; a blackout cancels the weekly windows opening on its days.
+CREATE TABLE `menu_exception` (
+  `id` int(11) NOT NULL AUTO_INCREMENT,
+  `menu_id` int(11) NOT NULL,
+  `kind` enum('special','blackout') COLLATE utf8_unicode_ci NOT NULL,
+  `start_date` date NOT NULL,
+  `end_date` date NOT NULL,
+  `start_time` time NOT NULL DEFAULT '00:00:00',
+  `end_time` time NOT NULL DEFAULT '00:00:00',
+  `overnight` tinyint(1) NOT NULL DEFAULT '0',
+  `note` varchar(255) COLLATE utf8_unicode_ci NOT NULL DEFAULT '',
+  `updated_at` datetime DEFAULT NULL,
+  `created_at` datetime DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  KEY `menu_exception_menu_dates` (`menu_id`, `start_date`),
+  FOREIGN KEY (menu_id)
+        REFERENCES menu(id)
+        ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8 COLLATE=utf8_unicode_ci;
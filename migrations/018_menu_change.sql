@@ -0,0 +1,25 @@
+USE `happy_hour`;
+
+-- The history of a venue's menu items, schedule windows and exceptions:
+-- each create, update and delete with who made it and the record's JSON
+-- before and after. menu_id is kept after the menu is deleted.
+CREATE TABLE `menu_change` (
+  `id` int(11) NOT NULL AUTO_INCREMENT,
+  `venue_id` int(11) NOT NULL,
+  `menu_id` int(11) NOT NULL,
+  `entity` enum('menu_item','schedule','exception') COLLATE utf8_unicode_ci NOT NULL,
+  `entity_id` int(11) NOT NULL,
+  `action` enum('create','update','delete') COLLATE utf8_unicode_ci NOT NULL,
+  `user_id` int(11) DEFAULT NULL,
+  `old_value` text COLLATE utf8_unicode_ci,
+  `new_value` text COLLATE utf8_unicode_ci,
+  `created_at` datetime NOT NULL,
+  PRIMARY KEY (`id`),
+  KEY `menu_change_venue_created` (`venue_id`, `created_at`),
+  FOREIGN KEY (venue_id)
+        REFERENCES venue(id)
+        ON DELETE CASCADE,
+  FOREIGN KEY (user_id)
+        REFERENCES user(id)
+        ON DELETE SET NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8 COLLATE=utf8_unicode_ci;
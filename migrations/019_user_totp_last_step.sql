@@ -0,0 +1,6 @@
+USE `happy_hour`;
+
+-- The time step of the last TOTP code accepted for each user. Codes for the
+-- same or an earlier step are rejected so one can't be replayed.
+ALTER TABLE `user`
+  ADD COLUMN `totp_last_step` bigint DEFAULT NULL AFTER `totp_enabled_at`;
@@ -0,0 +1,6 @@
+USE `happy_hour`;
+
+-- Menus' own creation, renaming and deletion join the history, so that a
+-- venue's menus can be rebuilt as they were at a past time.
+ALTER TABLE `menu_change`
+  MODIFY COLUMN `entity` enum('menu','menu_item','schedule','exception') COLLATE utf8_unicode_ci NOT NULL;
@@ -0,0 +1,7 @@
+USE `happy_hour`;
+
+-- A venue's menu history outlives the venue, so that what its menus said
+-- can still be looked up after it is deleted. venue_id stays indexed by
+-- menu_change_venue_created but no longer cascades.
+ALTER TABLE `menu_change`
+  DROP FOREIGN KEY `menu_change_ibfk_1`;
@@ -0,0 +1,6 @@
+USE `happy_hour`;
+
+-- Access tokens carry the generation they were issued under and are only
+-- accepted while it matches. Bumping it revokes every outstanding token.
+ALTER TABLE `user`
+  ADD COLUMN `token_generation` int(11) NOT NULL DEFAULT 0 AFTER `totp_last_step`;